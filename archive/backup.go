@@ -2,10 +2,14 @@ package archive
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/server/proto"
@@ -17,6 +21,11 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+const (
+	// checksumFileSuffix is the suffix of the file with the checksum of the single file archive
+	checksumFileSuffix = ".sha256"
+)
+
 // CreateBackup fetches blockchain data with the specific range via gRPC
 // and save this data as binary archive to given path
 func CreateBackup(
@@ -83,13 +92,17 @@ func CreateBackup(
 		return 0, 0, err
 	}
 
-	if err := writeMetadata(fs, logger, reqTo, reqToHash); err != nil {
+	// the checksum covers the whole archive, including the metadata
+	checksum := sha256.New()
+	writer := io.MultiWriter(fs, checksum)
+
+	if err := writeMetadata(writer, logger, reqTo, reqToHash); err != nil {
 		closeAndRemoveFile()
 
 		return 0, 0, err
 	}
 
-	resFrom, resTo, err := processExportStream(stream, logger, fs, from, reqTo)
+	resFrom, resTo, err := processExportStream(stream, logger, writer, from, reqTo)
 	if err != nil {
 		closeAndRemoveFile()
 
@@ -102,9 +115,40 @@ func CreateBackup(
 		return 0, 0, err
 	}
 
+	if err := writeChecksumFile(outPath, checksum.Sum(nil)); err != nil {
+		removeFile()
+
+		return 0, 0, err
+	}
+
 	return *resFrom, *resTo, nil
 }
 
+// writeChecksumFile writes the SHA-256 checksum of the archive next to it,
+// in the format of the sha256sum tool
+func writeChecksumFile(archivePath string, checksum []byte) error {
+	content := fmt.Sprintf("%s  %s\n", hex.EncodeToString(checksum), filepath.Base(archivePath))
+
+	return os.WriteFile(archivePath+checksumFileSuffix, []byte(content), 0644) //nolint:gosec
+}
+
+// verifyChecksumFile verifies the archive against its checksum file, if the archive has one
+func verifyChecksumFile(archivePath string) error {
+	content, err := os.ReadFile(archivePath + checksumFileSuffix)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return fmt.Errorf("invalid checksum file %s", archivePath+checksumFileSuffix)
+	}
+
+	return verifyChecksum(archivePath, fields[0])
+}
+
 func determineTo(ctx context.Context, clt proto.SystemClient, to *uint64) (uint64, types.Hash, error) {
 	status, err := clt.GetStatus(ctx, &emptypb.Empty{})
 	if err != nil {
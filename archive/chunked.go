@@ -0,0 +1,548 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// manifestFileName is the name of the file describing the chunks of the archive
+	manifestFileName = "manifest.json"
+
+	// manifestVersion is the current version of the archive manifest
+	manifestVersion = 1
+
+	// GzipCompression is the compression of the gzip compressed chunks
+	GzipCompression = "gzip"
+
+	tmpFileSuffix = ".tmp"
+)
+
+var (
+	errArchiveDiverged   = errors.New("the archive does not match the chain of the node")
+	errChecksumMismatch  = errors.New("checksum mismatch")
+	errUnknownCompressor = errors.New("unknown compression")
+)
+
+// Manifest describes the archive which is stored as a directory of chunk files
+type Manifest struct {
+	Version     uint64   `json:"version"`
+	Compression string   `json:"compression,omitempty"`
+	ChunkSize   uint64   `json:"chunkSize"`
+	Chunks      []*Chunk `json:"chunks"`
+}
+
+// Chunk is the file with the RLP encoded blocks of the given range
+type Chunk struct {
+	File     string     `json:"file"`
+	From     uint64     `json:"from"`
+	To       uint64     `json:"to"`
+	LastHash types.Hash `json:"lastHash"`
+	// Checksum is the hex encoded SHA-256 checksum of the chunk file
+	Checksum string `json:"checksum"`
+}
+
+// IsChunkedArchive returns true if the given path is an archive stored as a directory of chunks
+func IsChunkedArchive(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && info.IsDir()
+}
+
+// readManifest reads the manifest of the archive in the given directory,
+// it returns nil if the archive doesn't exist yet
+func readManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("invalid archive manifest: %w", err)
+	}
+
+	if manifest.Version != manifestVersion {
+		return nil, fmt.Errorf("unsupported archive manifest version %d", manifest.Version)
+	}
+
+	return manifest, nil
+}
+
+// write replaces the manifest in the given directory atomically
+func (m *Manifest) write(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, manifestFileName)
+
+	if err := os.WriteFile(path+tmpFileSuffix, data, 0644); err != nil { //nolint:gosec
+		return err
+	}
+
+	return os.Rename(path+tmpFileSuffix, path)
+}
+
+// latest returns the last block stored in the archive
+func (m *Manifest) latest() (*Chunk, bool) {
+	if len(m.Chunks) == 0 {
+		return nil, false
+	}
+
+	return m.Chunks[len(m.Chunks)-1], true
+}
+
+// CreateChunkedBackup fetches blockchain data with the specific range via gRPC and saves it
+// into the archive directory as chunk files of chunkSize blocks (zero means one chunk per backup).
+// If the archive already exists, the backup is incremental and continues after its latest block,
+// using the chunk size and the compression the archive was created with
+func CreateChunkedBackup(
+	conn *grpc.ClientConn,
+	logger hclog.Logger,
+	from uint64,
+	to *uint64,
+	outDir string,
+	chunkSize uint64,
+	compression string,
+) (uint64, uint64, error) {
+	if compression != "" && compression != GzipCompression {
+		return 0, 0, fmt.Errorf("%w: %s", errUnknownCompressor, compression)
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return 0, 0, err
+	}
+
+	manifest, err := readManifest(outDir)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if manifest == nil {
+		manifest = &Manifest{
+			Version:     manifestVersion,
+			Compression: compression,
+			ChunkSize:   chunkSize,
+			Chunks:      []*Chunk{},
+		}
+	}
+
+	// remove the chunk which was being written when the previous backup was interrupted
+	if err := removeTmpFiles(outDir); err != nil {
+		return 0, 0, err
+	}
+
+	signalCh := common.GetTerminationSignalCh()
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	go func() {
+		<-signalCh
+		logger.Info("Caught termination signal, shutting down...")
+		cancelFn()
+	}()
+
+	clt := proto.NewSystemClient(conn)
+
+	if latest, ok := manifest.latest(); ok {
+		if err := checkArchiveLatest(ctx, clt, latest); err != nil {
+			return 0, 0, err
+		}
+
+		from = latest.To + 1
+
+		logger.Info("Continuing existing archive", "latest", latest.To, "hash", latest.LastHash)
+	}
+
+	reqTo, _, err := determineTo(ctx, clt, to)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if from <= reqTo {
+		stream, err := clt.Export(ctx, &proto.ExportRequest{
+			From: from,
+			To:   reqTo,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+
+		writer := newChunkWriter(outDir, manifest)
+
+		if _, _, err := processExportStream(stream, logger, writer, from, reqTo); err != nil {
+			writer.abort()
+
+			return 0, 0, err
+		}
+
+		if err := writer.flush(); err != nil {
+			writer.abort()
+
+			return 0, 0, err
+		}
+	} else {
+		logger.Info("Archive is up to date", "latest", from-1)
+	}
+
+	latest, ok := manifest.latest()
+	if !ok {
+		return 0, 0, errors.New("couldn't get any blocks")
+	}
+
+	return manifest.Chunks[0].From, latest.To, nil
+}
+
+// checkArchiveLatest checks that the latest block of the archive is part of the chain of the node
+func checkArchiveLatest(ctx context.Context, clt proto.SystemClient, latest *Chunk) error {
+	resp, err := clt.BlockByNumber(ctx, &proto.BlockByNumberRequest{Number: latest.To})
+	if err != nil {
+		return fmt.Errorf("unable to get block %d: %w", latest.To, err)
+	}
+
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(resp.Data); err != nil {
+		return err
+	}
+
+	if block.Hash() != latest.LastHash {
+		return fmt.Errorf("%w: block %d has hash %s in the archive and %s in the chain",
+			errArchiveDiverged, latest.To, latest.LastHash, block.Hash())
+	}
+
+	return nil
+}
+
+func removeTmpFiles(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), tmpFileSuffix) {
+			if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// chunkWriter splits the exported blocks into chunk files and registers
+// every completed chunk in the archive manifest
+type chunkWriter struct {
+	dir      string
+	manifest *Manifest
+
+	file       *os.File
+	compressor *gzip.Writer
+	checksum   hash.Hash
+	writer     io.Writer
+	chunk      *Chunk
+	blocks     uint64
+}
+
+func newChunkWriter(dir string, manifest *Manifest) *chunkWriter {
+	return &chunkWriter{
+		dir:      dir,
+		manifest: manifest,
+	}
+}
+
+// Write splits the RLP encoded blocks of the export event into the chunks
+func (w *chunkWriter) Write(data []byte) (int, error) {
+	stream := newBlockStream(bytes.NewReader(data))
+
+	for {
+		block, err := stream.nextBlock()
+		if err != nil {
+			return 0, err
+		}
+
+		if block == nil {
+			return len(data), nil
+		}
+
+		if err := w.writeBlock(block); err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (w *chunkWriter) writeBlock(block *types.Block) error {
+	if w.chunk == nil {
+		if err := w.open(block.Number()); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.writer.Write(block.MarshalRLP()); err != nil {
+		return err
+	}
+
+	w.chunk.To = block.Number()
+	w.chunk.LastHash = block.Hash()
+	w.blocks++
+
+	if w.manifest.ChunkSize != 0 && w.blocks == w.manifest.ChunkSize {
+		return w.flush()
+	}
+
+	return nil
+}
+
+func (w *chunkWriter) open(from uint64) error {
+	file, err := os.OpenFile(w.tmpPath(from), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	w.file = file
+	w.checksum = sha256.New()
+	w.writer = io.MultiWriter(file, w.checksum)
+	w.chunk = &Chunk{From: from}
+	w.blocks = 0
+
+	if w.manifest.Compression == GzipCompression {
+		w.compressor = gzip.NewWriter(w.writer)
+		w.writer = w.compressor
+	}
+
+	return nil
+}
+
+// flush completes the current chunk, if any, and adds it to the manifest
+func (w *chunkWriter) flush() error {
+	if w.chunk == nil {
+		return nil
+	}
+
+	if w.compressor != nil {
+		if err := w.compressor.Close(); err != nil {
+			return err
+		}
+	}
+
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	w.chunk.File = w.chunkFileName(w.chunk.From, w.chunk.To)
+	w.chunk.Checksum = hex.EncodeToString(w.checksum.Sum(nil))
+
+	if err := os.Rename(w.tmpPath(w.chunk.From), filepath.Join(w.dir, w.chunk.File)); err != nil {
+		return err
+	}
+
+	w.manifest.Chunks = append(w.manifest.Chunks, w.chunk)
+	w.chunk, w.file, w.compressor = nil, nil, nil
+
+	return w.manifest.write(w.dir)
+}
+
+// abort removes the incomplete chunk
+func (w *chunkWriter) abort() {
+	if w.chunk == nil {
+		return
+	}
+
+	_ = w.file.Close()
+	_ = os.Remove(w.tmpPath(w.chunk.From))
+
+	w.chunk, w.file, w.compressor = nil, nil, nil
+}
+
+func (w *chunkWriter) tmpPath(from uint64) string {
+	return filepath.Join(w.dir, fmt.Sprintf("%020d%s", from, tmpFileSuffix))
+}
+
+func (w *chunkWriter) chunkFileName(from, to uint64) string {
+	name := fmt.Sprintf("%020d-%020d.rlp", from, to)
+	if w.manifest.Compression == GzipCompression {
+		name += ".gz"
+	}
+
+	return name
+}
+
+// openChunkedArchive verifies the chunks of the archive in the given directory
+// and returns the stream of the archive metadata followed by all the blocks of the chunks
+func openChunkedArchive(dir string) (io.Reader, func(), error) {
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("archive manifest not found in %s", dir)
+	}
+
+	latest, ok := manifest.latest()
+	if !ok {
+		return nil, nil, errors.New("archive has no chunks")
+	}
+
+	metadata := &Metadata{
+		Latest:     latest.To,
+		LatestHash: latest.LastHash,
+	}
+
+	if manifest.Compression != "" && manifest.Compression != GzipCompression {
+		return nil, nil, fmt.Errorf("%w: %s", errUnknownCompressor, manifest.Compression)
+	}
+
+	for _, chunk := range manifest.Chunks {
+		if err := verifyChecksum(filepath.Join(dir, chunk.File), chunk.Checksum); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	chunks := &chunkReader{
+		dir:         dir,
+		compression: manifest.Compression,
+		chunks:      manifest.Chunks,
+	}
+
+	closeFn := func() {
+		_ = chunks.closeChunk()
+	}
+
+	return io.MultiReader(bytes.NewReader(metadata.MarshalRLP()), chunks), closeFn, nil
+}
+
+// chunkReader reads the blocks of the chunks one after another.
+// Only the chunk being read is open, so that large archives don't exhaust the file descriptors
+type chunkReader struct {
+	dir         string
+	compression string
+	chunks      []*Chunk
+
+	// file and reader of the chunk being read, nil if no chunk is open
+	file       *os.File
+	compressed *gzip.Reader
+	reader     io.Reader
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.reader == nil {
+			if len(r.chunks) == 0 {
+				return 0, io.EOF
+			}
+
+			if err := r.openChunk(r.chunks[0]); err != nil {
+				return 0, err
+			}
+
+			r.chunks = r.chunks[1:]
+		}
+
+		n, err := r.reader.Read(p)
+		if !errors.Is(err, io.EOF) {
+			return n, err
+		}
+
+		// the chunk is read completely, continue with the next one
+		if err := r.closeChunk(); err != nil {
+			return n, err
+		}
+
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+// openChunk opens the chunk file and its decompressor
+func (r *chunkReader) openChunk(chunk *Chunk) error {
+	file, err := os.Open(filepath.Join(r.dir, chunk.File))
+	if err != nil {
+		return err
+	}
+
+	r.file = file
+	r.reader = file
+
+	if r.compression == GzipCompression {
+		compressed, err := gzip.NewReader(file)
+		if err != nil {
+			_ = r.closeChunk()
+
+			return err
+		}
+
+		r.compressed = compressed
+		r.reader = compressed
+	}
+
+	return nil
+}
+
+// closeChunk closes the chunk being read, if any
+func (r *chunkReader) closeChunk() error {
+	var err error
+
+	if r.compressed != nil {
+		err = r.compressed.Close()
+	}
+
+	if r.file != nil {
+		if closeErr := r.file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	r.file, r.compressed, r.reader = nil, nil, nil
+
+	return err
+}
+
+// verifyChecksum checks the SHA-256 checksum of the given file
+func verifyChecksum(path string, checksum string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return err
+	}
+
+	if actual := hex.EncodeToString(hasher.Sum(nil)); actual != checksum {
+		return fmt.Errorf("%w: %s has checksum %s, expected %s", errChecksumMismatch, path, actual, checksum)
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package archive
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func writeTestChunks(t *testing.T, dir string, manifest *Manifest, events ...[]*types.Block) {
+	t.Helper()
+
+	writer := newChunkWriter(dir, manifest)
+
+	for _, event := range events {
+		data := []byte{}
+		for _, b := range event {
+			data = append(data, b.MarshalRLP()...)
+		}
+
+		n, err := writer.Write(data)
+		require.NoError(t, err)
+		require.Equal(t, len(data), n)
+	}
+
+	require.NoError(t, writer.flush())
+}
+
+func Test_chunkWriter(t *testing.T) {
+	t.Parallel()
+
+	for _, compression := range []string{"", GzipCompression} {
+		compression := compression
+
+		t.Run("compression "+compression, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			manifest := &Manifest{Version: manifestVersion, ChunkSize: 2, Compression: compression}
+
+			writeTestChunks(t, dir, manifest,
+				[]*types.Block{genesis, blocks[0], blocks[1]},
+				[]*types.Block{blocks[2]},
+			)
+
+			stored, err := readManifest(dir)
+			require.NoError(t, err)
+			require.Len(t, stored.Chunks, 2)
+
+			assert.Equal(t, uint64(0), stored.Chunks[0].From)
+			assert.Equal(t, uint64(1), stored.Chunks[0].To)
+			assert.Equal(t, blocks[0].Hash(), stored.Chunks[0].LastHash)
+			assert.Equal(t, uint64(2), stored.Chunks[1].From)
+			assert.Equal(t, uint64(3), stored.Chunks[1].To)
+			assert.Equal(t, blocks[2].Hash(), stored.Chunks[1].LastHash)
+
+			for _, chunk := range stored.Chunks {
+				assert.NoError(t, verifyChecksum(filepath.Join(dir, chunk.File), chunk.Checksum))
+			}
+
+			chain := &mockChain{genesis: genesis, blocks: []*types.Block{}}
+			require.NoError(t, RestoreChain(chain, dir, progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+			assert.Equal(t, blocks, chain.blocks)
+		})
+	}
+}
+
+func Test_chunkWriter_Incremental(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	manifest := &Manifest{Version: manifestVersion}
+
+	writeTestChunks(t, dir, manifest, []*types.Block{genesis, blocks[0]})
+
+	// the interrupted chunk is never added to the archive
+	interrupted := newChunkWriter(dir, manifest)
+	_, err := interrupted.Write(blocks[1].MarshalRLP())
+	require.NoError(t, err)
+	interrupted.abort()
+
+	manifest, err = readManifest(dir)
+	require.NoError(t, err)
+	require.Len(t, manifest.Chunks, 1)
+
+	writeTestChunks(t, dir, manifest, []*types.Block{blocks[1], blocks[2]})
+
+	manifest, err = readManifest(dir)
+	require.NoError(t, err)
+	require.Len(t, manifest.Chunks, 2)
+
+	latest, ok := manifest.latest()
+	require.True(t, ok)
+	assert.Equal(t, blocks[2].Hash(), latest.LastHash)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+
+	chain := &mockChain{genesis: genesis, blocks: []*types.Block{}}
+	require.NoError(t, RestoreChain(chain, dir, progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+	assert.Equal(t, blocks, chain.blocks)
+}
+
+func Test_openChunkedArchive_ChecksumMismatch(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	manifest := &Manifest{Version: manifestVersion, ChunkSize: 2}
+
+	writeTestChunks(t, dir, manifest, []*types.Block{genesis, blocks[0], blocks[1], blocks[2]})
+
+	path := filepath.Join(dir, manifest.Chunks[1].File)
+	require.NoError(t, os.WriteFile(path, blocks[1].MarshalRLP(), 0600))
+
+	_, _, err := openChunkedArchive(dir)
+	assert.ErrorIs(t, err, errChecksumMismatch)
+}
+
+func Test_chunkReader_OpensChunksLazily(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	manifest := &Manifest{Version: manifestVersion, ChunkSize: 2, Compression: GzipCompression}
+
+	writeTestChunks(t, dir, manifest, []*types.Block{genesis, blocks[0], blocks[1], blocks[2]})
+	require.Len(t, manifest.Chunks, 2)
+
+	reader := &chunkReader{dir: dir, compression: manifest.Compression, chunks: manifest.Chunks}
+
+	// nothing is open before the first read
+	assert.Nil(t, reader.file)
+
+	buf := make([]byte, 1)
+	_, err := reader.Read(buf)
+	require.NoError(t, err)
+
+	firstFile := reader.file
+	require.NotNil(t, firstFile)
+
+	rest, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	// the chunks are closed once they are read
+	assert.Nil(t, reader.file)
+	assert.ErrorIs(t, firstFile.Close(), os.ErrClosed)
+
+	expected := []byte{}
+	for _, b := range []*types.Block{genesis, blocks[0], blocks[1], blocks[2]} {
+		expected = append(expected, b.MarshalRLP()...)
+	}
+
+	assert.Equal(t, expected, append(buf, rest...))
+}
+
+func Test_removeTmpFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "1"+tmpFileSuffix), []byte{0x1}, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, manifestFileName), []byte{0x1}, 0600))
+
+	require.NoError(t, removeTmpFiles(dir))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, manifestFileName, entries[0].Name())
+}
+
+func Test_checkArchiveLatest(t *testing.T) {
+	t.Parallel()
+
+	clt := &systemClientMock{
+		block: &proto.BlockResponse{
+			Data: blocks[1].MarshalRLP(),
+		},
+	}
+
+	assert.NoError(t, checkArchiveLatest(context.Background(), clt, &Chunk{To: 2, LastHash: blocks[1].Hash()}))
+
+	err := checkArchiveLatest(context.Background(), clt, &Chunk{To: 2, LastHash: blocks[0].Hash()})
+	assert.ErrorIs(t, err, errArchiveDiverged)
+
+	clt.errForBlock = errors.New("not found")
+	assert.Error(t, checkArchiveLatest(context.Background(), clt, &Chunk{To: 2, LastHash: blocks[1].Hash()}))
+}
+
+func Test_verifyChecksumFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "backup")
+	data := genesis.MarshalRLP()
+
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	// archives without a checksum file are accepted
+	assert.NoError(t, verifyChecksumFile(path))
+
+	checksum := sha256.Sum256(data)
+	require.NoError(t, writeChecksumFile(path, checksum[:]))
+	assert.NoError(t, verifyChecksumFile(path))
+
+	require.NoError(t, os.WriteFile(path, append(data, 0x1), 0600))
+	assert.ErrorIs(t, verifyChecksumFile(path), errChecksumMismatch)
+}
@@ -26,13 +26,31 @@ type blockchainInterface interface {
 	VerifyFinalizedBlock(*types.Block) (*types.FullBlock, error)
 }
 
-// RestoreChain reads blocks from the archive and write to the chain
+// RestoreChain reads blocks from the archive and write to the chain.
+// The archive is either a single file or a directory of chunks
 func RestoreChain(chain blockchainInterface, filePath string, progression *progress.ProgressionWrapper) error {
+	if IsChunkedArchive(filePath) {
+		input, closeFn, err := openChunkedArchive(filePath)
+		if err != nil {
+			return err
+		}
+
+		defer closeFn()
+
+		return importBlocks(chain, newBlockStream(input), progression)
+	}
+
+	if err := verifyChecksumFile(filePath); err != nil {
+		return err
+	}
+
 	fp, err := os.Open(filePath)
 	if err != nil {
 		return err
 	}
 
+	defer fp.Close()
+
 	blockStream := newBlockStream(fp)
 
 	return importBlocks(chain, blockStream, progression)
@@ -219,7 +237,7 @@ func (b *blockStream) loadRLPArray() (uint64, error) {
 // loadRLPPrefix loads first byte of RLP encoded data from input
 func (b *blockStream) loadRLPPrefix() (byte, error) {
 	buf := b.buffer[:1]
-	if _, err := b.input.Read(buf); err != nil {
+	if _, err := io.ReadFull(b.input, buf); err != nil {
 		return 0, err
 	}
 
@@ -241,15 +259,14 @@ func (b *blockStream) loadPrefixSize(offset uint64, prefix byte) (uint64, uint64
 
 		b.reserveCap(offset + payloadSizeSize)
 		payloadSizeBytes := b.buffer[offset : offset+payloadSizeSize]
-		n, err := b.input.Read(payloadSizeBytes)
 
-		if err != nil {
-			return 0, 0, err
-		}
+		if _, err := io.ReadFull(b.input, payloadSizeBytes); err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				// couldn't load required amount of bytes
+				return 0, 0, io.EOF
+			}
 
-		if uint64(n) < payloadSizeSize {
-			// couldn't load required amount of bytes
-			return 0, 0, io.EOF
+			return 0, 0, err
 		}
 
 		payloadSize := new(big.Int).SetBytes(payloadSizeBytes).Int64()
@@ -265,7 +282,7 @@ func (b *blockStream) loadPayload(offset uint64, size uint64) error {
 	b.reserveCap(offset + size)
 	buf := b.buffer[offset : offset+size]
 
-	if _, err := b.input.Read(buf); err != nil {
+	if _, err := io.ReadFull(b.input, buf); err != nil {
 		return err
 	}
 
@@ -0,0 +1,663 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+)
+
+const (
+	// snapshotVersion is the current version of the state snapshot format
+	snapshotVersion = 1
+
+	// snapshotTrieBatchSize is the number of trie nodes written to the storage at once
+	snapshotTrieBatchSize = 10000
+
+	// snapshotFileChunkSize is the maximum size of the file data in a single snapshot record
+	snapshotFileChunkSize = 1 << 20
+
+	// snapshotFilesDir is the directory of the data directory the snapshot files are imported into
+	snapshotFilesDir = "consensus"
+
+	// snapshotVerificationFile is the file in the data directory marking the imported headers,
+	// which are not verified by the consensus yet
+	snapshotVerificationFile = "snapshot-verification"
+)
+
+var (
+	errSnapshotTargetNotEmpty = errors.New("the blockchain storage is not empty")
+	errInvalidSnapshot        = errors.New("invalid snapshot")
+)
+
+// SnapshotFile is the file of the data directory, such as the consensus state, included in the snapshot as is
+type SnapshotFile struct {
+	// Path is the slash separated path of the file relative to the data directory,
+	// only the files of the consensus directory are accepted
+	Path string
+	// Data is the content of the file
+	Data io.Reader
+}
+
+// ExportSnapshot writes the snapshot of the state of the given block into the output.
+// The snapshot contains the headers of all the canonical blocks up to the given one,
+// the bodies and the receipts of the given number of recent blocks, the whole state trie
+// and the given files, which is enough for a new node to start from the given block
+// without re-executing the chain
+func ExportSnapshot(
+	logger hclog.Logger,
+	chain storage.Storage,
+	trie itrie.Storage,
+	number uint64,
+	recent uint64,
+	files []*SnapshotFile,
+	out io.Writer,
+) (*SnapshotMetadata, error) {
+	hash, ok := chain.ReadCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+
+	header, err := chain.ReadHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read header %d: %w", number, err)
+	}
+
+	metadata := &SnapshotMetadata{
+		Version:   snapshotVersion,
+		Number:    number,
+		Hash:      hash,
+		StateRoot: header.StateRoot,
+	}
+
+	writer := bufio.NewWriter(out)
+
+	if _, err := writer.Write(metadata.MarshalRLP()); err != nil {
+		return nil, err
+	}
+
+	if err := exportSnapshotChain(logger, chain, number, recent, writer); err != nil {
+		return nil, err
+	}
+
+	if err := exportSnapshotState(logger, trie, header.StateRoot, writer); err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if err := exportSnapshotFile(logger, file, writer); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// exportSnapshotChain writes the canonical headers and the recent blocks with their receipts
+func exportSnapshotChain(logger hclog.Logger, chain storage.Storage, number, recent uint64, out io.Writer) error {
+	for n := uint64(0); n <= number; n++ {
+		header, err := readCanonicalHeader(chain, n)
+		if err != nil {
+			return err
+		}
+
+		if err := writeSnapshotRecord(out, snapshotHeader, nil, header.MarshalRLP()); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Exported headers", "count", number+1)
+
+	from := uint64(0)
+	if recent <= number {
+		from = number - recent + 1
+	}
+
+	for n := from; n <= number && recent > 0; n++ {
+		header, err := readCanonicalHeader(chain, n)
+		if err != nil {
+			return err
+		}
+
+		body, err := chain.ReadBody(header.Hash)
+		if err != nil {
+			return fmt.Errorf("unable to read body of block %d: %w", n, err)
+		}
+
+		block := &types.Block{
+			Header:       header,
+			Transactions: body.Transactions,
+			Uncles:       body.Uncles,
+		}
+
+		if err := writeSnapshotRecord(out, snapshotBlock, nil, block.MarshalRLP()); err != nil {
+			return err
+		}
+
+		receipts, err := chain.ReadReceipts(header.Hash)
+		if err != nil {
+			return fmt.Errorf("unable to read receipts of block %d: %w", n, err)
+		}
+
+		data := types.Receipts(receipts).MarshalStoreRLPTo(nil)
+		if err := writeSnapshotRecord(out, snapshotReceipts, header.Hash.Bytes(), data); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Exported recent blocks", "from", from, "to", number)
+
+	return nil
+}
+
+// exportSnapshotState writes all the trie nodes and the contract codes of the given state
+func exportSnapshotState(logger hclog.Logger, trie itrie.Storage, stateRoot types.Hash, out io.Writer) error {
+	if stateRoot == types.EmptyRootHash {
+		return nil
+	}
+
+	writer := &snapshotTrieWriter{
+		out:     out,
+		written: map[string]struct{}{},
+	}
+
+	if err := itrie.CopyTrie(stateRoot.Bytes(), trie, writer, nil, false); err != nil {
+		return err
+	}
+
+	if writer.err != nil {
+		return writer.err
+	}
+
+	logger.Info("Exported state", "root", stateRoot, "trie nodes", writer.nodes, "codes", writer.codes)
+
+	return nil
+}
+
+// exportSnapshotFile writes the file in chunks, so that a large file is not loaded into memory at once
+func exportSnapshotFile(logger hclog.Logger, file *SnapshotFile, out io.Writer) error {
+	if _, err := snapshotFilePath("", file.Path); err != nil {
+		return err
+	}
+
+	var (
+		chunk = make([]byte, snapshotFileChunkSize)
+		size  = 0
+	)
+
+	for {
+		n, err := io.ReadFull(file.Data, chunk)
+		if n > 0 {
+			if err := writeSnapshotRecord(out, snapshotFile, []byte(file.Path), chunk[:n]); err != nil {
+				return err
+			}
+
+			size += n
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to read file %s: %w", file.Path, err)
+		}
+	}
+
+	logger.Info("Exported file", "path", file.Path, "size", size)
+
+	return nil
+}
+
+// snapshotFilePath returns the path of the snapshot file in the data directory,
+// the file must be in the consensus directory
+func snapshotFilePath(dataDir, path string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(path))
+
+	if filepath.IsAbs(clean) || !strings.HasPrefix(clean, snapshotFilesDir+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: file %q is not in the %s directory", errInvalidSnapshot, path, snapshotFilesDir)
+	}
+
+	return filepath.Join(dataDir, clean), nil
+}
+
+func readCanonicalHeader(chain storage.Storage, number uint64) (*types.Header, error) {
+	hash, ok := chain.ReadCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("canonical hash of block %d not found", number)
+	}
+
+	header, err := chain.ReadHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read header %d: %w", number, err)
+	}
+
+	return header, nil
+}
+
+func writeSnapshotRecord(out io.Writer, kind snapshotRecordKind, key, value []byte) error {
+	record := &snapshotRecord{Kind: kind, Key: key, Value: value}
+
+	_, err := out.Write(record.MarshalRLPTo(nil))
+
+	return err
+}
+
+// snapshotTrieWriter is the trie storage which the trie is copied to in order
+// to write its nodes into the snapshot, every node is only written once
+type snapshotTrieWriter struct {
+	itrie.Storage
+
+	out     io.Writer
+	written map[string]struct{}
+	nodes   uint64
+	codes   uint64
+	err     error
+}
+
+func (w *snapshotTrieWriter) Put(k, v []byte) {
+	if len(v) == 0 {
+		w.setErr(fmt.Errorf("trie node %s not found", types.BytesToHash(k)))
+
+		return
+	}
+
+	if w.isWritten(snapshotTrieNode, k) {
+		return
+	}
+
+	w.nodes++
+	w.setErr(writeSnapshotRecord(w.out, snapshotTrieNode, k, v))
+}
+
+func (w *snapshotTrieWriter) SetCode(hash types.Hash, code []byte) {
+	if w.isWritten(snapshotCode, hash.Bytes()) {
+		return
+	}
+
+	w.codes++
+	w.setErr(writeSnapshotRecord(w.out, snapshotCode, hash.Bytes(), code))
+}
+
+func (w *snapshotTrieWriter) isWritten(kind snapshotRecordKind, key []byte) bool {
+	id := string(append([]byte{byte(kind)}, key...))
+
+	if _, ok := w.written[id]; ok {
+		return true
+	}
+
+	w.written[id] = struct{}{}
+
+	return false
+}
+
+func (w *snapshotTrieWriter) setErr(err error) {
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+// ImportSnapshot writes the snapshot into the empty blockchain and trie storages and its files
+// into the data directory, so that the node starts from the block of the snapshot.
+// The head of the chain is written last, after the whole snapshot is verified.
+// The consensus engine is not available offline, so that the imported headers are marked
+// to be verified by the consensus when the node starts (see SnapshotVerificationPending)
+func ImportSnapshot(
+	logger hclog.Logger,
+	chain storage.Storage,
+	trie itrie.Storage,
+	dataDir string,
+	input io.Reader,
+) (*SnapshotMetadata, error) {
+	if _, ok := chain.ReadHeadHash(); ok {
+		return nil, errSnapshotTargetNotEmpty
+	}
+
+	stream := newBlockStream(bufio.NewReader(input))
+
+	metadata, err := stream.getSnapshotMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", metadata.Version)
+	}
+
+	importer := &snapshotImporter{
+		metadata:   metadata,
+		chain:      chain,
+		trie:       trie,
+		dataDir:    dataDir,
+		batch:      trie.Batch(),
+		difficulty: big.NewInt(0),
+		files:      map[string]*os.File{},
+	}
+
+	defer importer.closeFiles()
+
+	for {
+		record, err := stream.nextSnapshotRecord()
+		if err != nil {
+			return nil, err
+		}
+
+		if record == nil {
+			break
+		}
+
+		if err := importer.importRecord(record); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := importer.finish(); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Imported snapshot", "block", metadata.Number, "hash", metadata.Hash,
+		"recent blocks", importer.blocks, "trie nodes", importer.nodes)
+
+	return metadata, nil
+}
+
+// snapshotImporter writes the snapshot records into the storages
+type snapshotImporter struct {
+	metadata *SnapshotMetadata
+	chain    storage.Storage
+	trie     itrie.Storage
+	dataDir  string
+	batch    itrie.Batch
+
+	// files are the imported files by their paths
+	files map[string]*os.File
+
+	// last is the latest imported header
+	last       *types.Header
+	difficulty *big.Int
+
+	blocks       uint64
+	nodes        uint64
+	pendingNodes int
+}
+
+func (i *snapshotImporter) importRecord(record *snapshotRecord) error {
+	switch record.Kind {
+	case snapshotHeader:
+		return i.importHeader(record.Value)
+	case snapshotBlock:
+		return i.importBlock(record.Value)
+	case snapshotReceipts:
+		return i.importReceipts(types.BytesToHash(record.Key), record.Value)
+	case snapshotTrieNode:
+		return i.importTrieNode(record.Key, record.Value)
+	case snapshotCode:
+		if hash := crypto.Keccak256Hash(record.Value); !bytes.Equal(hash.Bytes(), record.Key) {
+			return fmt.Errorf("%w: code %s has hash %s", errInvalidSnapshot, types.BytesToHash(record.Key), hash)
+		}
+
+		i.trie.SetCode(types.BytesToHash(record.Key), record.Value)
+
+		return nil
+	case snapshotFile:
+		return i.importFile(string(record.Key), record.Value)
+	default:
+		return fmt.Errorf("%w: unknown record kind %d", errInvalidSnapshot, record.Kind)
+	}
+}
+
+// importHeader writes the header of the canonical chain, the headers are expected in order
+func (i *snapshotImporter) importHeader(data []byte) error {
+	header := &types.Header{}
+	if err := header.UnmarshalRLP(data); err != nil {
+		return err
+	}
+
+	if i.last == nil {
+		if header.Number != 0 {
+			return fmt.Errorf("%w: the first header is %d, expected genesis", errInvalidSnapshot, header.Number)
+		}
+	} else if header.Number != i.last.Number+1 || header.ParentHash != i.last.Hash {
+		return fmt.Errorf("%w: header %d doesn't follow header %d", errInvalidSnapshot, header.Number, i.last.Number)
+	}
+
+	if header.Number > i.metadata.Number {
+		return fmt.Errorf("%w: header %d is after the snapshot block", errInvalidSnapshot, header.Number)
+	}
+
+	i.difficulty = new(big.Int).Add(i.difficulty, new(big.Int).SetUint64(header.Difficulty))
+
+	if err := i.chain.WriteHeader(header); err != nil {
+		return err
+	}
+
+	if err := i.chain.WriteCanonicalHash(header.Number, header.Hash); err != nil {
+		return err
+	}
+
+	if err := i.chain.WriteTotalDifficulty(header.Hash, i.difficulty); err != nil {
+		return err
+	}
+
+	i.last = header
+
+	return nil
+}
+
+// importBlock writes the body of the recent block, whose header is already imported
+func (i *snapshotImporter) importBlock(data []byte) error {
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(data); err != nil {
+		return err
+	}
+
+	if hash, ok := i.chain.ReadCanonicalHash(block.Number()); !ok || hash != block.Hash() {
+		return fmt.Errorf("%w: block %d is not part of the imported chain", errInvalidSnapshot, block.Number())
+	}
+
+	if root := buildroot.CalculateTransactionsRoot(block.Transactions); root != block.Header.TxRoot {
+		return fmt.Errorf("%w: transactions root of block %d is %s, expected %s",
+			errInvalidSnapshot, block.Number(), root, block.Header.TxRoot)
+	}
+
+	if root := buildroot.CalculateUncleRoot(block.Uncles); root != block.Header.Sha3Uncles {
+		return fmt.Errorf("%w: uncles root of block %d is %s, expected %s",
+			errInvalidSnapshot, block.Number(), root, block.Header.Sha3Uncles)
+	}
+
+	if err := i.chain.WriteBody(block.Hash(), block.Body()); err != nil {
+		return err
+	}
+
+	for _, tx := range block.Transactions {
+		if err := i.chain.WriteTxLookup(tx.Hash, block.Hash()); err != nil {
+			return err
+		}
+	}
+
+	i.blocks++
+
+	return nil
+}
+
+// importReceipts writes the receipts of the recent block, whose header is already imported
+func (i *snapshotImporter) importReceipts(blockHash types.Hash, data []byte) error {
+	receipts := types.Receipts{}
+	if err := receipts.UnmarshalStoreRLP(data); err != nil {
+		return err
+	}
+
+	header, err := i.chain.ReadHeader(blockHash)
+	if err != nil {
+		return fmt.Errorf("%w: receipts of block %s which is not part of the imported chain",
+			errInvalidSnapshot, blockHash)
+	}
+
+	if root := buildroot.CalculateReceiptsRoot(receipts); root != header.ReceiptsRoot {
+		return fmt.Errorf("%w: receipts root of block %d is %s, expected %s",
+			errInvalidSnapshot, header.Number, root, header.ReceiptsRoot)
+	}
+
+	return i.chain.WriteReceipts(blockHash, receipts)
+}
+
+func (i *snapshotImporter) importTrieNode(key, value []byte) error {
+	if hash := crypto.Keccak256(value); !bytes.Equal(hash, key) {
+		return fmt.Errorf("%w: trie node %s has hash %s", errInvalidSnapshot,
+			types.BytesToHash(key), types.BytesToHash(hash))
+	}
+
+	i.batch.Put(key, value)
+	i.nodes++
+
+	if i.pendingNodes++; i.pendingNodes == snapshotTrieBatchSize {
+		i.batch.Write()
+		i.batch = i.trie.Batch()
+		i.pendingNodes = 0
+	}
+
+	return nil
+}
+
+// importFile appends the chunk to the file, which is created by its first chunk
+func (i *snapshotImporter) importFile(path string, chunk []byte) error {
+	file, ok := i.files[path]
+	if !ok {
+		target, err := snapshotFilePath(i.dataDir, path)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0770); err != nil {
+			return err
+		}
+
+		if file, err = os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0660); err != nil {
+			return err
+		}
+
+		i.files[path] = file
+	}
+
+	_, err := file.Write(chunk)
+
+	return err
+}
+
+func (i *snapshotImporter) closeFiles() {
+	for _, file := range i.files {
+		_ = file.Close()
+	}
+}
+
+// finish verifies the imported snapshot, marks its headers to be verified by the consensus
+// and sets the head of the chain
+func (i *snapshotImporter) finish() error {
+	i.batch.Write()
+
+	for path, file := range i.files {
+		if err := file.Sync(); err != nil {
+			return fmt.Errorf("unable to write file %s: %w", path, err)
+		}
+	}
+
+	if i.last == nil || i.last.Number != i.metadata.Number || i.last.Hash != i.metadata.Hash {
+		return fmt.Errorf("%w: the headers don't end with block %d", errInvalidSnapshot, i.metadata.Number)
+	}
+
+	if i.last.StateRoot != i.metadata.StateRoot {
+		return fmt.Errorf("%w: state root %s doesn't match the header", errInvalidSnapshot, i.metadata.StateRoot)
+	}
+
+	if i.metadata.StateRoot != types.EmptyRootHash {
+		root, err := itrie.HashChecker(i.metadata.StateRoot.Bytes(), i.trie)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errInvalidSnapshot, err)
+		}
+
+		if root != i.metadata.StateRoot {
+			return fmt.Errorf("%w: state root is %s, expected %s", errInvalidSnapshot, root, i.metadata.StateRoot)
+		}
+	}
+
+	marker := []byte(strconv.FormatUint(i.last.Number, 10))
+	if err := os.WriteFile(filepath.Join(i.dataDir, snapshotVerificationFile), marker, 0660); err != nil {
+		return err
+	}
+
+	if err := i.chain.WriteHeadHash(i.last.Hash); err != nil {
+		return err
+	}
+
+	return i.chain.WriteHeadNumber(i.last.Number)
+}
+
+// SnapshotVerificationPending reports whether the data directory has the headers imported from a snapshot,
+// which are not verified by the consensus yet
+func SnapshotVerificationPending(dataDir string) bool {
+	_, err := os.Stat(filepath.Join(dataDir, snapshotVerificationFile))
+
+	return err == nil
+}
+
+// CompleteSnapshotVerification marks the headers imported from a snapshot as verified by the consensus
+func CompleteSnapshotVerification(dataDir string) error {
+	return os.Remove(filepath.Join(dataDir, snapshotVerificationFile))
+}
+
+// getSnapshotMetadata consumes some bytes from input and returns parsed SnapshotMetadata
+func (b *blockStream) getSnapshotMetadata() (*SnapshotMetadata, error) {
+	size, err := b.loadRLPArray()
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 {
+		return nil, fmt.Errorf("%w: metadata not found", errInvalidSnapshot)
+	}
+
+	metadata := &SnapshotMetadata{}
+	if err := metadata.UnmarshalRLP(b.buffer[:size]); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// nextSnapshotRecord consumes some bytes from input and returns parsed snapshot record,
+// it returns nil at the end of the snapshot
+func (b *blockStream) nextSnapshotRecord() (*snapshotRecord, error) {
+	size, err := b.loadRLPArray()
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 {
+		return nil, nil
+	}
+
+	record := &snapshotRecord{}
+	if err := record.UnmarshalRLP(b.buffer[:size]); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
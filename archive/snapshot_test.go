@@ -0,0 +1,324 @@
+package archive
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+)
+
+var (
+	snapshotTestContract = types.StringToAddress("0x1000")
+	snapshotTestCode     = []byte{0x60, 0x01, 0x60, 0x00, 0x55}
+)
+
+// newSnapshotTestChain writes the chain of the given number of blocks, every block
+// has a transaction and changes the state of the test contract
+func newSnapshotTestChain(t *testing.T, length uint64) (storage.Storage, itrie.Storage, []*types.Block) {
+	t.Helper()
+
+	chain, err := memory.NewMemoryStorage(hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	trie := itrie.NewMemoryStorage()
+	snap := itrie.NewState(trie).NewSnapshot()
+
+	var (
+		root   []byte
+		blocks = make([]*types.Block, 0, length)
+		parent = types.ZeroHash
+		td     = big.NewInt(0)
+	)
+
+	for n := uint64(0); n < length; n++ {
+		snap, root = snap.Commit([]*state.Object{
+			{
+				Address:   snapshotTestContract,
+				Balance:   new(big.Int).SetUint64(n),
+				CodeHash:  crypto.Keccak256Hash(snapshotTestCode),
+				Root:      types.EmptyRootHash,
+				DirtyCode: n == 0,
+				Code:      snapshotTestCode,
+				Storage: []*state.StorageObject{
+					{Key: types.StringToHash("0x1").Bytes(), Val: types.BytesToHash([]byte{byte(n + 1)}).Bytes()},
+				},
+			},
+		})
+
+		tx := &types.Transaction{Nonce: n, Value: big.NewInt(1), V: big.NewInt(1)}
+		tx.ComputeHash()
+
+		txs := []*types.Transaction{tx}
+		receipts := []*types.Receipt{
+			{CumulativeGasUsed: n, TxHash: tx.Hash, Logs: []*types.Log{}},
+		}
+
+		block := &types.Block{
+			Header: &types.Header{
+				Number:       n,
+				ParentHash:   parent,
+				Difficulty:   1,
+				StateRoot:    types.BytesToHash(root),
+				TxRoot:       buildroot.CalculateTransactionsRoot(txs),
+				ReceiptsRoot: buildroot.CalculateReceiptsRoot(receipts),
+				Sha3Uncles:   types.EmptyUncleHash,
+				ExtraData:    []byte{},
+			},
+			Transactions: txs,
+		}
+		block.Header.ComputeHash()
+
+		td.Add(td, big.NewInt(1))
+
+		require.NoError(t, chain.WriteCanonicalHeader(block.Header, td))
+		require.NoError(t, chain.WriteBody(block.Hash(), block.Body()))
+		require.NoError(t, chain.WriteReceipts(block.Hash(), receipts))
+
+		blocks = append(blocks, block)
+		parent = block.Hash()
+	}
+
+	return chain, trie, blocks
+}
+
+func TestSnapshot_ExportImport(t *testing.T) {
+	t.Parallel()
+
+	source, sourceTrie, blocks := newSnapshotTestChain(t, 10)
+
+	buf := &bytes.Buffer{}
+
+	exported, err := ExportSnapshot(hclog.NewNullLogger(), source, sourceTrie, 7, 3, nil, buf)
+	require.NoError(t, err)
+	assert.Equal(t, blocks[7].Hash(), exported.Hash)
+	assert.Equal(t, blocks[7].Header.StateRoot, exported.StateRoot)
+
+	chain, err := memory.NewMemoryStorage(hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	trie := itrie.NewMemoryStorage()
+
+	dataDir := t.TempDir()
+
+	imported, err := ImportSnapshot(hclog.NewNullLogger(), chain, trie, dataDir, buf)
+	require.NoError(t, err)
+	assert.Equal(t, exported, imported)
+
+	// the imported headers are verified by the consensus when the node starts
+	assert.True(t, SnapshotVerificationPending(dataDir))
+	require.NoError(t, CompleteSnapshotVerification(dataDir))
+	assert.False(t, SnapshotVerificationPending(dataDir))
+
+	head, ok := chain.ReadHeadHash()
+	require.True(t, ok)
+	assert.Equal(t, blocks[7].Hash(), head)
+
+	number, ok := chain.ReadHeadNumber()
+	require.True(t, ok)
+	assert.Equal(t, uint64(7), number)
+
+	for _, block := range blocks[:8] {
+		hash, ok := chain.ReadCanonicalHash(block.Number())
+		require.True(t, ok)
+		assert.Equal(t, block.Hash(), hash)
+
+		td, ok := chain.ReadTotalDifficulty(hash)
+		require.True(t, ok)
+		assert.Equal(t, new(big.Int).SetUint64(block.Number()+1), td)
+
+		_, err := chain.ReadBody(hash)
+		if block.Number() < 5 {
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+
+			continue
+		}
+
+		require.NoError(t, err)
+
+		receipts, err := chain.ReadReceipts(hash)
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+
+		lookup, ok := chain.ReadTxLookup(block.Transactions[0].Hash)
+		require.True(t, ok)
+		assert.Equal(t, hash, lookup)
+	}
+
+	snap, err := itrie.NewState(trie).NewSnapshotAt(imported.StateRoot)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(snapshotTestContract)
+	require.NoError(t, err)
+	require.NotNil(t, account)
+	assert.Equal(t, big.NewInt(7), account.Balance)
+	assert.Equal(t, types.BytesToHash([]byte{8}),
+		snap.GetStorage(snapshotTestContract, account.Root, types.StringToHash("0x1")))
+
+	code, ok := trie.GetCode(types.BytesToHash(account.CodeHash))
+	require.True(t, ok)
+	assert.Equal(t, snapshotTestCode, code)
+}
+
+func TestSnapshot_ExportImportFiles(t *testing.T) {
+	t.Parallel()
+
+	source, sourceTrie, _ := newSnapshotTestChain(t, 2)
+
+	// the file spans several records
+	state := bytes.Repeat([]byte{0x1, 0x2, 0x3}, snapshotFileChunkSize)
+
+	buf := &bytes.Buffer{}
+
+	_, err := ExportSnapshot(hclog.NewNullLogger(), source, sourceTrie, 1, 1, []*SnapshotFile{
+		{Path: "consensus/consensusState.db", Data: bytes.NewReader(state)},
+	}, buf)
+	require.NoError(t, err)
+
+	chain, err := memory.NewMemoryStorage(hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	dataDir := t.TempDir()
+
+	_, err = ImportSnapshot(hclog.NewNullLogger(), chain, itrie.NewMemoryStorage(), dataDir, buf)
+	require.NoError(t, err)
+
+	imported, err := os.ReadFile(filepath.Join(dataDir, "consensus", "consensusState.db"))
+	require.NoError(t, err)
+	assert.Equal(t, state, imported)
+
+	// only the files of the consensus directory are exported
+	_, err = ExportSnapshot(hclog.NewNullLogger(), source, sourceTrie, 1, 1, []*SnapshotFile{
+		{Path: "../consensusState.db", Data: bytes.NewReader(state)},
+	}, &bytes.Buffer{})
+	assert.ErrorIs(t, err, errInvalidSnapshot)
+}
+
+func TestSnapshot_ImportNotEmpty(t *testing.T) {
+	t.Parallel()
+
+	source, sourceTrie, _ := newSnapshotTestChain(t, 2)
+
+	buf := &bytes.Buffer{}
+
+	_, err := ExportSnapshot(hclog.NewNullLogger(), source, sourceTrie, 1, 1, nil, buf)
+	require.NoError(t, err)
+
+	_, err = ImportSnapshot(hclog.NewNullLogger(), source, itrie.NewMemoryStorage(), t.TempDir(), buf)
+	assert.ErrorIs(t, err, errSnapshotTargetNotEmpty)
+}
+
+func TestSnapshot_ImportInvalid(t *testing.T) {
+	t.Parallel()
+
+	source, sourceTrie, blocks := newSnapshotTestChain(t, 3)
+
+	buf := &bytes.Buffer{}
+
+	_, err := ExportSnapshot(hclog.NewNullLogger(), source, sourceTrie, 2, 0, nil, buf)
+	require.NoError(t, err)
+
+	valid := buf.Bytes()
+
+	// withHeaders returns the snapshot of the test chain headers followed by the given record
+	withHeaders := func(record *snapshotRecord) []byte {
+		snapshot := (&SnapshotMetadata{Version: snapshotVersion, Number: 2, Hash: blocks[2].Hash()}).MarshalRLP()
+
+		for _, block := range blocks {
+			snapshot = (&snapshotRecord{Kind: snapshotHeader, Value: block.Header.MarshalRLP()}).MarshalRLPTo(snapshot)
+		}
+
+		return record.MarshalRLPTo(snapshot)
+	}
+
+	tests := []struct {
+		name     string
+		snapshot []byte
+		err      string
+	}{
+		{
+			name: "truncated record",
+			// cuts the record of the state root node
+			snapshot: valid[:bytes.LastIndex(valid, blocks[2].Header.StateRoot.Bytes())],
+		},
+		{
+			name: "tampered trie node",
+			snapshot: func() []byte {
+				tampered := append([]byte{}, valid...)
+				tampered[len(tampered)-1] ^= 0xff
+
+				return tampered
+			}(),
+		},
+		{
+			name: "missing headers",
+			snapshot: append(
+				(&SnapshotMetadata{Version: snapshotVersion, Number: 2, Hash: blocks[2].Hash()}).MarshalRLP(),
+				(&snapshotRecord{Kind: snapshotHeader, Value: blocks[0].Header.MarshalRLP()}).MarshalRLPTo(nil)...,
+			),
+		},
+		{
+			name: "tampered block body",
+			snapshot: withHeaders(&snapshotRecord{Kind: snapshotBlock, Value: (&types.Block{
+				Header:       blocks[2].Header,
+				Transactions: blocks[1].Transactions,
+			}).MarshalRLP()}),
+			err: "transactions root of block 2",
+		},
+		{
+			name: "tampered receipts",
+			snapshot: withHeaders(&snapshotRecord{
+				Kind:  snapshotReceipts,
+				Key:   blocks[2].Hash().Bytes(),
+				Value: types.Receipts{{CumulativeGasUsed: 10, Logs: []*types.Log{}}}.MarshalStoreRLPTo(nil),
+			}),
+			err: "receipts root of block 2",
+		},
+		{
+			name: "file outside the consensus directory",
+			snapshot: withHeaders(&snapshotRecord{
+				Kind:  snapshotFile,
+				Key:   []byte("consensus/../blockchain/000001.log"),
+				Value: []byte{0x1},
+			}),
+			err: "is not in the consensus directory",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			chain, err := memory.NewMemoryStorage(hclog.NewNullLogger())
+			require.NoError(t, err)
+
+			dataDir := t.TempDir()
+
+			_, err = ImportSnapshot(
+				hclog.NewNullLogger(), chain, itrie.NewMemoryStorage(), dataDir, bytes.NewReader(tt.snapshot))
+			assert.Error(t, err)
+			assert.False(t, SnapshotVerificationPending(dataDir))
+
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+			}
+
+			_, ok := chain.ReadHeadHash()
+			assert.False(t, ok)
+		})
+	}
+}
@@ -59,3 +59,144 @@ func (m *Metadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 
 	return nil
 }
+
+// SnapshotMetadata is the data stored in the beginning of state snapshot
+type SnapshotMetadata struct {
+	Version   uint64
+	Number    uint64
+	Hash      types.Hash
+	StateRoot types.Hash
+}
+
+// MarshalRLP returns RLP encoded bytes
+func (m *SnapshotMetadata) MarshalRLP() []byte {
+	return m.MarshalRLPTo(nil)
+}
+
+// MarshalRLPTo sets RLP encoded bytes to given byte slice
+func (m *SnapshotMetadata) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(m.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (m *SnapshotMetadata) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(m.Version))
+	vv.Set(arena.NewUint(m.Number))
+	vv.Set(arena.NewBytes(m.Hash.Bytes()))
+	vv.Set(arena.NewBytes(m.StateRoot.Bytes()))
+
+	return vv
+}
+
+// UnmarshalRLP unmarshals and sets the fields from RLP encoded bytes
+func (m *SnapshotMetadata) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(m.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (m *SnapshotMetadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 4 {
+		return fmt.Errorf("incorrect number of elements to decode SnapshotMetadata, expected 4 but found %d", len(elems))
+	}
+
+	if m.Version, err = elems[0].GetUint64(); err != nil {
+		return err
+	}
+
+	if m.Number, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	if err = elems[2].GetHash(m.Hash[:]); err != nil {
+		return err
+	}
+
+	if err = elems[3].GetHash(m.StateRoot[:]); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// snapshotRecordKind is the kind of the data stored in the snapshot record
+type snapshotRecordKind uint64
+
+const (
+	// snapshotHeader is the RLP encoded header of the canonical chain
+	snapshotHeader snapshotRecordKind = iota + 1
+	// snapshotBlock is the RLP encoded block of the recent blocks
+	snapshotBlock
+	// snapshotReceipts are the receipts of the block with the hash of the record key
+	snapshotReceipts
+	// snapshotTrieNode is the trie node with the hash of the record key
+	snapshotTrieNode
+	// snapshotCode is the contract code with the hash of the record key
+	snapshotCode
+	// snapshotFile is the chunk of the file with the path of the record key
+	snapshotFile
+)
+
+// snapshotRecord is the single item of the state snapshot following the metadata
+type snapshotRecord struct {
+	Kind  snapshotRecordKind
+	Key   []byte
+	Value []byte
+}
+
+// MarshalRLPTo sets RLP encoded bytes to given byte slice
+func (r *snapshotRecord) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(r.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (r *snapshotRecord) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(uint64(r.Kind)))
+	vv.Set(arena.NewBytes(r.Key))
+	vv.Set(arena.NewBytes(r.Value))
+
+	return vv
+}
+
+// UnmarshalRLP unmarshals and sets the fields from RLP encoded bytes
+func (r *snapshotRecord) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(r.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (r *snapshotRecord) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 3 {
+		return fmt.Errorf("incorrect number of elements to decode snapshot record, expected 3 but found %d", len(elems))
+	}
+
+	kind, err := elems[0].GetUint64()
+	if err != nil {
+		return err
+	}
+
+	r.Kind = snapshotRecordKind(kind)
+
+	// the parsed values are only valid until the parser is reused
+	if r.Key, err = elems[1].GetBytes(nil); err != nil {
+		return err
+	}
+
+	if r.Value, err = elems[2].GetBytes(nil); err != nil {
+		return err
+	}
+
+	return nil
+}
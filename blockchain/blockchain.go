@@ -94,6 +94,13 @@ type Verifier interface {
 	PreCommitState(header *types.Header, txn *state.Transition) error
 }
 
+// HeadersVerifier is implemented by the consensus able to verify a chain of headers
+// before their parents are written to the chain.
+// The parents are the already verified headers preceding the headers, which are not written yet
+type HeadersVerifier interface {
+	VerifyHeaders(parents, headers []*types.Header) error
+}
+
 type Executor interface {
 	ProcessBlock(parentRoot types.Hash, block *types.Block, blockCreator types.Address) (*state.Transition, error)
 }
@@ -648,6 +655,64 @@ func (b *Blockchain) WriteHeadersWithBodies(headers []*types.Header) error {
 	return nil
 }
 
+// VerifyHeaders verifies a chain of consecutive headers following a locally saved header,
+// without writing them. The parents are the already verified headers preceding the headers,
+// which are not written yet, so that a long chain can be verified in parts.
+// The whole chain is verified by the consensus if it implements HeadersVerifier.
+// Otherwise only the header following the local chain is verified by the consensus, since the other engines
+// verify a header against its parent and the validators read from the local chain, which don't exist yet
+// for the rest of the headers. The rest are tied to the verified header by the hash chain only, which doesn't
+// prove their seals, so that they are verified by the consensus along with their blocks before being written
+// (see VerifyFinalizedBlock), and a forged header is rejected there
+func (b *Blockchain) VerifyHeaders(parents, headers []*types.Header) error {
+	if len(headers) == 0 {
+		return fmt.Errorf("passed in headers array is empty")
+	}
+
+	var parent *types.Header
+
+	if len(parents) > 0 {
+		parent = parents[len(parents)-1]
+	} else {
+		var ok bool
+
+		if parent, ok = b.readHeader(headers[0].ParentHash); !ok {
+			return ErrParentNotFound
+		}
+	}
+
+	for _, header := range headers {
+		if header.ParentHash != parent.Hash {
+			return ErrParentHashMismatch
+		}
+
+		if header.Number-1 != parent.Number {
+			return ErrInvalidBlockSequence
+		}
+
+		if err := b.verifyGasLimit(header, parent); err != nil {
+			return fmt.Errorf("invalid gas limit, %w", err)
+		}
+
+		parent = header
+	}
+
+	if verifier, ok := b.consensus.(HeadersVerifier); ok {
+		return verifier.VerifyHeaders(parents, headers)
+	}
+
+	// the parents were verified along with the first header already
+	if len(parents) > 0 {
+		return nil
+	}
+
+	if err := b.consensus.VerifyHeader(headers[0]); err != nil {
+		return fmt.Errorf("failed to verify the header: %w", err)
+	}
+
+	return nil
+}
+
 // VerifyPotentialBlock does the minimal block verification without consulting the
 // consensus layer. Should only be used if consensus checks are done
 // outside the method call
@@ -12,6 +12,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
@@ -1390,3 +1391,98 @@ func TestBlockchain_CalculateBaseFee(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockchain_VerifyHeaders(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(5)
+	newHeaders := AppendNewTestHeaders(headers, 3)[len(headers):]
+
+	errInvalidSeal := errors.New("invalid seal")
+
+	t.Run("should verify the headers following the local chain", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, headers)
+
+		assert.NoError(t, b.VerifyHeaders(nil, newHeaders))
+
+		// the headers aren't written
+		assert.Equal(t, headers[len(headers)-1].Hash, b.Header().Hash)
+	})
+
+	t.Run("should return error if the headers are empty", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, headers)
+
+		assert.Error(t, b.VerifyHeaders(nil, nil))
+	})
+
+	t.Run("should return error if the parent is unknown", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, headers)
+
+		assert.ErrorIs(t, b.VerifyHeaders(nil, newHeaders[1:]), ErrParentNotFound)
+	})
+
+	t.Run("should verify the headers following the verified parents", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, headers)
+
+		assert.NoError(t, b.VerifyHeaders(newHeaders[:1], newHeaders[1:]))
+		assert.ErrorIs(t, b.VerifyHeaders(newHeaders[:1], newHeaders[2:]), ErrParentHashMismatch)
+	})
+
+	t.Run("should return error if the headers aren't chained", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, headers)
+
+		assert.ErrorIs(
+			t,
+			b.VerifyHeaders(nil, []*types.Header{newHeaders[0], newHeaders[2]}),
+			ErrParentHashMismatch,
+		)
+	})
+
+	t.Run("should return error if the consensus rejects the header", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, headers)
+
+		verifier, ok := b.consensus.(*MockVerifier)
+		require.True(t, ok)
+
+		verifier.HookVerifyHeader(func(h *types.Header) error {
+			return errInvalidSeal
+		})
+
+		assert.ErrorIs(t, b.VerifyHeaders(nil, newHeaders), errInvalidSeal)
+	})
+
+	t.Run("should verify only the header following the local chain by the consensus", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, headers)
+
+		verifier, ok := b.consensus.(*MockVerifier)
+		require.True(t, ok)
+
+		verified := make([]uint64, 0, len(newHeaders))
+
+		verifier.HookVerifyHeader(func(h *types.Header) error {
+			verified = append(verified, h.Number)
+
+			return nil
+		})
+
+		assert.NoError(t, b.VerifyHeaders(nil, newHeaders[:1]))
+		assert.NoError(t, b.VerifyHeaders(newHeaders[:1], newHeaders[1:]))
+
+		// the rest are verified by the consensus along with their blocks
+		assert.Equal(t, []uint64{newHeaders[0].Number}, verified)
+	})
+}
@@ -30,6 +30,9 @@ type Params struct {
 
 	// Governance contract where the token will be sent to and burn in london fork
 	BurnContract map[uint64]string `json:"burnContract"`
+
+	// BlockBuildingPolicy selects the ordering of the transactions during block building
+	BlockBuildingPolicy *BlockBuildingPolicyConfig `json:"blockBuildingPolicy,omitempty"`
 }
 
 type AddressListConfig struct {
@@ -40,6 +43,18 @@ type AddressListConfig struct {
 	EnabledAddresses []types.Address `json:"enabledAddresses,omitempty"`
 }
 
+// BlockBuildingPolicyConfig is the configuration of the block building policy
+type BlockBuildingPolicyConfig struct {
+	// Name is the name of the policy (price, fifo, fair or reserved-gas)
+	Name string `json:"name"`
+
+	// ReservedGas is the gas of each block reserved for the whitelisted addresses
+	ReservedGas uint64 `json:"reservedGas,omitempty"`
+
+	// WhitelistedAddresses is the list of the senders allowed to spend the reserved gas
+	WhitelistedAddresses []types.Address `json:"whitelistedAddresses,omitempty"`
+}
+
 // CalculateBurnContract calculates burn contract address for the given block number
 func (p *Params) CalculateBurnContract(block uint64) (types.Address, error) {
 	blocks := make([]uint64, 0, len(p.BurnContract))
@@ -82,9 +97,13 @@ const (
 	Petersburg     = "petersburg"
 	Istanbul       = "istanbul"
 	London         = "london"
+	Berlin         = "berlin"   // EIP-2929, EIP-2930
+	Shanghai       = "shanghai" // EIP-3651, EIP-3855, EIP-3860
+	Cancun         = "cancun"   // EIP-1153, EIP-5656
 	EIP150         = "EIP150"
 	EIP158         = "EIP158"
 	EIP155         = "EIP155"
+	EIP3529        = "EIP3529" // reduction in refunds, activated separately from London to keep the existing chains valid
 )
 
 // Forks is map which contains all forks and their starting blocks from genesis
@@ -111,9 +130,13 @@ func (f *Forks) At(block uint64) ForksInTime {
 		Petersburg:     f.IsActive(Petersburg, block),
 		Istanbul:       f.IsActive(Istanbul, block),
 		London:         f.IsActive(London, block),
+		Berlin:         f.IsActive(Berlin, block),
+		Shanghai:       f.IsActive(Shanghai, block),
+		Cancun:         f.IsActive(Cancun, block),
 		EIP150:         f.IsActive(EIP150, block),
 		EIP158:         f.IsActive(EIP158, block),
 		EIP155:         f.IsActive(EIP155, block),
+		EIP3529:        f.IsActive(EIP3529, block),
 	}
 }
 
@@ -141,9 +164,13 @@ type ForksInTime struct {
 	Petersburg,
 	Istanbul,
 	London,
+	Berlin,
+	Shanghai,
+	Cancun,
 	EIP150,
 	EIP158,
-	EIP155 bool
+	EIP155,
+	EIP3529 bool
 }
 
 // AllForksEnabled should contain all supported forks by current edge version
@@ -157,4 +184,8 @@ var AllForksEnabled = &Forks{
 	Petersburg:     NewFork(0),
 	Istanbul:       NewFork(0),
 	London:         NewFork(0),
+	Berlin:         NewFork(0),
+	Shanghai:       NewFork(0),
+	Cancun:         NewFork(0),
+	EIP3529:        NewFork(0),
 }
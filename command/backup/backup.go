@@ -16,6 +16,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterGRPCAddressFlag(backupCmd)
+	helper.RegisterGRPCClientTLSFlags(backupCmd)
 
 	setFlags(backupCmd)
 	helper.SetRequiredFlags(backupCmd, params.getRequiredFlags())
@@ -28,7 +29,23 @@ func setFlags(cmd *cobra.Command) {
 		&params.out,
 		outFlag,
 		"",
-		"the export path for the backup",
+		"the export path for the backup. If the path is an existing archive directory, "+
+			"the backup continues after the latest block of the archive",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.chunkSize,
+		chunkSizeFlag,
+		0,
+		"the number of blocks per chunk file, stores the backup as a directory of chunks "+
+			"with a manifest, which can be extended by the later backups",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.compress,
+		compressFlag,
+		false,
+		"compress the chunk files with gzip, stores the backup as a directory of chunks",
 	)
 
 	cmd.Flags().StringVar(
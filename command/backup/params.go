@@ -11,9 +11,11 @@ import (
 )
 
 const (
-	outFlag  = "out"
-	fromFlag = "from"
-	toFlag   = "to"
+	outFlag       = "out"
+	fromFlag      = "from"
+	toFlag        = "to"
+	chunkSizeFlag = "chunk-size"
+	compressFlag  = "compress"
 )
 
 var (
@@ -28,6 +30,9 @@ var (
 type backupParams struct {
 	out string
 
+	chunkSize uint64
+	compress  bool
+
 	fromRaw string
 	toRaw   string
 
@@ -76,17 +81,39 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 		return err
 	}
 
-	// resFrom and resTo represents the range of blocks that can be included in the file
-	resFrom, resTo, err := archive.CreateBackup(
-		connection,
-		hclog.New(&hclog.LoggerOptions{
-			Name:  "backup",
-			Level: hclog.LevelFromString("INFO"),
-		}),
-		p.from,
-		p.to,
-		p.out,
-	)
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "backup",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	var resFrom, resTo uint64
+
+	// resFrom and resTo represents the range of blocks that can be included in the archive
+	if p.isChunked() {
+		compression := ""
+		if p.compress {
+			compression = archive.GzipCompression
+		}
+
+		resFrom, resTo, err = archive.CreateChunkedBackup(
+			connection,
+			logger,
+			p.from,
+			p.to,
+			p.out,
+			p.chunkSize,
+			compression,
+		)
+	} else {
+		resFrom, resTo, err = archive.CreateBackup(
+			connection,
+			logger,
+			p.from,
+			p.to,
+			p.out,
+		)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -97,6 +124,12 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 	return nil
 }
 
+// isChunked returns true if the backup is stored as a directory of chunk files,
+// which is required for the incremental backups, chunking and compression
+func (p *backupParams) isChunked() bool {
+	return p.chunkSize > 0 || p.compress || archive.IsChunkedArchive(p.out)
+}
+
 func (p *backupParams) getResult() command.CommandResult {
 	return &BackupResult{
 		From: p.resFrom,
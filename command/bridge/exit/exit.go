@@ -10,7 +10,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/umbracle/ethgo"
-	"github.com/umbracle/ethgo/jsonrpc"
 
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/0xPolygon/polygon-edge/command/bridge/common"
@@ -97,6 +96,10 @@ func GetCommand() *cobra.Command {
 		"test indicates whether exit transaction sender is hardcoded test account",
 	)
 
+	// the credentials authenticate the command to the child chain JSON RPC endpoint
+	cmdHelper.RegisterJSONRPCJWTSecretFlag(exitCmd)
+	cmdHelper.RegisterJSONRPCClientTLSFlags(exitCmd)
+
 	_ = exitCmd.MarkFlagRequired(exitHelperFlag)
 	exitCmd.MarkFlagsMutuallyExclusive(helper.TestModeFlag, common.SenderKeyFlag)
 
@@ -121,13 +124,22 @@ func run(cmd *cobra.Command, _ []string) {
 		return
 	}
 
-	childClient, err := jsonrpc.NewClient(ep.childJSONRPCAddr)
+	creds, err := cmdHelper.GetJSONRPCCredentials(cmd)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	childClient, err := txrelayer.NewClient(ep.childJSONRPCAddr, creds)
 	if err != nil {
 		outputter.SetError(fmt.Errorf("could not create child chain JSON RPC client: %w", err))
 
 		return
 	}
 
+	defer childClient.Close()
+
 	// acquire proof for given exit event
 	var proof types.Proof
 
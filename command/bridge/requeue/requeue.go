@@ -0,0 +1,102 @@
+package requeue
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/statesyncrelayer"
+)
+
+const (
+	// flag names
+	dataDirFlag     = "data-dir"
+	stateSyncIDFlag = "id"
+)
+
+type requeueParams struct {
+	dataDir      string
+	stateSyncIDs []uint
+}
+
+var (
+	// rp represents requeue command parameters
+	rp *requeueParams = &requeueParams{}
+)
+
+// GetCommand returns the bridge requeue-state-syncs command
+func GetCommand() *cobra.Command {
+	requeueCmd := &cobra.Command{
+		Use: "requeue-state-syncs",
+		Short: "Requeues the state syncs the relayer of the stopped node failed to execute in all the attempts, " +
+			"so that the relayer executes them again once the node is started",
+		Run: run,
+	}
+
+	requeueCmd.Flags().StringVar(
+		&rp.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node running the state sync relayer",
+	)
+
+	requeueCmd.Flags().UintSliceVar(
+		&rp.stateSyncIDs,
+		stateSyncIDFlag,
+		nil,
+		"the ids of the failed state syncs to requeue (all the failed state syncs if not set)",
+	)
+
+	_ = requeueCmd.MarkFlagRequired(dataDirFlag)
+
+	return requeueCmd
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	ids := make([]uint64, len(rp.stateSyncIDs))
+	for i, id := range rp.stateSyncIDs {
+		ids[i] = uint64(id)
+	}
+
+	requeued, err := statesyncrelayer.RequeueFailedStateSyncs(rp.dataDir, ids)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("failed to requeue the state syncs: %w", err))
+
+		return
+	}
+
+	outputter.SetCommandResult(&requeueResult{IDs: requeued})
+}
+
+type requeueResult struct {
+	IDs []uint64 `json:"ids"`
+}
+
+func (r *requeueResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	ids := make([]string, len(r.IDs))
+	for i, id := range r.IDs {
+		ids[i] = fmt.Sprintf("%d", id)
+	}
+
+	buffer.WriteString("\n[REQUEUED STATE SYNCS]\n")
+
+	if len(ids) == 0 {
+		buffer.WriteString("No failed state syncs found\n")
+	} else {
+		buffer.WriteString(cmdHelper.FormatKV([]string{
+			fmt.Sprintf("State Sync IDs|%s", strings.Join(ids, ", ")),
+		}))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
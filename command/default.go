@@ -27,6 +27,14 @@ const (
 	JSONOutputFlag  = "json"
 	GRPCAddressFlag = "grpc-address"
 	JSONRPCFlag     = "jsonrpc"
+
+	GRPCTLSCAFlag        = "grpc-tls-ca"
+	GRPCTLSCertFlag      = "grpc-tls-cert"
+	GRPCTLSKeyFlag       = "grpc-tls-key"
+	JSONRPCJWTSecretFlag = "jsonrpc-jwt-secret"
+	JSONRPCTLSCAFlag     = "jsonrpc-tls-ca"
+	JSONRPCTLSCertFlag   = "jsonrpc-tls-cert"
+	JSONRPCTLSKeyFlag    = "jsonrpc-tls-key"
 )
 
 // GRPCAddressFlagLEGACY Legacy flag that needs to be present to preserve backwards
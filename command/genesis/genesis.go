@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/validators"
 	"github.com/spf13/cobra"
 )
@@ -79,6 +80,30 @@ func setFlags(cmd *cobra.Command) {
 		"the burn contract blocks and addresses (format: <block>:<address>)",
 	)
 
+	cmd.Flags().StringVar(
+		&params.blockBuildingPolicy,
+		blockBuildingPolicyFlag,
+		"",
+		fmt.Sprintf(
+			"the policy ordering the transactions during block building %v (default %s)",
+			txpool.BlockBuildingPolicies, txpool.PricePolicy,
+		),
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.reservedGas,
+		reservedGasFlag,
+		0,
+		"the gas of each block reserved for the reserved gas addresses (used by the reserved-gas policy)",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.reservedGasAddresses,
+		reservedGasAddressesFlag,
+		[]string{},
+		"the addresses allowed to spend the reserved gas (used by the reserved-gas policy)",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.bootnodes,
 		command.BootnodeFlag,
@@ -20,25 +20,29 @@ import (
 	"github.com/0xPolygon/polygon-edge/contracts/staking"
 	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
 )
 
 const (
-	dirFlag               = "dir"
-	nameFlag              = "name"
-	premineFlag           = "premine"
-	chainIDFlag           = "chain-id"
-	epochSizeFlag         = "epoch-size"
-	epochRewardFlag       = "epoch-reward"
-	blockGasLimitFlag     = "block-gas-limit"
-	burnContractFlag      = "burn-contract"
-	posFlag               = "pos"
-	minValidatorCount     = "min-validator-count"
-	maxValidatorCount     = "max-validator-count"
-	nativeTokenConfigFlag = "native-token-config"
-	rewardTokenCodeFlag   = "reward-token-code"
-	rewardWalletFlag      = "reward-wallet"
+	dirFlag                  = "dir"
+	nameFlag                 = "name"
+	premineFlag              = "premine"
+	chainIDFlag              = "chain-id"
+	epochSizeFlag            = "epoch-size"
+	epochRewardFlag          = "epoch-reward"
+	blockGasLimitFlag        = "block-gas-limit"
+	burnContractFlag         = "burn-contract"
+	blockBuildingPolicyFlag  = "block-building-policy"
+	reservedGasFlag          = "reserved-gas"
+	reservedGasAddressesFlag = "reserved-gas-addresses"
+	posFlag                  = "pos"
+	minValidatorCount        = "min-validator-count"
+	maxValidatorCount        = "max-validator-count"
+	nativeTokenConfigFlag    = "native-token-config"
+	rewardTokenCodeFlag      = "reward-token-code"
+	rewardWalletFlag         = "reward-wallet"
 
 	defaultNativeTokenName     = "Polygon"
 	defaultNativeTokenSymbol   = "MATIC"
@@ -83,6 +87,10 @@ type genesisParams struct {
 
 	burnContracts []string
 
+	blockBuildingPolicy  string
+	reservedGas          uint64
+	reservedGasAddresses []string
+
 	minNumValidators uint64
 	maxNumValidators uint64
 
@@ -153,6 +161,10 @@ func (p *genesisParams) validateFlags() error {
 		}
 	}
 
+	if err := p.validateBlockBuildingPolicy(); err != nil {
+		return err
+	}
+
 	// Check if the genesis file already exists
 	if generateError := verifyGenesisExistence(p.genesisPath); generateError != nil {
 		return errors.New(generateError.GetMessage())
@@ -177,6 +189,33 @@ func (p *genesisParams) validateFlags() error {
 	return command.ValidateMinMaxValidatorsNumber(p.minNumValidators, p.maxNumValidators)
 }
 
+func (p *genesisParams) validateBlockBuildingPolicy() error {
+	if _, err := txpool.NewBlockBuildingPolicy(p.blockBuildingPolicy, 0, nil); err != nil {
+		return err
+	}
+
+	if p.blockBuildingPolicy == txpool.ReservedGasPolicy && p.reservedGas >= p.blockGasLimit {
+		return fmt.Errorf("reserved gas (%d) must be lower than the block gas limit (%d)",
+			p.reservedGas, p.blockGasLimit)
+	}
+
+	return nil
+}
+
+// getBlockBuildingPolicyConfig returns the block building policy configuration,
+// or nil if the default policy is used
+func (p *genesisParams) getBlockBuildingPolicyConfig() *chain.BlockBuildingPolicyConfig {
+	if p.blockBuildingPolicy == "" {
+		return nil
+	}
+
+	return &chain.BlockBuildingPolicyConfig{
+		Name:                 p.blockBuildingPolicy,
+		ReservedGas:          p.reservedGas,
+		WhitelistedAddresses: stringSliceToAddressSlice(p.reservedGasAddresses),
+	}
+}
+
 func (p *genesisParams) isIBFTConsensus() bool {
 	return server.ConsensusType(p.consensusRaw) == server.IBFTConsensus
 }
@@ -383,9 +422,10 @@ func (p *genesisParams) initGenesisConfig() error {
 			GasUsed:    command.DefaultGenesisGasUsed,
 		},
 		Params: &chain.Params{
-			ChainID: int64(p.chainID),
-			Forks:   enabledForks,
-			Engine:  p.consensusEngineConfig,
+			ChainID:             int64(p.chainID),
+			Forks:               enabledForks,
+			Engine:              p.consensusEngineConfig,
+			BlockBuildingPolicy: p.getBlockBuildingPolicyConfig(),
 		},
 		Bootnodes: p.bootnodes,
 	}
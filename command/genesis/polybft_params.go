@@ -165,6 +165,7 @@ func (p *genesisParams) generatePolyBftChainConfig(o command.OutputFormatter) er
 			Engine: map[string]interface{}{
 				string(server.PolyBFTConsensus): polyBftConfig,
 			},
+			BlockBuildingPolicy: p.getBlockBuildingPolicyConfig(),
 		},
 		Bootnodes: p.bootnodes,
 	}
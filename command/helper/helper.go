@@ -13,15 +13,27 @@ import (
 	"github.com/0xPolygon/polygon-edge/command"
 	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/jwt"
+	"github.com/0xPolygon/polygon-edge/helper/tlsconfig"
 	"github.com/0xPolygon/polygon-edge/server"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	txpoolOp "github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
 	"github.com/ryanuber/columnize"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// grpcClientTLS holds the TLS credentials of the GRPC clients,
+// set by the flags registered with RegisterGRPCClientTLSFlags
+var grpcClientTLS struct {
+	caFile   string
+	certFile string
+	keyFile  string
+}
+
 type ClientCloseResult struct {
 	Message string `json:"message"`
 }
@@ -137,9 +149,21 @@ func GetIBFTOperatorClientConnection(address string) (
 	return ibftOp.NewIbftOperatorClient(conn), nil
 }
 
-// GetGRPCConnection returns a grpc client connection
+// GetGRPCConnection returns a grpc client connection,
+// secured with TLS if any of the GRPC client TLS flags is set
 func GetGRPCConnection(address string) (*grpc.ClientConn, error) {
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds := insecure.NewCredentials()
+
+	if grpcClientTLS.caFile != "" || grpcClientTLS.certFile != "" || grpcClientTLS.keyFile != "" {
+		config, err := tlsconfig.NewClientConfig(grpcClientTLS.caFile, grpcClientTLS.certFile, grpcClientTLS.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GRPC TLS credentials: %w", err)
+		}
+
+		creds = credentials.NewTLS(config)
+	}
+
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to server: %w", err)
 	}
@@ -162,6 +186,42 @@ func GetJSONRPCAddress(cmd *cobra.Command) string {
 	return cmd.Flag(command.JSONRPCFlag).Value.String()
 }
 
+// GetJSONRPCJWTSecret extracts the set JSON-RPC JWT secret path
+func GetJSONRPCJWTSecret(cmd *cobra.Command) string {
+	return cmd.Flag(command.JSONRPCJWTSecretFlag).Value.String()
+}
+
+// GetJSONRPCCredentials loads the JSON-RPC client credentials from the files set by the flags
+// registered with RegisterJSONRPCFlag (or RegisterJSONRPCJWTSecretFlag) and RegisterJSONRPCClientTLSFlags.
+// TLS is enabled if any of the TLS flags is set
+func GetJSONRPCCredentials(cmd *cobra.Command) (*txrelayer.Credentials, error) {
+	creds := &txrelayer.Credentials{}
+
+	if secretFile := GetJSONRPCJWTSecret(cmd); secretFile != "" {
+		secret, err := jwt.ReadSecret(secretFile)
+		if err != nil {
+			return nil, err
+		}
+
+		creds.JWTSecret = secret
+	}
+
+	caFile := cmd.Flag(command.JSONRPCTLSCAFlag).Value.String()
+	certFile := cmd.Flag(command.JSONRPCTLSCertFlag).Value.String()
+	keyFile := cmd.Flag(command.JSONRPCTLSKeyFlag).Value.String()
+
+	if caFile != "" || certFile != "" || keyFile != "" {
+		config, err := tlsconfig.NewClientConfig(caFile, certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON-RPC TLS credentials: %w", err)
+		}
+
+		creds.TLSConfig = config
+	}
+
+	return creds, nil
+}
+
 // GetJSONLogFormat extracts the set JSON Format flag
 func GetJSONLogFormat(cmd *cobra.Command) bool {
 	return cmd.Flag(command.JSONOutputFlag).Changed
@@ -185,6 +245,30 @@ func RegisterGRPCAddressFlag(cmd *cobra.Command) {
 	)
 }
 
+// RegisterGRPCClientTLSFlags registers the GRPC client TLS credentials flags for all child commands
+func RegisterGRPCClientTLSFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(
+		&grpcClientTLS.caFile,
+		command.GRPCTLSCAFlag,
+		"",
+		"the CA certificates verifying the GRPC server certificate (enables TLS)",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&grpcClientTLS.certFile,
+		command.GRPCTLSCertFlag,
+		"",
+		"the client certificate presented to the GRPC server requiring mutual TLS",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&grpcClientTLS.keyFile,
+		command.GRPCTLSKeyFlag,
+		"",
+		"the key of the GRPC client certificate",
+	)
+}
+
 // RegisterLegacyGRPCAddressFlag registers the legacy GRPC address flag for all child commands
 func RegisterLegacyGRPCAddressFlag(cmd *cobra.Command) {
 	cmd.PersistentFlags().String(
@@ -209,6 +293,39 @@ func RegisterJSONRPCFlag(cmd *cobra.Command) {
 		fmt.Sprintf("%s:%d", AllInterfacesBinding, server.DefaultJSONRPCPort),
 		"the JSON-RPC interface",
 	)
+
+	RegisterJSONRPCJWTSecretFlag(cmd)
+}
+
+// RegisterJSONRPCJWTSecretFlag registers the JSON-RPC JWT secret flag for all child commands.
+// It is registered by RegisterJSONRPCFlag, and separately only by the commands with their own JSON-RPC address flags
+func RegisterJSONRPCJWTSecretFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(
+		command.JSONRPCJWTSecretFlag,
+		"",
+		"the file with the hex encoded secret of the JWT authentication of the JSON-RPC interface",
+	)
+}
+
+// RegisterJSONRPCClientTLSFlags registers the JSON-RPC client TLS credentials flags for all child commands
+func RegisterJSONRPCClientTLSFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(
+		command.JSONRPCTLSCAFlag,
+		"",
+		"the CA certificates verifying the JSON-RPC server certificate (enables TLS)",
+	)
+
+	cmd.PersistentFlags().String(
+		command.JSONRPCTLSCertFlag,
+		"",
+		"the client certificate presented to the JSON-RPC server requiring mutual TLS",
+	)
+
+	cmd.PersistentFlags().String(
+		command.JSONRPCTLSKeyFlag,
+		"",
+		"the key of the JSON-RPC client certificate",
+	)
 }
 
 // ParseJSONRPCAddress parses the passed in JSONRPC address
@@ -18,6 +18,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterGRPCAddressFlag(ibftCmd)
+	helper.RegisterGRPCClientTLSFlags(ibftCmd)
 
 	registerSubcommands(ibftCmd)
 
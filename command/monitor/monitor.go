@@ -23,6 +23,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterGRPCAddressFlag(monitorCmd)
+	helper.RegisterGRPCClientTLSFlags(monitorCmd)
 
 	return monitorCmd
 }
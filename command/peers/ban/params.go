@@ -0,0 +1,78 @@
+package ban
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+var (
+	params = &banParams{}
+)
+
+var (
+	errInvalidDuration = errors.New("ban duration can't be negative")
+)
+
+const (
+	peerIDFlag   = "peer-id"
+	durationFlag = "duration"
+	reasonFlag   = "reason"
+)
+
+type banParams struct {
+	peerID   string
+	duration time.Duration
+	reason   string
+}
+
+func (p *banParams) getRequiredFlags() []string {
+	return []string{
+		peerIDFlag,
+	}
+}
+
+func (p *banParams) validateFlags() error {
+	if p.duration < 0 {
+		return errInvalidDuration
+	}
+
+	return nil
+}
+
+func (p *banParams) banPeer(grpcAddress string) error {
+	systemClient, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	if _, err := systemClient.PeersBan(
+		context.Background(),
+		&proto.PeersBanRequest{
+			Id:       p.peerID,
+			Duration: uint64(p.duration / time.Second),
+			Reason:   p.reason,
+		},
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *banParams) getResult() command.CommandResult {
+	result := &PeersBanResult{
+		ID:     p.peerID,
+		Reason: p.reason,
+	}
+
+	if p.duration > 0 {
+		result.Duration = p.duration.String()
+	}
+
+	return result
+}
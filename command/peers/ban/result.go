@@ -0,0 +1,33 @@
+package ban
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type PeersBanResult struct {
+	ID       string `json:"id"`
+	Duration string `json:"duration,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+func (r *PeersBanResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	duration := r.Duration
+	if duration == "" {
+		duration = "permanent"
+	}
+
+	buffer.WriteString("\n[PEER BANNED]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("ID|%s", r.ID),
+		fmt.Sprintf("Duration|%s", duration),
+		fmt.Sprintf("Reason|%s", r.Reason),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
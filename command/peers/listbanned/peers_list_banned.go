@@ -0,0 +1,46 @@
+package listbanned
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	peersListBannedCmd := &cobra.Command{
+		Use:   "list-banned",
+		Short: "Returns the list of banned peers",
+		Run:   runCommand,
+	}
+
+	return peersListBannedCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	bannedPeers, err := getBannedPeers(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(
+		newPeersListBannedResult(bannedPeers.Peers),
+	)
+}
+
+func getBannedPeers(grpcAddress string) (*proto.PeersListBannedResponse, error) {
+	client, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.PeersListBanned(context.Background(), &empty.Empty{})
+}
@@ -0,0 +1,62 @@
+package listbanned
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+type BannedPeer struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+	Expiry int64  `json:"expiry,omitempty"`
+}
+
+type PeersListBannedResult struct {
+	Peers []BannedPeer `json:"peers"`
+}
+
+func newPeersListBannedResult(peers []*proto.BannedPeer) *PeersListBannedResult {
+	resultPeers := make([]BannedPeer, len(peers))
+	for i, p := range peers {
+		resultPeers[i] = BannedPeer{
+			ID:     p.Id,
+			Reason: p.Reason,
+			Expiry: p.Expiry,
+		}
+	}
+
+	return &PeersListBannedResult{
+		Peers: resultPeers,
+	}
+}
+
+func (r *PeersListBannedResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BANNED PEERS]\n")
+
+	if len(r.Peers) == 0 {
+		buffer.WriteString("No banned peers found")
+	} else {
+		buffer.WriteString(fmt.Sprintf("Number of banned peers: %d\n\n", len(r.Peers)))
+
+		rows := make([]string, len(r.Peers))
+		for i, p := range r.Peers {
+			expiry := "permanent"
+			if p.Expiry != 0 {
+				expiry = time.Unix(p.Expiry, 0).UTC().Format(time.RFC3339)
+			}
+
+			rows[i] = fmt.Sprintf("[%d]|%s|%s|%s", i, p.ID, expiry, p.Reason)
+		}
+		buffer.WriteString(helper.FormatKV(rows))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,51 @@
+package unban
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+var (
+	params = &unbanParams{}
+)
+
+const (
+	peerIDFlag = "peer-id"
+)
+
+type unbanParams struct {
+	peerID string
+}
+
+func (p *unbanParams) getRequiredFlags() []string {
+	return []string{
+		peerIDFlag,
+	}
+}
+
+func (p *unbanParams) unbanPeer(grpcAddress string) error {
+	systemClient, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	if _, err := systemClient.PeersUnban(
+		context.Background(),
+		&proto.PeersUnbanRequest{
+			Id: p.peerID,
+		},
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *unbanParams) getResult() command.CommandResult {
+	return &PeersUnbanResult{
+		ID: p.peerID,
+	}
+}
@@ -4,9 +4,10 @@ import (
 	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
 	"github.com/spf13/cobra"
 	"github.com/umbracle/ethgo"
-	"github.com/umbracle/ethgo/jsonrpc"
 )
 
 var (
@@ -26,13 +27,22 @@ func GetRootCMD() *cobra.Command {
 		outputter := command.InitializeOutputter(getRootCmd)
 		defer outputter.WriteOutput()
 
-		rpcClient, err := jsonrpc.NewClient(jsonRPCAddress)
+		creds, err := helper.GetJSONRPCCredentials(cmd)
+		if err != nil {
+			outputter.SetError(err)
+
+			return
+		}
+
+		rpcClient, err := txrelayer.NewClient(jsonRPCAddress, creds)
 		if err != nil {
 			outputter.SetError(fmt.Errorf("connect to client error:%w", err))
 
 			return
 		}
 
+		defer rpcClient.Close()
+
 		block, err := rpcClient.Eth().GetBlockByNumber(ethgo.BlockNumber(blockNumber), false)
 		if err != nil {
 			outputter.SetError(fmt.Errorf("get block error:%w", err))
@@ -61,5 +71,8 @@ func GetRootCMD() *cobra.Command {
 		"Block number of trie snapshot",
 	)
 
+	helper.RegisterJSONRPCJWTSecretFlag(getRootCmd)
+	helper.RegisterJSONRPCClientTLSFlags(getRootCmd)
+
 	return getRootCmd
 }
@@ -311,6 +311,9 @@ func GetCommand() *cobra.Command {
 		helper.StakeManagerFlagDesc,
 	)
 
+	cmdHelper.RegisterJSONRPCJWTSecretFlag(cmd)
+	cmdHelper.RegisterJSONRPCClientTLSFlags(cmd)
+
 	cmd.MarkFlagsMutuallyExclusive(helper.TestModeFlag, deployerKeyFlag)
 	_ = cmd.MarkFlagRequired(helper.StakeManagerFlag)
 	_ = cmd.MarkFlagRequired(helper.StakeTokenFlag)
@@ -337,7 +340,14 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		return
 	}
 
-	client, err := jsonrpc.NewClient(params.jsonRPCAddress)
+	creds, err := cmdHelper.GetJSONRPCCredentials(cmd)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	client, err := txrelayer.NewClient(params.jsonRPCAddress, creds)
 	if err != nil {
 		outputter.SetError(fmt.Errorf("failed to initialize JSON RPC client for provided IP address: %s: %w",
 			params.jsonRPCAddress, err))
@@ -345,6 +355,8 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		return
 	}
 
+	defer client.Close()
+
 	if consensusCfg.Bridge != nil {
 		code, err := client.Eth().GetCode(ethgo.Address(consensusCfg.Bridge.StateSenderAddr), ethgo.Latest)
 		if err != nil {
@@ -360,7 +372,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		}
 	}
 
-	rootchainCfg, supernetID, err := deployContracts(outputter, client,
+	rootchainCfg, supernetID, err := deployContracts(outputter, client.Client,
 		chainConfig.Params.ChainID, consensusCfg.InitialValidatorSet, cmd.Context())
 	if err != nil {
 		outputter.SetError(fmt.Errorf("failed to deploy rootchain contracts: %w", err))
@@ -56,6 +56,7 @@ func setFlags(cmd *cobra.Command) {
 	)
 
 	helper.RegisterJSONRPCFlag(cmd)
+	helper.RegisterJSONRPCClientTLSFlags(cmd)
 	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountConfigFlag, polybftsecrets.AccountDirFlag)
 }
 
@@ -74,7 +75,13 @@ func runCommand(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC))
+	creds, err := helper.GetJSONRPCCredentials(cmd)
+	if err != nil {
+		return err
+	}
+
+	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC),
+		txrelayer.WithCredentials(creds))
 	if err != nil {
 		return err
 	}
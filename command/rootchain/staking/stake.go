@@ -30,6 +30,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterJSONRPCFlag(stakeCmd)
+	helper.RegisterJSONRPCClientTLSFlags(stakeCmd)
 	setFlags(stakeCmd)
 
 	return stakeCmd
@@ -96,7 +97,13 @@ func runCommand(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	creds, err := helper.GetJSONRPCCredentials(cmd)
+	if err != nil {
+		return err
+	}
+
 	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC),
+		txrelayer.WithCredentials(creds),
 		txrelayer.WithReceiptTimeout(150*time.Millisecond))
 	if err != nil {
 		return err
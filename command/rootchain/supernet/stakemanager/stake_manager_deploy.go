@@ -92,6 +92,7 @@ func setFlags(cmd *cobra.Command) {
 	cmd.MarkFlagsMutuallyExclusive(rootHelper.TestModeFlag, rootHelper.StakeTokenFlag)
 
 	helper.RegisterJSONRPCFlag(cmd)
+	helper.RegisterJSONRPCClientTLSFlags(cmd)
 }
 
 func runCommand(cmd *cobra.Command, _ []string) error {
@@ -113,7 +114,13 @@ func runCommand(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("faield to get deployer key: %w", err)
 	}
 
-	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC))
+	creds, err := helper.GetJSONRPCCredentials(cmd)
+	if err != nil {
+		return err
+	}
+
+	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC),
+		txrelayer.WithCredentials(creds))
 	if err != nil {
 		return fmt.Errorf("deploying stake manager failed: %w", err)
 	}
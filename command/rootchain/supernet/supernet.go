@@ -101,6 +101,7 @@ func setFlags(cmd *cobra.Command) {
 	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.PrivateKeyFlag, polybftsecrets.AccountDirFlag)
 
 	helper.RegisterJSONRPCFlag(cmd)
+	helper.RegisterJSONRPCClientTLSFlags(cmd)
 }
 
 func runCommand(cmd *cobra.Command, _ []string) error {
@@ -112,7 +113,13 @@ func runCommand(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC))
+	creds, err := helper.GetJSONRPCCredentials(cmd)
+	if err != nil {
+		return err
+	}
+
+	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC),
+		txrelayer.WithCredentials(creds))
 	if err != nil {
 		return fmt.Errorf("enlist validator failed: %w", err)
 	}
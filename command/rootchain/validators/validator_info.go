@@ -26,6 +26,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterJSONRPCFlag(validatorInfoCmd)
+	helper.RegisterJSONRPCClientTLSFlags(validatorInfoCmd)
 	setFlags(validatorInfoCmd)
 
 	return validatorInfoCmd
@@ -85,7 +86,13 @@ func runCommand(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC))
+	creds, err := helper.GetJSONRPCCredentials(cmd)
+	if err != nil {
+		return err
+	}
+
+	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC),
+		txrelayer.WithCredentials(creds))
 	if err != nil {
 		return err
 	}
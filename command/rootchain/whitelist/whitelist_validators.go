@@ -71,6 +71,7 @@ func setFlags(cmd *cobra.Command) {
 	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.PrivateKeyFlag, polybftsecrets.AccountDirFlag)
 
 	helper.RegisterJSONRPCFlag(cmd)
+	helper.RegisterJSONRPCClientTLSFlags(cmd)
 }
 
 func runPreRun(cmd *cobra.Command, _ []string) error {
@@ -88,7 +89,13 @@ func runCommand(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	creds, err := helper.GetJSONRPCCredentials(cmd)
+	if err != nil {
+		return err
+	}
+
 	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC),
+		txrelayer.WithCredentials(creds),
 		txrelayer.WithReceiptTimeout(150*time.Millisecond))
 	if err != nil {
 		return fmt.Errorf("whitelist validator failed. Could not create tx relayer: %w", err)
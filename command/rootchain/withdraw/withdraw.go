@@ -69,6 +69,7 @@ func setFlags(cmd *cobra.Command) {
 
 	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountDirFlag, polybftsecrets.AccountConfigFlag)
 	helper.RegisterJSONRPCFlag(cmd)
+	helper.RegisterJSONRPCClientTLSFlags(cmd)
 }
 
 func runPreRun(cmd *cobra.Command, _ []string) error {
@@ -86,7 +87,13 @@ func runCommand(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	creds, err := helper.GetJSONRPCCredentials(cmd)
+	if err != nil {
+		return err
+	}
+
 	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC),
+		txrelayer.WithCredentials(creds),
 		txrelayer.WithReceiptTimeout(150*time.Millisecond))
 	if err != nil {
 		return err
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/encryptedlocal"
 )
 
 func GetCommand() *cobra.Command {
@@ -50,10 +51,11 @@ func setFlags(cmd *cobra.Command) {
 		typeFlag,
 		string(secrets.HashicorpVault),
 		fmt.Sprintf(
-			"the type of the secrets manager. Available types: %s, %s and %s",
+			"the type of the secrets manager. Available types: %s, %s, %s and %s",
 			secrets.HashicorpVault,
 			secrets.AWSSSM,
 			secrets.GCPSSM,
+			secrets.EncryptedLocal,
 		),
 	)
 
@@ -75,7 +77,16 @@ func setFlags(cmd *cobra.Command) {
 		&params.extra,
 		extraFlag,
 		"",
-		"Specifies the extra fields map in string format 'key1=val1,key2=val2'",
+		fmt.Sprintf(
+			"Specifies the extra fields map in string format 'key1=val1,key2=val2'. "+
+				"The %s secrets manager accepts %s (the keystore directory), "+
+				"%s or %s (the environment variable with the keystore password, %s by default)",
+			secrets.EncryptedLocal,
+			secrets.Path,
+			secrets.PasswordFile,
+			secrets.PasswordEnv,
+			encryptedlocal.DefaultPasswordEnv,
+		),
 	)
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/hashicorp/hcl"
@@ -29,10 +30,24 @@ type Config struct {
 	LogFilePath              string     `json:"log_to" yaml:"log_to"`
 	JSONRPCBatchRequestLimit uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
 	JSONRPCBlockRangeLimit   uint64     `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
+	JSONRPCIPCPath           string     `json:"json_rpc_ipc_path" yaml:"json_rpc_ipc_path"`
 	JSONLogFormat            bool       `json:"json_log_format" yaml:"json_log_format"`
 
-	Relayer               bool   `json:"relayer" yaml:"relayer"`
-	NumBlockConfirmations uint64 `json:"num_block_confirmations" yaml:"num_block_confirmations"`
+	Relayer                bool   `json:"relayer" yaml:"relayer"`
+	ExitRelayer            bool   `json:"exit_relayer" yaml:"exit_relayer"`
+	ExitRelayerRootJSONRPC string `json:"exit_relayer_root_json_rpc" yaml:"exit_relayer_root_json_rpc"`
+	ExitRelayerRootKeyFile string `json:"exit_relayer_root_key_file" yaml:"exit_relayer_root_key_file"`
+	NumBlockConfirmations  uint64 `json:"num_block_confirmations" yaml:"num_block_confirmations"`
+
+	StatePruning *StatePruning `json:"state_pruning" yaml:"state_pruning"`
+
+	JSONRPCLimits *JSONRPCLimits `json:"json_rpc_limits" yaml:"json_rpc_limits"`
+
+	JSONRPCSlowRequestThreshold uint64 `json:"json_rpc_slow_request_threshold" yaml:"json_rpc_slow_request_threshold"`
+
+	GRPCTLS          *TLS   `json:"grpc_tls" yaml:"grpc_tls"`
+	JSONRPCTLS       *TLS   `json:"json_rpc_tls" yaml:"json_rpc_tls"`
+	JSONRPCJWTSecret string `json:"json_rpc_jwt_secret" yaml:"json_rpc_jwt_secret"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -49,13 +64,46 @@ type Network struct {
 	MaxPeers         int64  `json:"max_peers,omitempty" yaml:"max_peers,omitempty"`
 	MaxOutboundPeers int64  `json:"max_outbound_peers,omitempty" yaml:"max_outbound_peers,omitempty"`
 	MaxInboundPeers  int64  `json:"max_inbound_peers,omitempty" yaml:"max_inbound_peers,omitempty"`
+
+	AllowList    []string `json:"allow_list,omitempty" yaml:"allow_list,omitempty"`
+	DenyList     []string `json:"deny_list,omitempty" yaml:"deny_list,omitempty"`
+	BanThreshold int64    `json:"ban_threshold" yaml:"ban_threshold"`
+	BanDuration  uint64   `json:"ban_duration" yaml:"ban_duration"`
 }
 
 // TxPool defines the TxPool configuration params
 type TxPool struct {
-	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
-	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
-	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	PriceLimit          uint64 `json:"price_limit" yaml:"price_limit"`
+	MaxSlots            uint64 `json:"max_slots" yaml:"max_slots"`
+	MaxAccountEnqueued  uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	PriceBump           uint64 `json:"price_bump" yaml:"price_bump"`
+	Journal             string `json:"journal" yaml:"journal"`
+	JournalRotation     uint64 `json:"journal_rotation" yaml:"journal_rotation"`
+	BlockBuildingPolicy string `json:"block_building_policy" yaml:"block_building_policy"`
+}
+
+// StatePruning defines the state trie pruning configuration params
+type StatePruning struct {
+	Mode               string `json:"mode" yaml:"mode"`
+	Retain             uint64 `json:"retain" yaml:"retain"`
+	CheckpointInterval uint64 `json:"checkpoint_interval" yaml:"checkpoint_interval"`
+}
+
+// JSONRPCLimits defines the JSON-RPC request limits
+type JSONRPCLimits struct {
+	RateLimit             float64  `json:"rate_limit" yaml:"rate_limit"`
+	RateLimitBurst        uint64   `json:"rate_limit_burst" yaml:"rate_limit_burst"`
+	MethodRateLimits      []string `json:"method_rate_limits" yaml:"method_rate_limits"`
+	AllowedMethods        []string `json:"allowed_methods" yaml:"allowed_methods"`
+	DeniedMethods         []string `json:"denied_methods" yaml:"denied_methods"`
+	MaxConcurrentRequests uint64   `json:"max_concurrent_requests" yaml:"max_concurrent_requests"`
+}
+
+// TLS defines the certificate files of a server accepting only TLS connections
+type TLS struct {
+	CertFile     string `json:"cert_file" yaml:"cert_file"`
+	KeyFile      string `json:"key_file" yaml:"key_file"`
+	ClientCAFile string `json:"client_ca_file" yaml:"client_ca_file"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -75,9 +123,25 @@ const (
 	// requests with fromBlock/toBlock values (e.g. eth_getLogs)
 	DefaultJSONRPCBlockRangeLimit uint64 = 1000
 
+	// DefaultJSONRPCIPCPath is the path of the json_rpc IPC socket, relative to the data directory
+	DefaultJSONRPCIPCPath = "jsonrpc.ipc"
+
 	// DefaultNumBlockConfirmations minimal number of child blocks required for the parent block to be considered final
 	// on ethereum epoch lasts for 32 blocks. more details: https://www.alchemy.com/overviews/ethereum-commitment-levels
 	DefaultNumBlockConfirmations uint64 = 64
+
+	// ArchiveStatePruningMode keeps the states of all the blocks
+	ArchiveStatePruningMode = "archive"
+
+	// PrunedStatePruningMode keeps only the states of the latest and the checkpoint blocks
+	PrunedStatePruningMode = "pruned"
+
+	// DefaultStatePruningRetain is the default number of the latest block states kept in pruned mode
+	DefaultStatePruningRetain uint64 = 128
+
+	// DefaultStatePruningCheckpointInterval is the default interval of blocks
+	// whose states are kept forever in pruned mode
+	DefaultStatePruningCheckpointInterval uint64 = 10000
 )
 
 // DefaultConfig returns the default server configuration
@@ -97,6 +161,8 @@ func DefaultConfig() *Config {
 				defaultNetworkConfig.Addr.IP,
 				defaultNetworkConfig.Addr.Port,
 			),
+			BanThreshold: defaultNetworkConfig.BanThreshold,
+			BanDuration:  uint64(defaultNetworkConfig.BanDuration / time.Second),
 		},
 		Telemetry:  &Telemetry{},
 		ShouldSeal: true,
@@ -104,6 +170,9 @@ func DefaultConfig() *Config {
 			PriceLimit:         0,
 			MaxSlots:           4096,
 			MaxAccountEnqueued: 128,
+			PriceBump:          10,
+			Journal:            "transactions.rlp",
+			JournalRotation:    uint64(time.Hour / time.Second),
 		},
 		LogLevel:    "INFO",
 		RestoreFile: "",
@@ -113,8 +182,18 @@ func DefaultConfig() *Config {
 		LogFilePath:              "",
 		JSONRPCBatchRequestLimit: DefaultJSONRPCBatchRequestLimit,
 		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
+		JSONRPCIPCPath:           DefaultJSONRPCIPCPath,
 		Relayer:                  false,
+		ExitRelayer:              false,
 		NumBlockConfirmations:    DefaultNumBlockConfirmations,
+		StatePruning: &StatePruning{
+			Mode:               ArchiveStatePruningMode,
+			Retain:             DefaultStatePruningRetain,
+			CheckpointInterval: DefaultStatePruningCheckpointInterval,
+		},
+		JSONRPCLimits: &JSONRPCLimits{},
+		GRPCTLS:       &TLS{},
+		JSONRPCTLS:    &TLS{},
 	}
 }
 
@@ -146,6 +225,8 @@ func ReadConfigFile(path string) (*Config, error) {
 	config.Network.MaxPeers = -1
 	config.Network.MaxInboundPeers = -1
 	config.Network.MaxOutboundPeers = -1
+	config.Network.BanThreshold = network.DefaultBanThreshold
+	config.Network.BanDuration = uint64(network.DefaultBanDuration / time.Second)
 
 	if err := unmarshalFunc(data, config); err != nil {
 		return nil, err
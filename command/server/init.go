@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"strconv"
+	"strings"
 
 	"github.com/0xPolygon/polygon-edge/command/server/config"
 
@@ -12,10 +14,12 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 var (
@@ -49,11 +53,36 @@ func (p *serverParams) initRawParams() error {
 		return err
 	}
 
+	if err := p.initStatePruning(); err != nil {
+		return err
+	}
+
+	if p.rawConfig.ExitRelayer && p.rawConfig.ExitRelayerRootKeyFile == "" {
+		return errExitRelayerKeyMissing
+	}
+
 	if p.isDevMode {
 		p.initDevMode()
 	}
 
 	p.initPeerLimits()
+
+	if err := p.initPeerScoring(); err != nil {
+		return err
+	}
+
+	if err := p.initJSONRPCLimits(); err != nil {
+		return err
+	}
+
+	if err := validateTLS(p.rawConfig.GRPCTLS); err != nil {
+		return fmt.Errorf("invalid grpc TLS config, %w", err)
+	}
+
+	if err := validateTLS(p.rawConfig.JSONRPCTLS); err != nil {
+		return fmt.Errorf("invalid json-rpc TLS config, %w", err)
+	}
+
 	p.initLogFileLocation()
 
 	p.relayer = p.rawConfig.Relayer
@@ -69,6 +98,21 @@ func (p *serverParams) initDataDirLocation() error {
 	return nil
 }
 
+func (p *serverParams) initStatePruning() error {
+	switch p.rawConfig.StatePruning.Mode {
+	case config.ArchiveStatePruningMode:
+		return nil
+	case config.PrunedStatePruningMode:
+		if p.rawConfig.StatePruning.Retain == 0 {
+			return errInvalidStatePruning
+		}
+
+		return nil
+	default:
+		return errInvalidStatePruningMode
+	}
+}
+
 func (p *serverParams) initLogFileLocation() {
 	if p.isLogFileLocationSet() {
 		p.logFileLocation = p.rawConfig.LogFilePath
@@ -173,6 +217,99 @@ func (p *serverParams) initDefaultPeerLimits() {
 	p.rawConfig.Network.MaxOutboundPeers = defaultNetworkConfig.MaxOutboundPeers
 }
 
+func (p *serverParams) initPeerScoring() error {
+	if p.rawConfig.Network.BanThreshold >= 0 {
+		return errInvalidPeerBanThreshold
+	}
+
+	var err error
+
+	if p.allowList, err = parsePeerIDs(p.rawConfig.Network.AllowList); err != nil {
+		return fmt.Errorf("invalid allowlist, %w", err)
+	}
+
+	if p.denyList, err = parsePeerIDs(p.rawConfig.Network.DenyList); err != nil {
+		return fmt.Errorf("invalid denylist, %w", err)
+	}
+
+	return nil
+}
+
+// parsePeerIDs decodes the libp2p peer IDs
+func parsePeerIDs(rawIDs []string) ([]peer.ID, error) {
+	ids := make([]peer.ID, 0, len(rawIDs))
+
+	for _, rawID := range rawIDs {
+		id, err := peer.Decode(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode peer ID %s, %w", rawID, err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (p *serverParams) initJSONRPCLimits() error {
+	if p.rawConfig.JSONRPCLimits.RateLimit < 0 {
+		return errInvalidJSONRPCRateLimit
+	}
+
+	var err error
+
+	if p.jsonRPCMethodRateLimits, err = parseMethodRateLimits(p.rawConfig.JSONRPCLimits.MethodRateLimits); err != nil {
+		return fmt.Errorf("invalid json-rpc method rate limit, %w", err)
+	}
+
+	return nil
+}
+
+// validateTLS checks that the TLS certificate is set together with its key
+func validateTLS(rawTLS *config.TLS) error {
+	if (rawTLS.CertFile == "") != (rawTLS.KeyFile == "") {
+		return errInvalidTLSCertificate
+	}
+
+	if rawTLS.ClientCAFile != "" && rawTLS.CertFile == "" {
+		return errors.New("client CA requires the server TLS certificate")
+	}
+
+	return nil
+}
+
+// parseMethodRateLimits decodes the json-rpc method group rate limits
+// in the <namespace|method>=<rate>[:<burst>] format
+func parseMethodRateLimits(rawLimits []string) (map[string]jsonrpc.RateLimit, error) {
+	limits := make(map[string]jsonrpc.RateLimit, len(rawLimits))
+
+	for _, rawLimit := range rawLimits {
+		group, rawRate, found := strings.Cut(rawLimit, "=")
+		if !found || group == "" {
+			return nil, fmt.Errorf("expected <namespace|method>=<rate>[:<burst>], got %s", rawLimit)
+		}
+
+		rawRate, rawBurst, hasBurst := strings.Cut(rawRate, ":")
+
+		rate, err := strconv.ParseFloat(rawRate, 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid rate of %s: %s", group, rawRate)
+		}
+
+		limit := jsonrpc.RateLimit{Rate: rate}
+
+		if hasBurst {
+			if limit.Burst, err = strconv.Atoi(rawBurst); err != nil || limit.Burst <= 0 {
+				return nil, fmt.Errorf("invalid burst of %s: %s", group, rawBurst)
+			}
+		}
+
+		limits[group] = limit
+	}
+
+	return limits, nil
+}
+
 func (p *serverParams) initUsingPeerRange() {
 	defaultConfig := network.DefaultConfig()
 
@@ -3,13 +3,16 @@ package server
 import (
 	"errors"
 	"net"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -25,11 +28,21 @@ const (
 	maxPeersFlag                 = "max-peers"
 	maxInboundPeersFlag          = "max-inbound-peers"
 	maxOutboundPeersFlag         = "max-outbound-peers"
+	allowListFlag                = "allowlist"
+	denyListFlag                 = "denylist"
+	peerBanThresholdFlag         = "peer-ban-threshold"
+	peerBanDurationFlag          = "peer-ban-duration"
 	priceLimitFlag               = "price-limit"
 	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
+	jsonRPCIPCPathFlag           = "json-rpc-ipc-path"
+	jsonRPCSlowRequestFlag       = "json-rpc-slow-request-threshold"
 	maxSlotsFlag                 = "max-slots"
 	maxEnqueuedFlag              = "max-enqueued"
+	priceBumpFlag                = "price-bump"
+	journalFlag                  = "journal"
+	journalRotationFlag          = "journal-rotation"
+	blockBuildingPolicyFlag      = "block-building-policy"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
 	restoreFlag                  = "restore"
@@ -39,7 +52,28 @@ const (
 	logFileLocationFlag          = "log-to"
 
 	relayerFlag               = "relayer"
+	exitRelayerFlag           = "exit-relayer"
+	exitRelayerRootRPCFlag    = "exit-relayer-root-json-rpc"
+	exitRelayerRootKeyFlag    = "exit-relayer-root-key-file"
 	numBlockConfirmationsFlag = "num-block-confirmations"
+
+	jsonRPCRateLimitFlag             = "json-rpc-rate-limit"
+	jsonRPCRateLimitBurstFlag        = "json-rpc-rate-limit-burst"
+	jsonRPCMethodRateLimitFlag       = "json-rpc-method-rate-limit"
+	jsonRPCAllowedMethodsFlag        = "json-rpc-allowed-methods"
+	jsonRPCDeniedMethodsFlag         = "json-rpc-denied-methods"
+	jsonRPCMaxConcurrentRequestsFlag = "json-rpc-max-concurrent-requests"
+
+	grpcTLSCertFlag        = "grpc-tls-cert"
+	grpcTLSKeyFlag         = "grpc-tls-key"
+	grpcTLSClientCAFlag    = "grpc-tls-client-ca"
+	jsonRPCTLSCertFlag     = "json-rpc-tls-cert"
+	jsonRPCTLSKeyFlag      = "json-rpc-tls-key"
+	jsonRPCTLSClientCAFlag = "json-rpc-tls-client-ca"
+
+	statePruningFlag                   = "state-pruning"
+	statePruningRetainFlag             = "state-pruning-retain"
+	statePruningCheckpointIntervalFlag = "state-pruning-checkpoint-interval"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -55,15 +89,25 @@ const (
 var (
 	params = &serverParams{
 		rawConfig: &config.Config{
-			Telemetry: &config.Telemetry{},
-			Network:   &config.Network{},
-			TxPool:    &config.TxPool{},
+			Telemetry:     &config.Telemetry{},
+			Network:       &config.Network{},
+			TxPool:        &config.TxPool{},
+			StatePruning:  &config.StatePruning{},
+			JSONRPCLimits: &config.JSONRPCLimits{},
+			GRPCTLS:       &config.TLS{},
+			JSONRPCTLS:    &config.TLS{},
 		},
 	}
 )
 
 var (
-	errInvalidNATAddress = errors.New("could not parse NAT IP address")
+	errInvalidNATAddress       = errors.New("could not parse NAT IP address")
+	errInvalidStatePruningMode = errors.New("invalid state pruning mode, expected archive or pruned")
+	errInvalidStatePruning     = errors.New("state pruning retain must be greater than zero")
+	errInvalidPeerBanThreshold = errors.New("peer ban threshold must be negative")
+	errInvalidJSONRPCRateLimit = errors.New("json-rpc rate limit must not be negative")
+	errInvalidTLSCertificate   = errors.New("both the TLS certificate and its key must be set")
+	errExitRelayerKeyMissing   = errors.New("the exit relayer requires the rootchain key file")
 )
 
 type serverParams struct {
@@ -73,6 +117,8 @@ type serverParams struct {
 	libp2pAddress     *net.TCPAddr
 	prometheusAddress *net.TCPAddr
 	natAddress        net.IP
+	allowList         []peer.ID
+	denyList          []peer.ID
 	dnsAddress        multiaddr.Multiaddr
 	grpcAddress       *net.TCPAddr
 	jsonRPCAddress    *net.TCPAddr
@@ -83,6 +129,8 @@ type serverParams struct {
 
 	corsAllowedOrigins []string
 
+	jsonRPCMethodRateLimits map[string]jsonrpc.RateLimit
+
 	ibftBaseTimeoutLegacy uint64
 
 	genesisConfig *chain.Chain
@@ -142,6 +190,10 @@ func (p *serverParams) setRawJSONRPCAddress(jsonRPCAddress string) {
 	p.rawConfig.JSONRPCAddr = jsonRPCAddress
 }
 
+func (p *serverParams) setRawJSONRPCJWTSecret(jwtSecret string) {
+	p.rawConfig.JSONRPCJWTSecret = jwtSecret
+}
+
 func (p *serverParams) setJSONLogFormat(jsonLogFormat bool) {
 	p.rawConfig.JSONLogFormat = jsonLogFormat
 }
@@ -154,8 +206,21 @@ func (p *serverParams) generateConfig() *server.Config {
 			AccessControlAllowOrigin: p.corsAllowedOrigins,
 			BatchLengthLimit:         p.rawConfig.JSONRPCBatchRequestLimit,
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
+			IPCPath:                  p.rawConfig.JSONRPCIPCPath,
+			RateLimit: jsonrpc.RateLimit{
+				Rate:  p.rawConfig.JSONRPCLimits.RateLimit,
+				Burst: int(p.rawConfig.JSONRPCLimits.RateLimitBurst),
+			},
+			MethodRateLimits:      p.jsonRPCMethodRateLimits,
+			AllowedMethods:        p.rawConfig.JSONRPCLimits.AllowedMethods,
+			DeniedMethods:         p.rawConfig.JSONRPCLimits.DeniedMethods,
+			MaxConcurrentRequests: p.rawConfig.JSONRPCLimits.MaxConcurrentRequests,
+			SlowRequestThreshold:  time.Duration(p.rawConfig.JSONRPCSlowRequestThreshold) * time.Millisecond,
+			TLS:                   newServerTLS(p.rawConfig.JSONRPCTLS),
+			JWTSecretPath:         p.rawConfig.JSONRPCJWTSecret,
 		},
 		GRPCAddr:   p.grpcAddress,
+		GRPCTLS:    newServerTLS(p.rawConfig.GRPCTLS),
 		LibP2PAddr: p.libp2pAddress,
 		Telemetry: &server.Telemetry{
 			PrometheusAddr: p.prometheusAddress,
@@ -170,19 +235,45 @@ func (p *serverParams) generateConfig() *server.Config {
 			MaxInboundPeers:  p.rawConfig.Network.MaxInboundPeers,
 			MaxOutboundPeers: p.rawConfig.Network.MaxOutboundPeers,
 			Chain:            p.genesisConfig,
+			AllowList:        p.allowList,
+			DenyList:         p.denyList,
+			BanThreshold:     p.rawConfig.Network.BanThreshold,
+			BanDuration:      time.Duration(p.rawConfig.Network.BanDuration) * time.Second,
 		},
-		DataDir:            p.rawConfig.DataDir,
-		Seal:               p.rawConfig.ShouldSeal,
-		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
-		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
-		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
-		SecretsManager:     p.secretsConfig,
-		RestoreFile:        p.getRestoreFilePath(),
-		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
-		JSONLogFormat:      p.rawConfig.JSONLogFormat,
-		LogFilePath:        p.logFileLocation,
-
-		Relayer:               p.relayer,
-		NumBlockConfirmations: p.rawConfig.NumBlockConfirmations,
+		DataDir:             p.rawConfig.DataDir,
+		Seal:                p.rawConfig.ShouldSeal,
+		PriceLimit:          p.rawConfig.TxPool.PriceLimit,
+		MaxSlots:            p.rawConfig.TxPool.MaxSlots,
+		MaxAccountEnqueued:  p.rawConfig.TxPool.MaxAccountEnqueued,
+		PriceBump:           p.rawConfig.TxPool.PriceBump,
+		Journal:             p.rawConfig.TxPool.Journal,
+		JournalRotation:     time.Duration(p.rawConfig.TxPool.JournalRotation) * time.Second,
+		BlockBuildingPolicy: p.rawConfig.TxPool.BlockBuildingPolicy,
+		SecretsManager:      p.secretsConfig,
+		RestoreFile:         p.getRestoreFilePath(),
+		LogLevel:            hclog.LevelFromString(p.rawConfig.LogLevel),
+		JSONLogFormat:       p.rawConfig.JSONLogFormat,
+		LogFilePath:         p.logFileLocation,
+
+		Relayer:                p.relayer,
+		ExitRelayer:            p.rawConfig.ExitRelayer,
+		ExitRelayerRootJSONRPC: p.rawConfig.ExitRelayerRootJSONRPC,
+		ExitRelayerRootKeyFile: p.rawConfig.ExitRelayerRootKeyFile,
+		NumBlockConfirmations:  p.rawConfig.NumBlockConfirmations,
+
+		StatePruning: &server.StatePruning{
+			Enabled:            p.rawConfig.StatePruning.Mode == config.PrunedStatePruningMode,
+			Retain:             p.rawConfig.StatePruning.Retain,
+			CheckpointInterval: p.rawConfig.StatePruning.CheckpointInterval,
+		},
+	}
+}
+
+// newServerTLS converts the raw TLS config to the server one
+func newServerTLS(rawTLS *config.TLS) *server.TLS {
+	return &server.TLS{
+		CertFile:     rawTLS.CertFile,
+		KeyFile:      rawTLS.KeyFile,
+		ClientCAFile: rawTLS.ClientCAFile,
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/server/config"
 	"github.com/0xPolygon/polygon-edge/command/server/export"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/spf13/cobra"
 )
 
@@ -115,7 +116,8 @@ func setFlags(cmd *cobra.Command) {
 		&params.rawConfig.RestoreFile,
 		restoreFlag,
 		"",
-		"the path to the archive blockchain data to restore on initialization",
+		"the path to the archive blockchain data to restore on initialization, "+
+			"either a backup file or a directory of backup chunks",
 	)
 
 	cmd.Flags().BoolVar(
@@ -161,6 +163,35 @@ func setFlags(cmd *cobra.Command) {
 	cmd.Flag(maxOutboundPeersFlag).DefValue = fmt.Sprintf("%d", defaultConfig.Network.MaxOutboundPeers)
 	cmd.MarkFlagsMutuallyExclusive(maxPeersFlag, maxOutboundPeersFlag)
 
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.AllowList,
+		allowListFlag,
+		defaultConfig.Network.AllowList,
+		"the libp2p IDs of the only peers allowed to connect, if set",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.DenyList,
+		denyListFlag,
+		defaultConfig.Network.DenyList,
+		"the libp2p IDs of the peers never allowed to connect",
+	)
+
+	cmd.Flags().Int64Var(
+		&params.rawConfig.Network.BanThreshold,
+		peerBanThresholdFlag,
+		defaultConfig.Network.BanThreshold,
+		"the score at which a misbehaving peer gets banned",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.Network.BanDuration,
+		peerBanDurationFlag,
+		defaultConfig.Network.BanDuration,
+		"the duration of the peer bans in seconds, 0 bans peers permanently "+
+			"(the bans are kept across the restarts until they are lifted with the peers unban command)",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.TxPool.PriceLimit,
 		priceLimitFlag,
@@ -185,6 +216,38 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of enqueued transactions per account",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.PriceBump,
+		priceBumpFlag,
+		defaultConfig.TxPool.PriceBump,
+		"minimum fee increase (in percent) required to replace a transaction with the same nonce",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.TxPool.Journal,
+		journalFlag,
+		defaultConfig.TxPool.Journal,
+		"the journal of the local transactions surviving node restarts, "+
+			"relative to the data directory (empty disables the journal)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.JournalRotation,
+		journalRotationFlag,
+		defaultConfig.TxPool.JournalRotation,
+		"the interval (in seconds) of the local transactions journal regeneration",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.TxPool.BlockBuildingPolicy,
+		blockBuildingPolicyFlag,
+		defaultConfig.TxPool.BlockBuildingPolicy,
+		fmt.Sprintf(
+			"the policy ordering the transactions during block building %v, overrides the genesis policy",
+			txpool.BlockBuildingPolicies,
+		),
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.corsAllowedOrigins,
 		corsOriginFlag,
@@ -207,6 +270,105 @@ func setFlags(cmd *cobra.Command) {
 			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCIPCPath,
+		jsonRPCIPCPathFlag,
+		defaultConfig.JSONRPCIPCPath,
+		"the path of the json-rpc IPC socket, relative to the data directory (empty disables IPC)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.GRPCTLS.CertFile,
+		grpcTLSCertFlag,
+		defaultConfig.GRPCTLS.CertFile,
+		"the certificate of the GRPC server, enables TLS",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.GRPCTLS.KeyFile,
+		grpcTLSKeyFlag,
+		defaultConfig.GRPCTLS.KeyFile,
+		"the key of the GRPC server certificate",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.GRPCTLS.ClientCAFile,
+		grpcTLSClientCAFlag,
+		defaultConfig.GRPCTLS.ClientCAFile,
+		"the CA certificates of the GRPC clients, enables mutual TLS",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCTLS.CertFile,
+		jsonRPCTLSCertFlag,
+		defaultConfig.JSONRPCTLS.CertFile,
+		"the certificate of the json-rpc server, enables TLS",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCTLS.KeyFile,
+		jsonRPCTLSKeyFlag,
+		defaultConfig.JSONRPCTLS.KeyFile,
+		"the key of the json-rpc server certificate",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCTLS.ClientCAFile,
+		jsonRPCTLSClientCAFlag,
+		defaultConfig.JSONRPCTLS.ClientCAFile,
+		"the CA certificates of the json-rpc clients, enables mutual TLS",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCSlowRequestThreshold,
+		jsonRPCSlowRequestFlag,
+		defaultConfig.JSONRPCSlowRequestThreshold,
+		"the duration (in milliseconds) after which the json-rpc requests are logged as slow, value of 0 disables it",
+	)
+
+	cmd.Flags().Float64Var(
+		&params.rawConfig.JSONRPCLimits.RateLimit,
+		jsonRPCRateLimitFlag,
+		defaultConfig.JSONRPCLimits.RateLimit,
+		"max number of json-rpc requests per second from a single IP (HTTP and WS), value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCLimits.RateLimitBurst,
+		jsonRPCRateLimitBurstFlag,
+		defaultConfig.JSONRPCLimits.RateLimitBurst,
+		"max number of json-rpc requests allowed at once from a single IP, defaults to the rate limit",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.JSONRPCLimits.MethodRateLimits,
+		jsonRPCMethodRateLimitFlag,
+		defaultConfig.JSONRPCLimits.MethodRateLimits,
+		"the rate limit of a json-rpc method group in the <namespace|method>=<rate>[:<burst>] format "+
+			"(e.g. debug=1 or eth_getLogs=10:20)",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.JSONRPCLimits.AllowedMethods,
+		jsonRPCAllowedMethodsFlag,
+		defaultConfig.JSONRPCLimits.AllowedMethods,
+		"the only json-rpc namespaces or methods served (e.g. eth or net_version), if set",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.JSONRPCLimits.DeniedMethods,
+		jsonRPCDeniedMethodsFlag,
+		defaultConfig.JSONRPCLimits.DeniedMethods,
+		"the json-rpc namespaces or methods never served (e.g. debug or txpool)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCLimits.MaxConcurrentRequests,
+		jsonRPCMaxConcurrentRequestsFlag,
+		defaultConfig.JSONRPCLimits.MaxConcurrentRequests,
+		"max number of json-rpc requests processed at once, value of 0 disables it",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.LogFilePath,
 		logFileLocationFlag,
@@ -221,6 +383,28 @@ func setFlags(cmd *cobra.Command) {
 		"start the state sync relayer service (PolyBFT only)",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.ExitRelayer,
+		exitRelayerFlag,
+		defaultConfig.ExitRelayer,
+		"start the exit relayer service executing the checkpointed withdrawals on the rootchain (PolyBFT only)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.ExitRelayerRootJSONRPC,
+		exitRelayerRootRPCFlag,
+		defaultConfig.ExitRelayerRootJSONRPC,
+		"the rootchain JSON-RPC endpoint used by the exit relayer (defaults to the bridge endpoint of the chain config)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.ExitRelayerRootKeyFile,
+		exitRelayerRootKeyFlag,
+		defaultConfig.ExitRelayerRootKeyFile,
+		"the file with the hex encoded private key of the funded rootchain account paying for the exits "+
+			"(required by the exit relayer)",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.NumBlockConfirmations,
 		numBlockConfirmationsFlag,
@@ -228,6 +412,28 @@ func setFlags(cmd *cobra.Command) {
 		"minimal number of child blocks required for the parent block to be considered final",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.StatePruning.Mode,
+		statePruningFlag,
+		defaultConfig.StatePruning.Mode,
+		"the state storage mode, archive keeps the states of all the blocks and pruned "+
+			"garbage-collects the states of the blocks which are not retained",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StatePruning.Retain,
+		statePruningRetainFlag,
+		defaultConfig.StatePruning.Retain,
+		"the number of the latest block states kept in pruned mode",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StatePruning.CheckpointInterval,
+		statePruningCheckpointIntervalFlag,
+		defaultConfig.StatePruning.CheckpointInterval,
+		"the interval of blocks whose states are kept forever in pruned mode, value of 0 disables it",
+	)
+
 	setLegacyFlags(cmd)
 
 	setDevFlags(cmd)
@@ -272,6 +478,7 @@ func runPreRun(cmd *cobra.Command, _ []string) error {
 	// The config file will have precedence over --flag
 	params.setRawGRPCAddress(helper.GetGRPCAddress(cmd))
 	params.setRawJSONRPCAddress(helper.GetJSONRPCAddress(cmd))
+	params.setRawJSONRPCJWTSecret(helper.GetJSONRPCJWTSecret(cmd))
 	params.setJSONLogFormat(helper.GetJSONLogFormat(cmd))
 
 	// Check if the config file has been specified
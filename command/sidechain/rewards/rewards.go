@@ -27,6 +27,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterJSONRPCFlag(unstakeCmd)
+	helper.RegisterJSONRPCClientTLSFlags(unstakeCmd)
 	setFlags(unstakeCmd)
 
 	return unstakeCmd
@@ -68,7 +69,13 @@ func runCommand(cmd *cobra.Command, _ []string) error {
 	validatorAddr := validatorAccount.Ecdsa.Address()
 	rewardPoolAddr := ethgo.Address(contracts.RewardPoolContract)
 
+	creds, err := helper.GetJSONRPCCredentials(cmd)
+	if err != nil {
+		return err
+	}
+
 	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC),
+		txrelayer.WithCredentials(creds),
 		txrelayer.WithReceiptTimeout(150*time.Millisecond))
 	if err != nil {
 		return err
@@ -0,0 +1,71 @@
+package snapshot
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func getExportCommand() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use: "export",
+		Short: "Export the state of the given block, the chain headers and the recent blocks " +
+			"from the data directory into the snapshot file. The node must be stopped",
+		PreRunE: runExportPreRun,
+		Run:     runExportCommand,
+	}
+
+	setExportFlags(exportCmd)
+	helper.SetRequiredFlags(exportCmd, exportParams.getRequiredFlags())
+
+	return exportCmd
+}
+
+func setExportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&exportParams.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().StringVar(
+		&exportParams.out,
+		outFlag,
+		"",
+		"the path of the snapshot file",
+	)
+
+	cmd.Flags().StringVar(
+		&exportParams.blockRaw,
+		blockFlag,
+		"",
+		"the block whose state is exported (default is the head of the chain, "+
+			"the PolyBFT chains are exported at the head only)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&exportParams.recent,
+		recentFlag,
+		defaultRecentBlocks,
+		"the number of the latest blocks exported with their transactions and receipts",
+	)
+}
+
+func runExportPreRun(_ *cobra.Command, _ []string) error {
+	return exportParams.validateFlags()
+}
+
+func runExportCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := exportParams.exportSnapshot(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(exportParams.getResult())
+}
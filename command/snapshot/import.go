@@ -0,0 +1,51 @@
+package snapshot
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func getImportCommand() *cobra.Command {
+	importCmd := &cobra.Command{
+		Use: "import",
+		Short: "Import the snapshot file into the empty data directory, " +
+			"so that the node started with it continues from the block of the snapshot",
+		Run: runImportCommand,
+	}
+
+	setImportFlags(importCmd)
+	helper.SetRequiredFlags(importCmd, importParams.getRequiredFlags())
+
+	return importCmd
+}
+
+func setImportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&importParams.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the new node",
+	)
+
+	cmd.Flags().StringVar(
+		&importParams.snapshot,
+		snapshotFlag,
+		"",
+		"the path of the snapshot file",
+	)
+}
+
+func runImportCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := importParams.importSnapshot(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(importParams.getResult())
+}
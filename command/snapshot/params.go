@@ -0,0 +1,238 @@
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	dataDirFlag  = "data-dir"
+	outFlag      = "out"
+	blockFlag    = "block"
+	recentFlag   = "recent"
+	snapshotFlag = "snapshot"
+
+	// defaultRecentBlocks is the default number of the latest blocks exported with their bodies
+	defaultRecentBlocks = 128
+
+	// polybftStatePath is the slash separated path of the PolyBFT consensus state in the data directory
+	polybftStatePath = "consensus/consensusState.db"
+)
+
+var (
+	exportParams = &snapshotExportParams{}
+	importParams = &snapshotImportParams{}
+)
+
+var (
+	errDecodeBlock           = errors.New("unable to decode block number")
+	errHeadNotFound          = errors.New("unable to read the head of the chain")
+	errConsensusStateNotHead = errors.New("the snapshots of the PolyBFT chains are exported at the head " +
+		"of the chain only, since the PolyBFT consensus state is kept for the head")
+)
+
+type snapshotExportParams struct {
+	dataDir  string
+	out      string
+	blockRaw string
+	recent   uint64
+
+	block    *uint64
+	metadata *archive.SnapshotMetadata
+}
+
+func (p *snapshotExportParams) validateFlags() error {
+	if p.blockRaw == "" {
+		return nil
+	}
+
+	block, err := types.ParseUint64orHex(&p.blockRaw)
+	if err != nil {
+		return errDecodeBlock
+	}
+
+	p.block = &block
+
+	return nil
+}
+
+func (p *snapshotExportParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		outFlag,
+	}
+}
+
+func (p *snapshotExportParams) exportSnapshot() error {
+	logger := newLogger()
+
+	chain, err := leveldb.NewLevelDBStorage(filepath.Join(p.dataDir, "blockchain"), logger)
+	if err != nil {
+		return err
+	}
+
+	defer chain.Close()
+
+	trie, err := itrie.NewLevelDBStorage(filepath.Join(p.dataDir, "trie"), logger)
+	if err != nil {
+		return err
+	}
+
+	defer trie.Close()
+
+	number, err := p.getBlock(chain)
+	if err != nil {
+		return err
+	}
+
+	files, closeFiles, err := p.getConsensusFiles(chain, number)
+	if err != nil {
+		return err
+	}
+
+	defer closeFiles()
+
+	fs, err := os.OpenFile(p.out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644) //nolint:gosec
+	if err != nil {
+		return err
+	}
+
+	if p.metadata, err = archive.ExportSnapshot(logger, chain, trie, number, p.recent, files, fs); err != nil {
+		_ = fs.Close()
+		_ = os.Remove(p.out)
+
+		return err
+	}
+
+	return fs.Close()
+}
+
+// getBlock returns the block of the snapshot, which is the head of the chain by default
+func (p *snapshotExportParams) getBlock(chain storage.Storage) (uint64, error) {
+	if p.block != nil {
+		return *p.block, nil
+	}
+
+	head, ok := chain.ReadHeadNumber()
+	if !ok {
+		return 0, errHeadNotFound
+	}
+
+	return head, nil
+}
+
+// getConsensusFiles returns the consensus state files included in the snapshot of the given block,
+// along with the function closing them
+func (p *snapshotExportParams) getConsensusFiles(
+	chain storage.Storage,
+	number uint64,
+) ([]*archive.SnapshotFile, func(), error) {
+	statePath := filepath.Join(p.dataDir, filepath.FromSlash(polybftStatePath))
+	if !common.FileExists(statePath) {
+		return nil, func() {}, nil
+	}
+
+	if head, ok := chain.ReadHeadNumber(); !ok || head != number {
+		return nil, nil, errConsensusStateNotHead
+	}
+
+	state, err := os.Open(statePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []*archive.SnapshotFile{{Path: polybftStatePath, Data: state}}, func() { _ = state.Close() }, nil
+}
+
+func (p *snapshotExportParams) getResult() command.CommandResult {
+	return &SnapshotResult{
+		Message:   fmt.Sprintf("Exported state snapshot to %s", p.out),
+		Number:    p.metadata.Number,
+		Hash:      p.metadata.Hash,
+		StateRoot: p.metadata.StateRoot,
+	}
+}
+
+type snapshotImportParams struct {
+	dataDir  string
+	snapshot string
+
+	metadata *archive.SnapshotMetadata
+}
+
+func (p *snapshotImportParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		snapshotFlag,
+	}
+}
+
+func (p *snapshotImportParams) importSnapshot() error {
+	logger := newLogger()
+
+	if err := common.SetupDataDir(p.dataDir, []string{"blockchain", "trie"}, 0770); err != nil {
+		return err
+	}
+
+	fs, err := os.Open(p.snapshot)
+	if err != nil {
+		return err
+	}
+
+	defer fs.Close()
+
+	chain, err := leveldb.NewLevelDBStorage(filepath.Join(p.dataDir, "blockchain"), logger)
+	if err != nil {
+		return err
+	}
+
+	defer chain.Close()
+
+	trie, err := itrie.NewLevelDBStorage(filepath.Join(p.dataDir, "trie"), logger)
+	if err != nil {
+		return err
+	}
+
+	defer trie.Close()
+
+	if p.metadata, err = archive.ImportSnapshot(logger, chain, trie, p.dataDir, fs); err != nil {
+		return err
+	}
+
+	// the imported headers are verified with the validators computed from them when the node starts
+	statePath := filepath.Join(p.dataDir, filepath.FromSlash(polybftStatePath))
+	if common.FileExists(statePath) {
+		return polybft.ResetValidatorSnapshots(statePath)
+	}
+
+	return nil
+}
+
+func (p *snapshotImportParams) getResult() command.CommandResult {
+	return &SnapshotResult{
+		Message:   fmt.Sprintf("Imported state snapshot into %s", p.dataDir),
+		Number:    p.metadata.Number,
+		Hash:      p.metadata.Hash,
+		StateRoot: p.metadata.StateRoot,
+	}
+}
+
+func newLogger() hclog.Logger {
+	return hclog.New(&hclog.LoggerOptions{
+		Name:  "snapshot",
+		Level: hclog.LevelFromString("INFO"),
+	})
+}
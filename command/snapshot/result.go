@@ -0,0 +1,31 @@
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type SnapshotResult struct {
+	Message   string     `json:"message"`
+	Number    uint64     `json:"number"`
+	Hash      types.Hash `json:"hash"`
+	StateRoot types.Hash `json:"stateRoot"`
+}
+
+func (r *SnapshotResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SNAPSHOT]\n")
+	buffer.WriteString(r.Message)
+	buffer.WriteString("\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Block|%d", r.Number),
+		fmt.Sprintf("Hash|%s", r.Hash),
+		fmt.Sprintf("State Root|%s", r.StateRoot),
+	}))
+
+	return buffer.String()
+}
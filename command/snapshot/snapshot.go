@@ -0,0 +1,30 @@
+package snapshot
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use: "snapshot",
+		Short: "Top level command for exporting and importing the state snapshots, " +
+			"which bootstrap a new node at the given block without re-executing the chain. " +
+			"The imported headers are verified by the consensus when the node starts for the first time, " +
+			"so that the IBFT PoS chains are not supported, since their validators are read from the state " +
+			"of the past blocks, which the snapshots don't include. " +
+			"Only accepts subcommands.",
+	}
+
+	registerSubcommands(snapshotCmd)
+
+	return snapshotCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// snapshot export
+		getExportCommand(),
+		// snapshot import
+		getImportCommand(),
+	)
+}
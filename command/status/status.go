@@ -20,6 +20,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterGRPCAddressFlag(statusCmd)
+	helper.RegisterGRPCClientTLSFlags(statusCmd)
 
 	return statusCmd
 }
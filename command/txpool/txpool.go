@@ -14,6 +14,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterGRPCAddressFlag(txPoolCmd)
+	helper.RegisterGRPCClientTLSFlags(txPoolCmd)
 
 	registerSubcommands(txPoolCmd)
 
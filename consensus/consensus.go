@@ -90,4 +90,14 @@ type BridgeDataProvider interface {
 
 	// GetStateSyncProof retrieves the StateSync proof
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
+
+	// GetStateSyncsByReceiver returns at most limit state syncs sent to the given receiver,
+	// starting from the given state sync id
+	GetStateSyncsByReceiver(receiver types.Address, fromID uint64, limit int) ([]*types.BridgeEvent, error)
+
+	// GetExitsBySender returns at most limit exits sent by the given sender, starting from the given exit id
+	GetExitsBySender(sender types.Address, fromID uint64, limit int) ([]*types.BridgeEvent, error)
+
+	// GetBridgeEvent returns the state sync or the exit with the given id
+	GetBridgeEvent(eventType types.BridgeEventType, id uint64) (*types.BridgeEvent, error)
 }
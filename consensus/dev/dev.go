@@ -107,6 +107,7 @@ func (d *Dev) run() {
 
 type transitionInterface interface {
 	Write(txn *types.Transaction) error
+	TotalGas() uint64
 }
 
 func (d *Dev) writeTransactions(baseFee, gasLimit uint64, transition transitionInterface) []*types.Transaction {
@@ -126,6 +127,11 @@ func (d *Dev) writeTransactions(baseFee, gasLimit uint64, transition transitionI
 			continue
 		}
 
+		if !d.txpool.Fits(tx, transition.TotalGas(), gasLimit) {
+			// the block building policy keeps the transaction out of this block
+			continue
+		}
+
 		if err := transition.Write(tx); err != nil {
 			if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
 				break
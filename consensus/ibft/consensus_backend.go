@@ -287,6 +287,7 @@ type txExeResult struct {
 
 type transitionInterface interface {
 	Write(txn *types.Transaction) error
+	TotalGas() uint64
 }
 
 func (i *backendIBFT) writeTransactions(
@@ -373,6 +374,11 @@ func (i *backendIBFT) writeTransaction(
 		return &txExeResult{tx, fail}, true
 	}
 
+	if !i.txpool.Fits(tx, transition.TotalGas(), gasLimit) {
+		// the block building policy keeps the transaction out of this block
+		return &txExeResult{tx, skip}, true
+	}
+
 	if err := transition.Write(tx); err != nil {
 		if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
 			// stop processing
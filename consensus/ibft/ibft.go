@@ -48,6 +48,7 @@ type txPoolInterface interface {
 	Pop(tx *types.Transaction)
 	Drop(tx *types.Transaction)
 	Demote(tx *types.Transaction)
+	Fits(tx *types.Transaction, gasUsed, gasLimit uint64) bool
 	ResetWithHeaders(headers ...*types.Header)
 	SetSealing(bool)
 }
@@ -35,7 +35,7 @@ func (i *backendIBFT) setupTransport() error {
 
 	// Subscribe to the newly created topic
 	if err := topic.Subscribe(
-		func(obj interface{}, _ peer.ID) {
+		func(obj interface{}, from peer.ID) {
 			if !i.isActiveValidator() {
 				return
 			}
@@ -47,6 +47,13 @@ func (i *backendIBFT) setupTransport() error {
 				return
 			}
 
+			if msg.View == nil || len(msg.From) == 0 || len(msg.Signature) == 0 {
+				i.logger.Debug("malformed message received", "peer", from)
+				i.network.ReportPeer(from, network.PenaltyInvalidConsensusMessage, "malformed consensus message")
+
+				return
+			}
+
 			i.consensus.AddMessage(msg)
 
 			i.logger.Debug(
@@ -188,6 +188,12 @@ func (b *BlockBuilder) writeTxPoolTransaction(tx *types.Transaction) (bool, erro
 		return true, nil
 	}
 
+	if !b.params.TxPool.Fits(tx, b.state.TotalGas(), b.params.GasLimit) {
+		// the block building policy keeps the transaction (and the rest of
+		// the sender's transactions) out of this block, leave them in the pool
+		return false, nil
+	}
+
 	if err := b.WriteTx(tx); err != nil {
 		if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
 			// stop processing
@@ -14,6 +14,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/umbracle/ethgo"
 )
@@ -76,6 +77,7 @@ func TestBlockBuilder_BuildBlockTxOneFailedTxAndOneTakesTooMuchGas(t *testing.T)
 
 	txPool := &txPoolMock{}
 	txPool.On("Prepare", uint64(0)).Once()
+	txPool.On("Fits", mock.Anything, mock.Anything, mock.Anything).Return(true)
 
 	for i, acc := range accounts {
 		receiver := types.Address(acc.Ecdsa.Address())
@@ -32,6 +32,8 @@ type CheckpointManager interface {
 	PostBlock(req *PostBlockRequest) error
 	BuildEventRoot(epoch uint64) (types.Hash, error)
 	GenerateExitProof(exitID uint64) (types.Proof, error)
+	GetExitsBySender(sender types.Address, fromID uint64, limit int) ([]*types.BridgeEvent, error)
+	GetExit(exitID uint64) (*types.BridgeEvent, error)
 }
 
 var _ CheckpointManager = (*dummyCheckpointManager)(nil)
@@ -45,6 +47,13 @@ func (d *dummyCheckpointManager) BuildEventRoot(epoch uint64) (types.Hash, error
 func (d *dummyCheckpointManager) GenerateExitProof(exitID uint64) (types.Proof, error) {
 	return types.Proof{}, nil
 }
+func (d *dummyCheckpointManager) GetExitsBySender(
+	sender types.Address, fromID uint64, limit int) ([]*types.BridgeEvent, error) {
+	return nil, nil
+}
+func (d *dummyCheckpointManager) GetExit(exitID uint64) (*types.BridgeEvent, error) {
+	return nil, nil
+}
 
 var _ CheckpointManager = (*checkpointManager)(nil)
 
@@ -62,6 +71,8 @@ type checkpointManager struct {
 	checkpointsOffset uint64
 	// checkpointManagerAddr is address of CheckpointManager smart contract
 	checkpointManagerAddr types.Address
+	// exitHelperAddr is address of ExitHelper smart contract
+	exitHelperAddr types.Address
 	// lastSentBlock represents the last block on which a checkpoint transaction was sent
 	lastSentBlock uint64
 	// logger instance
@@ -72,7 +83,7 @@ type checkpointManager struct {
 
 // newCheckpointManager creates a new instance of checkpointManager
 func newCheckpointManager(key ethgo.Key, checkpointOffset uint64,
-	checkpointManagerSC types.Address, txRelayer txrelayer.TxRelayer,
+	checkpointManagerSC, exitHelperSC types.Address, txRelayer txrelayer.TxRelayer,
 	blockchain blockchainBackend, backend polybftBackend, logger hclog.Logger,
 	state *State) *checkpointManager {
 	return &checkpointManager{
@@ -82,6 +93,7 @@ func newCheckpointManager(key ethgo.Key, checkpointOffset uint64,
 		rootChainRelayer:      txRelayer,
 		checkpointsOffset:     checkpointOffset,
 		checkpointManagerAddr: checkpointManagerSC,
+		exitHelperAddr:        exitHelperSC,
 		logger:                logger,
 		state:                 state,
 	}
@@ -436,6 +448,100 @@ func (c *checkpointManager) GenerateExitProof(exitID uint64) (types.Proof, error
 	}, nil
 }
 
+// GetExitsBySender returns at most limit exits sent by the given sender, starting from the given exit id,
+// along with their status
+func (c *checkpointManager) GetExitsBySender(
+	sender types.Address, fromID uint64, limit int) ([]*types.BridgeEvent, error) {
+	exitEvents, err := c.state.CheckpointStore.getExitEventsBySender(ethgo.Address(sender), fromID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(exitEvents) == 0 {
+		return []*types.BridgeEvent{}, nil
+	}
+
+	checkpointBlock, err := c.getLatestCheckpointBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	exits := make([]*types.BridgeEvent, len(exitEvents))
+
+	for i, exitEvent := range exitEvents {
+		if exits[i], err = c.toBridgeEvent(exitEvent, checkpointBlock); err != nil {
+			return nil, err
+		}
+	}
+
+	return exits, nil
+}
+
+// GetExit returns the exit with the given id along with its status
+func (c *checkpointManager) GetExit(exitID uint64) (*types.BridgeEvent, error) {
+	exitEvent, err := c.state.CheckpointStore.getExitEvent(exitID)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpointBlock, err := c.getLatestCheckpointBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.toBridgeEvent(exitEvent, checkpointBlock)
+}
+
+// toBridgeEvent converts the exit event to the bridge event,
+// resolving its status against the latest checkpoint block and the exits processed on the rootchain
+func (c *checkpointManager) toBridgeEvent(exitEvent *ExitEvent, checkpointBlock uint64) (*types.BridgeEvent, error) {
+	exit := &types.BridgeEvent{
+		Type:     types.ExitBridgeEvent,
+		ID:       exitEvent.ID,
+		Sender:   types.Address(exitEvent.Sender),
+		Receiver: types.Address(exitEvent.Receiver),
+		Status:   types.BridgeEventPending,
+	}
+
+	if exitEvent.BlockNumber > checkpointBlock {
+		return exit, nil
+	}
+
+	processed, err := c.isExitProcessed(exitEvent.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if processed {
+		exit.Status = types.BridgeEventExited
+	} else {
+		exit.Status = types.BridgeEventCheckpointed
+	}
+
+	return exit, nil
+}
+
+// isExitProcessed checks whether the exit was executed on the rootchain.
+// The exits once executed stay executed, so the rootchain is queried only for the exits not yet known to be executed
+func (c *checkpointManager) isExitProcessed(exitID uint64) (bool, error) {
+	processed, err := c.state.CheckpointStore.isExitProcessed(exitID)
+	if err != nil || processed {
+		return processed, err
+	}
+
+	processed, err = contractsapi.IsExitProcessed(
+		c.rootChainRelayer, ethgo.ZeroAddress, ethgo.Address(c.exitHelperAddr), exitID)
+	if err != nil || !processed {
+		return processed, err
+	}
+
+	if err := c.state.CheckpointStore.insertProcessedExits(exitID); err != nil {
+		c.logger.Warn("Failed to store the processed exit", "exitID", exitID, "err", err)
+	}
+
+	return true, nil
+}
+
 // getExitEventsFromReceipts parses logs from receipts to find exit events
 func getExitEventsFromReceipts(epoch, block uint64, receipts []*types.Receipt) ([]*ExitEvent, error) {
 	events := make([]*ExitEvent, 0)
@@ -281,7 +281,7 @@ func TestCheckpointManager_IsCheckpointBlock(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			t.Parallel()
 
-			checkpointMgr := newCheckpointManager(wallet.NewEcdsaSigner(createTestKey(t)), c.checkpointsOffset, types.ZeroAddress, nil, nil, nil, hclog.NewNullLogger(), nil)
+			checkpointMgr := newCheckpointManager(wallet.NewEcdsaSigner(createTestKey(t)), c.checkpointsOffset, types.ZeroAddress, types.ZeroAddress, nil, nil, nil, hclog.NewNullLogger(), nil)
 			require.Equal(t, c.isCheckpointBlock, checkpointMgr.isCheckpointBlock(c.blockNumber, c.isEpochEndingBlock))
 		})
 	}
@@ -308,7 +308,7 @@ func TestCheckpointManager_PostBlock(t *testing.T) {
 		Epoch: epoch}
 
 	checkpointManager := newCheckpointManager(wallet.NewEcdsaSigner(createTestKey(t)), 5, types.ZeroAddress,
-		nil, nil, nil, hclog.NewNullLogger(), state)
+		types.ZeroAddress, nil, nil, nil, hclog.NewNullLogger(), state)
 
 	t.Run("PostBlock - not epoch ending block", func(t *testing.T) {
 		req.IsEpochEndingBlock = false
@@ -371,6 +371,89 @@ func TestCheckpointManager_BuildEventRoot(t *testing.T) {
 	})
 }
 
+func TestCheckpointManager_GetExitsBySender(t *testing.T) {
+	t.Parallel()
+
+	const (
+		numOfBlocks         = 3
+		numOfEventsPerBlock = 2
+	)
+
+	var (
+		key            = wallet.NewEcdsaSigner(createTestKey(t))
+		exitHelperAddr = types.StringToAddress("2")
+	)
+
+	state := newTestState(t)
+	// exits 0 and 1 are emitted in block 1, exits 2 and 3 in block 2, exits 4 and 5 in block 3
+	insertTestExitEvents(t, state, 1, numOfBlocks, numOfEventsPerBlock)
+
+	checkpointBlockInput, err := currentCheckpointBlockNumMethod.Encode([]interface{}{})
+	require.NoError(t, err)
+
+	processedExitInput := func(exitID int64) []byte {
+		input, err := contractsapi.ExitHelper.Abi.Methods["processedExits"].Encode([]interface{}{big.NewInt(exitID)})
+		require.NoError(t, err)
+
+		return input
+	}
+
+	txRelayerMock := newDummyTxRelayer(t)
+	txRelayerMock.On("Call", key.Address(), ethgo.ZeroAddress, checkpointBlockInput).
+		Return("0x2", error(nil))
+
+	// the processed exits are queried only once, since their status is stored afterwards
+	for _, exitID := range []int64{0, 1} {
+		txRelayerMock.On("Call", ethgo.ZeroAddress, ethgo.Address(exitHelperAddr), processedExitInput(exitID)).
+			Return("0x1", error(nil)).Once()
+	}
+
+	for _, exitID := range []int64{2, 3} {
+		txRelayerMock.On("Call", ethgo.ZeroAddress, ethgo.Address(exitHelperAddr), processedExitInput(exitID)).
+			Return("0x0", error(nil))
+	}
+
+	checkpointMgr := newCheckpointManager(key, 0, types.ZeroAddress, exitHelperAddr,
+		txRelayerMock, nil, nil, hclog.NewNullLogger(), state)
+
+	expectedStatuses := []types.BridgeEventStatus{
+		types.BridgeEventExited,
+		types.BridgeEventExited,
+		types.BridgeEventCheckpointed,
+		types.BridgeEventCheckpointed,
+		types.BridgeEventPending,
+		types.BridgeEventPending,
+	}
+
+	for i := 0; i < 2; i++ {
+		exits, err := checkpointMgr.GetExitsBySender(types.ZeroAddress, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, exits, numOfBlocks*numOfEventsPerBlock)
+
+		for i, exit := range exits {
+			require.Equal(t, types.ExitBridgeEvent, exit.Type)
+			require.Equal(t, uint64(i), exit.ID)
+			require.Equal(t, expectedStatuses[i], exit.Status)
+		}
+	}
+
+	exits, err := checkpointMgr.GetExitsBySender(types.ZeroAddress, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, exits, 2)
+	require.Equal(t, uint64(1), exits[0].ID)
+	require.Equal(t, uint64(2), exits[1].ID)
+
+	exit, err := checkpointMgr.GetExit(3)
+	require.NoError(t, err)
+	require.Equal(t, types.BridgeEventCheckpointed, exit.Status)
+
+	exits, err = checkpointMgr.GetExitsBySender(types.StringToAddress("1"), 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, exits)
+
+	txRelayerMock.AssertExpectations(t)
+}
+
 func TestCheckpointManager_GenerateExitProof(t *testing.T) {
 	t.Parallel()
 
@@ -406,6 +489,7 @@ func TestCheckpointManager_GenerateExitProof(t *testing.T) {
 		createTestKey(t)),
 		0,
 		types.ZeroAddress,
+		types.ZeroAddress,
 		dummyTxRelayer,
 		nil,
 		nil,
@@ -43,6 +43,7 @@ type txPoolInterface interface {
 	Pop(*types.Transaction)
 	Drop(*types.Transaction)
 	Demote(*types.Transaction)
+	Fits(tx *types.Transaction, gasUsed, gasLimit uint64) bool
 	SetSealing(bool)
 	ResetWithHeaders(...*types.Header)
 }
@@ -208,6 +209,7 @@ func (c *consensusRuntime) initCheckpointManager(logger hcf.Logger) error {
 			wallet.NewEcdsaSigner(c.config.Key),
 			defaultCheckpointsOffset,
 			c.config.PolyBFTConfig.Bridge.CheckpointManagerAddr,
+			c.config.PolyBFTConfig.Bridge.ExitHelperAddr,
 			txRelayer,
 			c.config.blockchain,
 			c.config.polybftBackend,
@@ -601,6 +603,31 @@ func (c *consensusRuntime) GetStateSyncProof(stateSyncID uint64) (types.Proof, e
 	return c.stateSyncManager.GetStateSyncProof(stateSyncID)
 }
 
+// GetStateSyncsByReceiver returns at most limit state syncs sent to the given receiver,
+// starting from the given state sync id
+func (c *consensusRuntime) GetStateSyncsByReceiver(
+	receiver types.Address, fromID uint64, limit int) ([]*types.BridgeEvent, error) {
+	return c.stateSyncManager.GetStateSyncsByReceiver(receiver, fromID, limit)
+}
+
+// GetExitsBySender returns at most limit exits sent by the given sender, starting from the given exit id
+func (c *consensusRuntime) GetExitsBySender(
+	sender types.Address, fromID uint64, limit int) ([]*types.BridgeEvent, error) {
+	return c.checkpointManager.GetExitsBySender(sender, fromID, limit)
+}
+
+// GetBridgeEvent returns the state sync or the exit with the given id
+func (c *consensusRuntime) GetBridgeEvent(eventType types.BridgeEventType, id uint64) (*types.BridgeEvent, error) {
+	switch eventType {
+	case types.StateSyncBridgeEvent:
+		return c.stateSyncManager.GetStateSync(id)
+	case types.ExitBridgeEvent:
+		return c.checkpointManager.GetExit(id)
+	default:
+		return nil, fmt.Errorf("unknown bridge event type: %s", eventType)
+	}
+}
+
 // setIsActiveValidator updates the activeValidatorFlag field
 func (c *consensusRuntime) setIsActiveValidator(isActiveValidator bool) {
 	c.activeValidatorFlag.Store(isActiveValidator)
@@ -86,6 +86,7 @@ func main() {
 			[]string{
 				"initialize",
 				"exit",
+				"batchExit",
 			},
 			[]string{},
 		},
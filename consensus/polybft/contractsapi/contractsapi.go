@@ -322,6 +322,39 @@ func (e *ExitExitHelperFn) DecodeAbi(buf []byte) error {
 	return decodeMethod(ExitHelper.Abi.Methods["exit"], buf, e)
 }
 
+type BatchExitInput struct {
+	BlockNumber  *big.Int     `abi:"blockNumber"`
+	LeafIndex    *big.Int     `abi:"leafIndex"`
+	UnhashedLeaf []byte       `abi:"unhashedLeaf"`
+	Proof        []types.Hash `abi:"proof"`
+}
+
+var BatchExitInputABIType = abi.MustNewType("tuple(uint256 blockNumber,uint256 leafIndex,bytes unhashedLeaf,bytes32[] proof)")
+
+func (b *BatchExitInput) EncodeAbi() ([]byte, error) {
+	return BatchExitInputABIType.Encode(b)
+}
+
+func (b *BatchExitInput) DecodeAbi(buf []byte) error {
+	return decodeStruct(BatchExitInputABIType, buf, &b)
+}
+
+type BatchExitExitHelperFn struct {
+	Inputs []*BatchExitInput `abi:"inputs"`
+}
+
+func (b *BatchExitExitHelperFn) Sig() []byte {
+	return ExitHelper.Abi.Methods["batchExit"].ID()
+}
+
+func (b *BatchExitExitHelperFn) EncodeAbi() ([]byte, error) {
+	return ExitHelper.Abi.Methods["batchExit"].Encode(b)
+}
+
+func (b *BatchExitExitHelperFn) DecodeAbi(buf []byte) error {
+	return decodeMethod(ExitHelper.Abi.Methods["batchExit"], buf, b)
+}
+
 type InitializeChildERC20PredicateFn struct {
 	NewL2StateSender          types.Address `abi:"newL2StateSender"`
 	NewStateReceiver          types.Address `abi:"newStateReceiver"`
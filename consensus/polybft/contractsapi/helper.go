@@ -1,7 +1,13 @@
 package contractsapi
 
 import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/0xPolygon/polygon-edge/txrelayer"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
 	"github.com/umbracle/ethgo/abi"
 )
 
@@ -42,3 +48,23 @@ func (t *TransferEvent) IsStake() bool {
 func (t *TransferEvent) IsUnstake() bool {
 	return t.To == types.ZeroAddress && t.From != types.ZeroAddress
 }
+
+// IsExitProcessed queries the ExitHelper contract on the rootchain whether the exit with the given id was executed
+func IsExitProcessed(relayer txrelayer.TxRelayer, from, exitHelper ethgo.Address, exitID uint64) (bool, error) {
+	input, err := ExitHelper.Abi.Methods["processedExits"].Encode([]interface{}{new(big.Int).SetUint64(exitID)})
+	if err != nil {
+		return false, fmt.Errorf("failed to encode processedExits function parameters: %w", err)
+	}
+
+	response, err := relayer.Call(from, exitHelper, input)
+	if err != nil {
+		return false, fmt.Errorf("failed to invoke processedExits function on the rootchain: %w", err)
+	}
+
+	processed, err := strconv.ParseUint(response, 0, 64)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert processed exit flag '%s': %w", response, err)
+	}
+
+	return processed != 0, nil
+}
@@ -0,0 +1,459 @@
+package exitrelayer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/tracker"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
+	"github.com/0xPolygon/polygon-edge/types"
+
+	hcf "github.com/hashicorp/go-hclog"
+	"github.com/umbracle/ethgo"
+)
+
+const (
+	// exitRelayInterval is the interval at which the relayer checks for the newly checkpointed exits
+	exitRelayInterval = 30 * time.Second
+
+	// maxExitBatchSize is the maximum number of exits executed by a single transaction
+	maxExitBatchSize = 20
+
+	// minRetryBackoff is the delay before retrying an exit that failed to execute for the first time
+	minRetryBackoff = 10 * time.Second
+
+	// maxRetryBackoff is the maximum delay between the execution attempts of an exit
+	maxRetryBackoff = 30 * time.Minute
+
+	// maxExecutionAttempts is the number of failed execution attempts after which an exit is marked as failed
+	maxExecutionAttempts = 20
+
+	// generateExitProofFn is JSON RPC endpoint which creates exit proof
+	generateExitProofFn = "bridge_generateExitProof"
+)
+
+var (
+	// currentCheckpointBlockNumMethod is an ABI method object representation for
+	// currentCheckpointBlockNumber getter function on CheckpointManager contract
+	currentCheckpointBlockNumMethod = contractsapi.CheckpointManager.Abi.Methods["currentCheckpointBlockNumber"]
+)
+
+// ExitRelayer executes the exits on the rootchain once they are included in a checkpoint,
+// sparing the users from sending the exit transactions themselves
+type ExitRelayer struct {
+	dataDir                string
+	rpcEndpoint            string
+	credentials            *txrelayer.Credentials
+	exitHelperAddr         ethgo.Address
+	checkpointManagerAddr  ethgo.Address
+	eventTrackerStartBlock uint64
+	logger                 hcf.Logger
+	client                 *txrelayer.Client
+	rootTxRelayer          txrelayer.TxRelayer
+	key                    ethgo.Key
+	store                  *exitStore
+	notifyCh               chan struct{}
+	closeCh                chan struct{}
+	wg                     sync.WaitGroup
+}
+
+func NewExitRelayer(
+	dataDir string,
+	rpcEndpoint string,
+	credentials *txrelayer.Credentials,
+	rootRPCEndpoint string,
+	exitHelperAddr ethgo.Address,
+	checkpointManagerAddr ethgo.Address,
+	l2StateSenderTrackerStartBlock uint64,
+	logger hcf.Logger,
+	key ethgo.Key,
+) (*ExitRelayer, error) {
+	endpoint := txrelayer.SanitizeRPCEndpoint(rpcEndpoint)
+
+	// create the child chain JSON RPC client
+	client, err := txrelayer.NewClient(endpoint, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the JSON RPC client: %w", err)
+	}
+
+	rootTxRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(rootRPCEndpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the rootchain tx relayer: %w", err)
+	}
+
+	store, err := newExitStore(path.Join(dataDir, "exit_relayer.db"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the exit store: %w", err)
+	}
+
+	return &ExitRelayer{
+		dataDir:                dataDir,
+		rpcEndpoint:            endpoint,
+		credentials:            credentials,
+		exitHelperAddr:         exitHelperAddr,
+		checkpointManagerAddr:  checkpointManagerAddr,
+		eventTrackerStartBlock: l2StateSenderTrackerStartBlock,
+		logger:                 logger,
+		client:                 client,
+		rootTxRelayer:          rootTxRelayer,
+		key:                    key,
+		store:                  store,
+		notifyCh:               make(chan struct{}, 1),
+		closeCh:                make(chan struct{}),
+	}, nil
+}
+
+func (r *ExitRelayer) Start() error {
+	et := tracker.NewEventTracker(
+		path.Join(r.dataDir, "/exit_relayer_tracker.db"),
+		r.rpcEndpoint,
+		r.credentials,
+		ethgo.Address(contracts.L2StateSenderContract),
+		r,
+		0, // child chain has instant finality, so no need to wait
+		r.eventTrackerStartBlock,
+		r.logger,
+	)
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	go func() {
+		<-r.closeCh
+		cancelFn()
+	}()
+
+	// execute the exits left pending before the restart along with the new ones
+	r.wg.Add(1)
+
+	go r.run()
+
+	return et.Start(ctx)
+}
+
+// Stop function is used to tear down all the allocated resources
+func (r *ExitRelayer) Stop() {
+	close(r.closeCh)
+
+	r.wg.Wait()
+
+	if err := r.store.close(); err != nil {
+		r.logger.Error("Failed to close the exit store", "err", err)
+	}
+
+	if err := r.client.Close(); err != nil {
+		r.logger.Error("Failed to close the JSON RPC client", "err", err)
+	}
+}
+
+// Exit returns the execution status of the exit tracked by the relayer
+func (r *ExitRelayer) Exit(id uint64) (*ExitRelay, error) {
+	return r.store.get(id)
+}
+
+// AddLog tracks the new exit for execution.
+// Returns an error if it can not be stored, so that the log is delivered again
+func (r *ExitRelayer) AddLog(log *ethgo.Log) error {
+	r.logger.Debug("Received a log", "log", log)
+
+	var exitEvent contractsapi.L2StateSyncedEvent
+
+	doesMatch, err := exitEvent.ParseLog(log)
+	if !doesMatch {
+		return nil
+	}
+
+	if err != nil {
+		r.logger.Error("Failed to parse log", "err", err)
+
+		return nil
+	}
+
+	if err := r.store.add(exitEvent.ID.Uint64(), log.BlockNumber); err != nil {
+		return fmt.Errorf("failed to store exit %d: %w", exitEvent.ID, err)
+	}
+
+	r.logger.Debug("Exit tracked", "Block", log.BlockNumber, "ID", exitEvent.ID)
+
+	return nil
+}
+
+// run periodically executes the pending exits included in a checkpoint
+func (r *ExitRelayer) run() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(exitRelayInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.closeCh:
+			return
+		case <-ticker.C:
+		}
+
+		if err := r.executePending(); err != nil {
+			r.logger.Error("Failed to execute exits", "err", err)
+		}
+	}
+}
+
+// executePending executes the pending exits which are included in a checkpoint and are due for execution,
+// in batches of at most maxExitBatchSize exits
+func (r *ExitRelayer) executePending() error {
+	relays, err := r.store.pending()
+	if err != nil {
+		return fmt.Errorf("failed to read pending exits: %w", err)
+	}
+
+	if len(relays) == 0 {
+		return nil
+	}
+
+	checkpointBlock, err := r.getLatestCheckpointBlock()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	batch := make([]*ExitRelay, 0, maxExitBatchSize)
+
+	for _, relay := range relays {
+		select {
+		case <-r.closeCh:
+			return nil
+		default:
+		}
+
+		// the exits are checkpointed in order of the blocks they were emitted in
+		if relay.BlockNumber > checkpointBlock {
+			break
+		}
+
+		if now.Before(relay.NextAttempt) {
+			continue
+		}
+
+		batch = append(batch, relay)
+		if len(batch) == maxExitBatchSize {
+			r.executeBatch(batch)
+
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		r.executeBatch(batch)
+	}
+
+	return nil
+}
+
+// executeBatch executes the exits in a single transaction and records the outcome.
+// If the batch transaction fails, the exits are executed one by one
+// so that a single invalid exit doesn't hold the others back
+func (r *ExitRelayer) executeBatch(relays []*ExitRelay) {
+	inputs := make([]*contractsapi.BatchExitInput, 0, len(relays))
+	toExecute := make([]*ExitRelay, 0, len(relays))
+
+	for _, relay := range relays {
+		input, err := r.prepareExit(relay)
+		if err != nil {
+			r.recordFailure(relay, err)
+
+			continue
+		}
+
+		if input == nil {
+			// the exit was executed by someone else already
+			r.recordSuccess(relay)
+
+			continue
+		}
+
+		inputs = append(inputs, input)
+		toExecute = append(toExecute, relay)
+	}
+
+	if len(inputs) == 0 {
+		return
+	}
+
+	err := r.sendBatchExit(inputs)
+	if err == nil {
+		r.recordSuccess(toExecute...)
+
+		return
+	}
+
+	if len(inputs) == 1 {
+		r.recordFailure(toExecute[0], err)
+
+		return
+	}
+
+	r.logger.Warn("Failed to execute exits batch, executing the exits one by one", "size", len(inputs), "err", err)
+
+	for i, input := range inputs {
+		if err := r.sendBatchExit([]*contractsapi.BatchExitInput{input}); err != nil {
+			r.recordFailure(toExecute[i], err)
+		} else {
+			r.recordSuccess(toExecute[i])
+		}
+	}
+}
+
+// prepareExit fetches the proof of the exit from the child chain, and returns the input of the exit transaction.
+// Returns nil input if the exit was already executed on the rootchain
+func (r *ExitRelayer) prepareExit(relay *ExitRelay) (*contractsapi.BatchExitInput, error) {
+	processed, err := contractsapi.IsExitProcessed(r.rootTxRelayer, r.key.Address(), r.exitHelperAddr, relay.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if processed {
+		return nil, nil
+	}
+
+	var proof types.Proof
+
+	if err := r.client.Call(generateExitProofFn, &proof, fmt.Sprintf("0x%x", relay.ID)); err != nil {
+		return nil, fmt.Errorf("failed to get exit proof: %w", err)
+	}
+
+	return createExitInput(proof)
+}
+
+// sendBatchExit sends the batchExit transaction to the ExitHelper contract on the rootchain
+func (r *ExitRelayer) sendBatchExit(inputs []*contractsapi.BatchExitInput) error {
+	batchExitFn := &contractsapi.BatchExitExitHelperFn{Inputs: inputs}
+
+	input, err := batchExitFn.EncodeAbi()
+	if err != nil {
+		return fmt.Errorf("failed to encode batch exit input: %w", err)
+	}
+
+	txn := &ethgo.Transaction{
+		From:  r.key.Address(),
+		To:    &r.exitHelperAddr,
+		Input: input,
+	}
+
+	receipt, err := r.rootTxRelayer.SendTransaction(txn, r.key)
+	if err != nil {
+		return fmt.Errorf("failed to send batch exit transaction: %w", err)
+	}
+
+	if receipt.Status == uint64(types.ReceiptFailed) {
+		return errors.New("batch exit transaction failed")
+	}
+
+	return nil
+}
+
+// getLatestCheckpointBlock queries CheckpointManager smart contract and retrieves latest checkpoint block number
+func (r *ExitRelayer) getLatestCheckpointBlock() (uint64, error) {
+	input, err := currentCheckpointBlockNumMethod.Encode([]interface{}{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode currentCheckpointBlockNumber function parameters: %w", err)
+	}
+
+	response, err := r.rootTxRelayer.Call(r.key.Address(), r.checkpointManagerAddr, input)
+	if err != nil {
+		return 0, fmt.Errorf("failed to invoke currentCheckpointBlockNumber function on the rootchain: %w", err)
+	}
+
+	checkpointBlock, err := strconv.ParseUint(response, 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert current checkpoint block '%s' to number: %w", response, err)
+	}
+
+	return checkpointBlock, nil
+}
+
+// recordSuccess marks the exits executed
+func (r *ExitRelayer) recordSuccess(relays ...*ExitRelay) {
+	for _, relay := range relays {
+		relay.Status = ExitExecuted
+		relay.LastError = ""
+		relay.NextAttempt = time.Time{}
+
+		r.logger.Info("Exit executed", "ID", relay.ID)
+	}
+
+	if err := r.store.update(relays...); err != nil {
+		r.logger.Error("Failed to update exits", "err", err)
+	}
+}
+
+// recordFailure records the failed execution attempt of the exit, and schedules the next one.
+// The exit is marked as failed once it fails maxExecutionAttempts times
+func (r *ExitRelayer) recordFailure(relay *ExitRelay, err error) {
+	relay.Attempts++
+	relay.LastError = err.Error()
+
+	if relay.Attempts >= maxExecutionAttempts {
+		// give up on the exit which keeps failing
+		relay.Status = ExitFailed
+		relay.NextAttempt = time.Time{}
+	} else {
+		relay.NextAttempt = time.Now().Add(common.RetryBackoff(relay.Attempts, minRetryBackoff, maxRetryBackoff))
+	}
+
+	r.logger.Error("Failed to execute exit", "ID", relay.ID, "attempts", relay.Attempts,
+		"status", relay.Status, "err", err)
+
+	if err := r.store.update(relay); err != nil {
+		r.logger.Error("Failed to update exit", "ID", relay.ID, "err", err)
+	}
+}
+
+// createExitInput encodes the exit proof into the input of the batchExit function on ExitHelper contract
+func createExitInput(proof types.Proof) (*contractsapi.BatchExitInput, error) {
+	exitEventMap, ok := proof.Metadata["ExitEvent"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("could not get exit event from proof")
+	}
+
+	raw, err := json.Marshal(exitEventMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal exit event map to JSON. Error: %w", err)
+	}
+
+	// the exit event fields not emitted by the contract are ignored
+	var exitEvent *contractsapi.L2StateSyncedEvent
+	if err = json.Unmarshal(raw, &exitEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal exit event from JSON. Error: %w", err)
+	}
+
+	exitEventEncoded, err := exitEvent.Encode(exitEvent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode exit event: %w", err)
+	}
+
+	leafIndex, ok := proof.Metadata["LeafIndex"].(float64)
+	if !ok {
+		return nil, errors.New("failed to convert proof leaf index")
+	}
+
+	checkpointBlock, ok := proof.Metadata["CheckpointBlock"].(float64)
+	if !ok {
+		return nil, errors.New("failed to convert proof checkpoint block")
+	}
+
+	return &contractsapi.BatchExitInput{
+		BlockNumber:  new(big.Int).SetUint64(uint64(checkpointBlock)),
+		LeafIndex:    new(big.Int).SetUint64(uint64(leafIndex)),
+		UnhashedLeaf: exitEventEncoded,
+		Proof:        proof.Data,
+	}, nil
+}
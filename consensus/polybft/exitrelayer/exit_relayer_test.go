@@ -0,0 +1,225 @@
+package exitrelayer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+	"github.com/umbracle/ethgo/wallet"
+)
+
+var _ txrelayer.TxRelayer = (*txRelayerMock)(nil)
+
+type txRelayerMock struct {
+	mock.Mock
+}
+
+func (t *txRelayerMock) Call(from ethgo.Address, to ethgo.Address, input []byte) (string, error) {
+	args := t.Called(from, to, input)
+
+	return args.String(0), args.Error(1)
+}
+
+func (t *txRelayerMock) SendTransaction(txn *ethgo.Transaction, key ethgo.Key) (*ethgo.Receipt, error) {
+	args := t.Called(txn, key)
+
+	return args.Get(0).(*ethgo.Receipt), args.Error(1) //nolint:forcetypeassert
+}
+
+func (t *txRelayerMock) SendTransactionLocal(txn *ethgo.Transaction) (*ethgo.Receipt, error) {
+	args := t.Called(txn)
+
+	return nil, args.Error(1)
+}
+
+func (t *txRelayerMock) Client() *jsonrpc.Client {
+	return nil
+}
+
+// batchSize matches the batchExit transactions with the given number of exits
+func batchSize(size int) interface{} {
+	return mock.MatchedBy(func(txn *ethgo.Transaction) bool {
+		var batchExitFn contractsapi.BatchExitExitHelperFn
+		if err := batchExitFn.DecodeAbi(txn.Input); err != nil {
+			return false
+		}
+
+		return len(batchExitFn.Inputs) == size
+	})
+}
+
+func TestExitRelayer_ExecutePending(t *testing.T) {
+	t.Parallel()
+
+	var (
+		checkpointManagerAddr = ethgo.Address(types.StringToAddress("0x1"))
+		exitHelperAddr        = ethgo.Address(types.StringToAddress("0x2"))
+	)
+
+	// JSON-RPC server serving the exit proofs
+	proofServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":{"Data":[],"Metadata":{`+
+			`"LeafIndex":0,"CheckpointBlock":10,"ExitEvent":{"ID":1,`+
+			`"Sender":"0x0000000000000000000000000000000000000000",`+
+			`"Receiver":"0x0000000000000000000000000000000000000000","Data":""}}}}`)
+	}))
+	t.Cleanup(proofServer.Close)
+
+	client, err := txrelayer.NewClient(proofServer.URL, nil)
+	require.NoError(t, err)
+
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	rootTxRelayer := &txRelayerMock{}
+
+	// the exits up to block 10 are checkpointed, none of them is processed yet
+	rootTxRelayer.On("Call", mock.Anything, checkpointManagerAddr, mock.Anything).
+		Return("0x000000000000000000000000000000000000000000000000000000000000000a", nil)
+	rootTxRelayer.On("Call", mock.Anything, exitHelperAddr, mock.Anything).
+		Return("0x0000000000000000000000000000000000000000000000000000000000000000", nil)
+
+	// the batch fails, so the exits are executed one by one and the second one fails
+	rootTxRelayer.On("SendTransaction", batchSize(2), mock.Anything).
+		Return(&ethgo.Receipt{Status: uint64(types.ReceiptFailed)}, nil).Once()
+	rootTxRelayer.On("SendTransaction", batchSize(1), mock.Anything).
+		Return(&ethgo.Receipt{Status: uint64(types.ReceiptSuccess)}, nil).Once()
+	rootTxRelayer.On("SendTransaction", batchSize(1), mock.Anything).
+		Return((*ethgo.Receipt)(nil), errors.New("insufficient funds")).Once()
+
+	r := &ExitRelayer{
+		checkpointManagerAddr: checkpointManagerAddr,
+		exitHelperAddr:        exitHelperAddr,
+		logger:                hclog.NewNullLogger(),
+		client:                client,
+		rootTxRelayer:         rootTxRelayer,
+		key:                   key,
+		store:                 newTestExitStore(t),
+		closeCh:               make(chan struct{}),
+	}
+
+	require.NoError(t, r.store.add(1, 5))
+	require.NoError(t, r.store.add(2, 6))
+	require.NoError(t, r.store.add(3, 50))
+
+	require.NoError(t, r.executePending())
+
+	exit, err := r.Exit(1)
+	require.NoError(t, err)
+	require.Equal(t, ExitExecuted, exit.Status)
+
+	exit, err = r.Exit(2)
+	require.NoError(t, err)
+	require.Equal(t, ExitPending, exit.Status)
+	require.Equal(t, uint64(1), exit.Attempts)
+	require.Contains(t, exit.LastError, "insufficient funds")
+	require.True(t, exit.NextAttempt.After(time.Now()))
+
+	// the exit is not checkpointed yet
+	exit, err = r.Exit(3)
+	require.NoError(t, err)
+	require.Equal(t, ExitPending, exit.Status)
+	require.Zero(t, exit.Attempts)
+
+	// the failed exit is not retried before the backoff elapses
+	require.NoError(t, r.executePending())
+
+	rootTxRelayer.AssertExpectations(t)
+	rootTxRelayer.AssertNumberOfCalls(t, "SendTransaction", 3)
+}
+
+func TestExitRelayer_ExecutePending_MaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	var (
+		checkpointManagerAddr = ethgo.Address(types.StringToAddress("0x1"))
+		exitHelperAddr        = ethgo.Address(types.StringToAddress("0x2"))
+	)
+
+	rootTxRelayer := &txRelayerMock{}
+
+	// the exit is checkpointed, but its processed status can not be read
+	rootTxRelayer.On("Call", mock.Anything, checkpointManagerAddr, mock.Anything).
+		Return("0x000000000000000000000000000000000000000000000000000000000000000a", nil)
+	rootTxRelayer.On("Call", mock.Anything, exitHelperAddr, mock.Anything).
+		Return("", errors.New("execution reverted"))
+
+	r := &ExitRelayer{
+		checkpointManagerAddr: checkpointManagerAddr,
+		exitHelperAddr:        exitHelperAddr,
+		logger:                hclog.NewNullLogger(),
+		rootTxRelayer:         rootTxRelayer,
+		key:                   key,
+		store:                 newTestExitStore(t),
+		closeCh:               make(chan struct{}),
+	}
+
+	require.NoError(t, r.store.add(1, 1))
+	require.NoError(t, r.store.update(&ExitRelay{
+		ID:          1,
+		BlockNumber: 1,
+		Status:      ExitPending,
+		Attempts:    maxExecutionAttempts - 1,
+	}))
+
+	// the last attempt fails as well
+	require.NoError(t, r.executePending())
+
+	exit, err := r.Exit(1)
+	require.NoError(t, err)
+	require.Equal(t, ExitFailed, exit.Status)
+	require.Equal(t, uint64(maxExecutionAttempts), exit.Attempts)
+	require.Contains(t, exit.LastError, "execution reverted")
+	require.True(t, exit.NextAttempt.IsZero())
+
+	// the failed exit is not retried anymore
+	pending, err := r.store.pending()
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	rootTxRelayer.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+}
+
+func TestExitRelayer_ExecutePending_AlreadyProcessed(t *testing.T) {
+	t.Parallel()
+
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	rootTxRelayer := &txRelayerMock{}
+
+	// the exit was executed by the user
+	rootTxRelayer.On("Call", mock.Anything, mock.Anything, mock.Anything).
+		Return("0x0000000000000000000000000000000000000000000000000000000000000001", nil)
+
+	r := &ExitRelayer{
+		logger:        hclog.NewNullLogger(),
+		rootTxRelayer: rootTxRelayer,
+		key:           key,
+		store:         newTestExitStore(t),
+		closeCh:       make(chan struct{}),
+	}
+
+	require.NoError(t, r.store.add(1, 1))
+	require.NoError(t, r.executePending())
+
+	exit, err := r.Exit(1)
+	require.NoError(t, err)
+	require.Equal(t, ExitExecuted, exit.Status)
+
+	rootTxRelayer.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,169 @@
+package exitrelayer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// bucket to store the exits tracked by the relayer
+	exitsBucket = []byte("exits")
+
+	// ErrExitNotFound is returned when the exit is not tracked by the relayer
+	ErrExitNotFound = errors.New("exit not found")
+)
+
+/*
+Bolt DB schema:
+
+exits/
+|--> exit.ID -> *ExitRelay (json marshalled)
+*/
+
+// ExitStatus is the execution status of an exit
+type ExitStatus string
+
+const (
+	// ExitPending is the status of an exit awaiting execution on the rootchain
+	ExitPending ExitStatus = "pending"
+	// ExitExecuted is the status of an exit executed on the rootchain
+	ExitExecuted ExitStatus = "executed"
+	// ExitFailed is the status of an exit which failed to execute in all the attempts,
+	// it is not retried anymore
+	ExitFailed ExitStatus = "failed"
+)
+
+// ExitRelay keeps track of the execution of an exit on the rootchain
+type ExitRelay struct {
+	// ID is the id of the exit event
+	ID uint64
+	// BlockNumber is the child chain block in which the exit event was emitted
+	BlockNumber uint64
+	// Status is the execution status of the exit
+	Status ExitStatus
+	// Attempts is the number of failed execution attempts
+	Attempts uint64
+	// LastError is the error of the last failed execution attempt
+	LastError string
+	// NextAttempt is the time of the next execution attempt of a pending exit
+	NextAttempt time.Time
+}
+
+// exitStore is the persistent queue of the exits executed by the relayer
+type exitStore struct {
+	db *bolt.DB
+}
+
+// newExitStore opens the store at the given path, creating it if it doesn't exist
+func newExitStore(path string) (*exitStore, error) {
+	db, err := bolt.Open(path, 0666, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(exitsBucket); err != nil {
+			return fmt.Errorf("failed to create bucket=%s: %w", string(exitsBucket), err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		db.Close()
+
+		return nil, err
+	}
+
+	return &exitStore{db: db}, nil
+}
+
+// close closes the store
+func (s *exitStore) close() error {
+	return s.db.Close()
+}
+
+// add inserts the exit as pending, the exits already tracked are left intact
+func (s *exitStore) add(id, blockNumber uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(exitsBucket)
+
+		key := common.EncodeUint64ToBytes(id)
+		if bucket.Get(key) != nil {
+			return nil
+		}
+
+		raw, err := json.Marshal(&ExitRelay{
+			ID:          id,
+			BlockNumber: blockNumber,
+			Status:      ExitPending,
+		})
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(key, raw)
+	})
+}
+
+// update overwrites the tracked exits
+func (s *exitStore) update(relays ...*ExitRelay) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(exitsBucket)
+
+		for _, relay := range relays {
+			raw, err := json.Marshal(relay)
+			if err != nil {
+				return err
+			}
+
+			if err := bucket.Put(common.EncodeUint64ToBytes(relay.ID), raw); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// get returns the tracked exit by its id
+func (s *exitStore) get(id uint64) (*ExitRelay, error) {
+	var relay *ExitRelay
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(exitsBucket).Get(common.EncodeUint64ToBytes(id))
+		if raw == nil {
+			return ErrExitNotFound
+		}
+
+		return json.Unmarshal(raw, &relay)
+	})
+
+	return relay, err
+}
+
+// pending returns the exits awaiting execution, sorted by id
+func (s *exitStore) pending() ([]*ExitRelay, error) {
+	var relays []*ExitRelay
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(exitsBucket).ForEach(func(_, v []byte) error {
+			var relay *ExitRelay
+			if err := json.Unmarshal(v, &relay); err != nil {
+				return err
+			}
+
+			if relay.Status == ExitPending {
+				relays = append(relays, relay)
+			}
+
+			return nil
+		})
+	})
+
+	return relays, err
+}
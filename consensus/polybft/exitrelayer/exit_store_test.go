@@ -0,0 +1,62 @@
+package exitrelayer
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExitStore(t *testing.T) *exitStore {
+	t.Helper()
+
+	store, err := newExitStore(path.Join(t.TempDir(), "exit_relayer.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, store.close())
+	})
+
+	return store
+}
+
+func TestExitStore_AddAndUpdate(t *testing.T) {
+	t.Parallel()
+
+	store := newTestExitStore(t)
+
+	require.NoError(t, store.add(1, 10))
+	require.NoError(t, store.add(2, 11))
+
+	pending, err := store.pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	require.Equal(t, uint64(1), pending[0].ID)
+	require.Equal(t, uint64(10), pending[0].BlockNumber)
+	require.Equal(t, ExitPending, pending[0].Status)
+
+	pending[0].Status = ExitExecuted
+	pending[1].Attempts = 1
+	pending[1].LastError = "execution reverted"
+	pending[1].NextAttempt = time.Unix(1_000_000, 0)
+	require.NoError(t, store.update(pending...))
+
+	// the exits already tracked are not reset
+	require.NoError(t, store.add(2, 12))
+
+	pending, err = store.pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, uint64(2), pending[0].ID)
+	require.Equal(t, uint64(11), pending[0].BlockNumber)
+	require.Equal(t, uint64(1), pending[0].Attempts)
+	require.True(t, pending[0].NextAttempt.Equal(time.Unix(1_000_000, 0)))
+
+	relay, err := store.get(1)
+	require.NoError(t, err)
+	require.Equal(t, ExitExecuted, relay.Status)
+
+	_, err = store.get(3)
+	require.ErrorIs(t, err, ErrExitNotFound)
+}
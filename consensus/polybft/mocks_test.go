@@ -331,6 +331,12 @@ func (tp *txPoolMock) Demote(tx *types.Transaction) {
 	tp.Called(tx)
 }
 
+func (tp *txPoolMock) Fits(tx *types.Transaction, gasUsed, gasLimit uint64) bool {
+	args := tp.Called(tx, gasUsed, gasLimit)
+
+	return args.Bool(0)
+}
+
 func (tp *txPoolMock) SetSealing(v bool) {
 	tp.Called(v)
 }
@@ -629,6 +629,40 @@ func (p *Polybft) VerifyHeader(header *types.Header) error {
 	return p.verifyHeaderImpl(parent, header, p.consensusConfig.BlockTimeDrift, nil)
 }
 
+// VerifyHeaders verifies a chain of headers, including their committed seals,
+// before their parents are written to the blockchain.
+// The parents are the already verified headers preceding the headers, which are not written yet
+func (p *Polybft) VerifyHeaders(parents, headers []*types.Header) error {
+	var parent *types.Header
+
+	if len(parents) > 0 {
+		parent = parents[len(parents)-1]
+	} else {
+		var ok bool
+
+		if parent, ok = p.blockchain.GetHeaderByHash(headers[0].ParentHash); !ok {
+			return fmt.Errorf(
+				"unable to get parent header by hash for block number %d",
+				headers[0].Number,
+			)
+		}
+	}
+
+	chain := make([]*types.Header, 0, len(parents)+len(headers))
+	chain = append(chain, parents...)
+
+	for _, header := range headers {
+		if err := p.verifyHeaderImpl(parent, header, p.consensusConfig.BlockTimeDrift, chain); err != nil {
+			return err
+		}
+
+		chain = append(chain, header)
+		parent = header
+	}
+
+	return nil
+}
+
 func (p *Polybft) verifyHeaderImpl(parent, header *types.Header, blockTimeDrift uint64, parents []*types.Header) error {
 	// validate header fields
 	if err := validateHeaderFields(parent, header, blockTimeDrift); err != nil {
@@ -190,6 +190,10 @@ func TestPolybft_VerifyHeader(t *testing.T) {
 	assert.NoError(t, polybft.validatorsCache.storeSnapshot(&validatorSnapshot{Epoch: 1, Snapshot: validatorSetCurrent}))
 	assert.NoError(t, polybft.VerifyHeader(currentHeader))
 
+	// verify the header as a chain of headers not written to the blockchain yet
+	assert.NoError(t, polybft.VerifyHeaders(nil, []*types.Header{currentHeader}))
+	assert.ErrorContains(t, polybft.VerifyHeaders(nil, []*types.Header{{Number: 12}}), "unable to get parent header")
+
 	// add current header to the blockchain (headersMap) and try validating again
 	headersMap.addHeader(currentHeader)
 	assert.NoError(t, polybft.VerifyHeader(currentHeader))
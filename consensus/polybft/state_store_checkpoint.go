@@ -17,6 +17,10 @@ var (
 	// bucket to store exit contract events
 	exitEventsBucket             = []byte("exitEvent")
 	exitEventToEpochLookupBucket = []byte("exitIdToEpochLookup")
+	// bucket to index exit events by their sender
+	exitEventsBySenderBucket = []byte("exitEventsBySender")
+	// bucket to store the ids of the exits known to be executed on the rootchain
+	processedExitsBucket = []byte("processedExits")
 )
 
 type exitEventNotFoundError struct {
@@ -34,6 +38,12 @@ Bolt DB schema:
 exit events/
 |--> (id+epoch+blockNumber) -> *ExitEvent (json marshalled)
 |--> (exitEventID) -> epochNumber
+
+exit events by sender/
+|--> (sender+exitEventID) -> nil
+
+processed exits/
+|--> (exitEventID) -> nil
 */
 type CheckpointStore struct {
 	db *bolt.DB
@@ -49,7 +59,28 @@ func (s *CheckpointStore) initialize(tx *bolt.Tx) error {
 		return fmt.Errorf("failed to create bucket=%s: %w", string(exitEventToEpochLookupBucket), err)
 	}
 
-	return nil
+	if _, err := tx.CreateBucketIfNotExists(processedExitsBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(processedExitsBucket), err)
+	}
+
+	if tx.Bucket(exitEventsBySenderBucket) != nil {
+		return nil
+	}
+
+	senderBucket, err := tx.CreateBucket(exitEventsBySenderBucket)
+	if err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(exitEventsBySenderBucket), err)
+	}
+
+	// index the exit events stored before the index was introduced
+	return tx.Bucket(exitEventsBucket).ForEach(func(_, v []byte) error {
+		var exitEvent *ExitEvent
+		if err := json.Unmarshal(v, &exitEvent); err != nil {
+			return err
+		}
+
+		return senderBucket.Put(exitEventSenderKey(exitEvent.Sender, exitEvent.ID), nil)
+	})
 }
 
 // insertExitEvents inserts a slice of exit events to exit event bucket in bolt db
@@ -62,8 +93,9 @@ func (s *CheckpointStore) insertExitEvents(exitEvents []*ExitEvent) error {
 	return s.db.Update(func(tx *bolt.Tx) error {
 		exitEventBucket := tx.Bucket(exitEventsBucket)
 		lookupBucket := tx.Bucket(exitEventToEpochLookupBucket)
+		senderBucket := tx.Bucket(exitEventsBySenderBucket)
 		for i := 0; i < len(exitEvents); i++ {
-			if err := insertExitEventToBucket(exitEventBucket, lookupBucket, exitEvents[i]); err != nil {
+			if err := insertExitEventToBucket(exitEventBucket, lookupBucket, senderBucket, exitEvents[i]); err != nil {
 				return err
 			}
 		}
@@ -73,7 +105,7 @@ func (s *CheckpointStore) insertExitEvents(exitEvents []*ExitEvent) error {
 }
 
 // insertExitEventToBucket inserts exit event to exit event bucket
-func insertExitEventToBucket(exitEventBucket, lookupBucket *bolt.Bucket, exitEvent *ExitEvent) error {
+func insertExitEventToBucket(exitEventBucket, lookupBucket, senderBucket *bolt.Bucket, exitEvent *ExitEvent) error {
 	raw, err := json.Marshal(exitEvent)
 	if err != nil {
 		return err
@@ -88,7 +120,16 @@ func insertExitEventToBucket(exitEventBucket, lookupBucket *bolt.Bucket, exitEve
 		return err
 	}
 
-	return lookupBucket.Put(exitIDBytes, epochBytes)
+	if err := lookupBucket.Put(exitIDBytes, epochBytes); err != nil {
+		return err
+	}
+
+	return senderBucket.Put(exitEventSenderKey(exitEvent.Sender, exitEvent.ID), nil)
+}
+
+// exitEventSenderKey returns the key of the exit event in the sender index
+func exitEventSenderKey(sender ethgo.Address, exitEventID uint64) []byte {
+	return bytes.Join([][]byte{sender.Bytes(), common.EncodeUint64ToBytes(exitEventID)}, nil)
 }
 
 // getExitEvent returns exit event with given id, which happened in given epoch and given block number
@@ -123,6 +164,74 @@ func (s *CheckpointStore) getExitEvent(exitEventID uint64) (*ExitEvent, error) {
 	return exitEvent, err
 }
 
+// getExitEventsBySender returns at most limit exit events sent by the given sender,
+// starting from the given exit event id, sorted by id
+func (s *CheckpointStore) getExitEventsBySender(sender ethgo.Address, fromID uint64, limit int) ([]*ExitEvent, error) {
+	var exitEventIDs []uint64
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(exitEventsBySenderBucket).Cursor()
+		prefix := sender.Bytes()
+
+		for k, _ := c.Seek(exitEventSenderKey(sender, fromID)); bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			if len(exitEventIDs) == limit {
+				break
+			}
+
+			exitEventIDs = append(exitEventIDs, common.EncodeBytesToUint64(k[len(prefix):]))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exitEvents := make([]*ExitEvent, 0, len(exitEventIDs))
+
+	for _, id := range exitEventIDs {
+		exitEvent, err := s.getExitEvent(id)
+		if err != nil {
+			return nil, err
+		}
+
+		exitEvents = append(exitEvents, exitEvent)
+	}
+
+	return exitEvents, nil
+}
+
+// insertProcessedExits marks the exits with the given ids as executed on the rootchain
+func (s *CheckpointStore) insertProcessedExits(exitEventIDs ...uint64) error {
+	if len(exitEventIDs) == 0 {
+		return nil
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(processedExitsBucket)
+		for _, id := range exitEventIDs {
+			if err := bucket.Put(common.EncodeUint64ToBytes(id), nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// isExitProcessed checks whether the exit with the given id is known to be executed on the rootchain
+func (s *CheckpointStore) isExitProcessed(exitEventID uint64) (bool, error) {
+	processed := false
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		processed = tx.Bucket(processedExitsBucket).Get(common.EncodeUint64ToBytes(exitEventID)) != nil
+
+		return nil
+	})
+
+	return processed, err
+}
+
 // getExitEventsByEpoch returns all exit events that happened in the given epoch
 func (s *CheckpointStore) getExitEventsByEpoch(epoch uint64) ([]*ExitEvent, error) {
 	return s.getExitEvents(epoch, func(exitEvent *ExitEvent) bool {
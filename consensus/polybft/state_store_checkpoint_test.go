@@ -37,6 +37,45 @@ func TestState_Insert_And_Get_ExitEvents_PerEpoch(t *testing.T) {
 	})
 }
 
+func TestState_Insert_And_Get_ExitEvents_BySender(t *testing.T) {
+	t.Parallel()
+
+	var (
+		senderA = ethgo.BytesToAddress([]byte{1})
+		senderB = ethgo.BytesToAddress([]byte{2})
+	)
+
+	state := newTestState(t)
+
+	exitEvents := []*ExitEvent{
+		{ID: 1, Sender: senderA, EpochNumber: 1, BlockNumber: 1},
+		{ID: 2, Sender: senderB, EpochNumber: 1, BlockNumber: 2},
+		{ID: 3, Sender: senderA, EpochNumber: 2, BlockNumber: 11},
+	}
+	require.NoError(t, state.CheckpointStore.insertExitEvents(exitEvents))
+
+	events, err := state.CheckpointStore.getExitEventsBySender(senderA, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, uint64(1), events[0].ID)
+	require.Equal(t, uint64(3), events[1].ID)
+	require.Equal(t, uint64(11), events[1].BlockNumber)
+
+	events, err = state.CheckpointStore.getExitEventsBySender(senderA, 2, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(3), events[0].ID)
+
+	events, err = state.CheckpointStore.getExitEventsBySender(senderA, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(1), events[0].ID)
+
+	events, err = state.CheckpointStore.getExitEventsBySender(ethgo.BytesToAddress([]byte{3}), 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
 func TestState_Insert_And_Get_ExitEvents_ForProof(t *testing.T) {
 	const (
 		numOfEpochs         = 11
@@ -2,6 +2,7 @@ package polybft
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
@@ -57,6 +58,29 @@ func (s *EpochStore) initialize(tx *bolt.Tx) error {
 	return nil
 }
 
+// ResetValidatorSnapshots removes the validator snapshots from the consensus state at the given path,
+// so that they are computed again from the headers of the chain. The consensus state imported
+// from a state snapshot is reset, so that the imported headers are verified with the validators
+// computed from them rather than with the imported validator snapshots
+func ResetValidatorSnapshots(path string) error {
+	db, err := bolt.Open(path, 0666, nil)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(validatorSnapshotsBucket); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+			return err
+		}
+
+		_, err := tx.CreateBucket(validatorSnapshotsBucket)
+
+		return err
+	})
+}
+
 // insertValidatorSnapshot inserts a validator snapshot for the given block to its bucket in db
 func (s *EpochStore) insertValidatorSnapshot(validatorSnapshot *validatorSnapshot) error {
 	return s.db.Update(func(tx *bolt.Tx) error {
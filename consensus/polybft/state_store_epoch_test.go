@@ -2,12 +2,14 @@ package polybft
 
 import (
 	"fmt"
+	"path/filepath"
 	"sync"
 	"testing"
 
 	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -214,3 +216,31 @@ func TestState_getLastSnapshot(t *testing.T) {
 	assert.Equal(t, lastEpoch, snapshotFromDB.Epoch)
 	assert.Equal(t, lastEpoch*fixedEpochSize, snapshotFromDB.EpochEndingBlock)
 }
+
+func TestResetValidatorSnapshots(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), stateFileName)
+
+	state, err := newState(path, hclog.NewNullLogger(), make(chan struct{}))
+	require.NoError(t, err)
+
+	require.NoError(t, state.EpochStore.insertEpoch(1))
+	require.NoError(t, state.EpochStore.insertValidatorSnapshot(&validatorSnapshot{Epoch: 1, EpochEndingBlock: 10}))
+	require.NoError(t, state.db.Close())
+
+	require.NoError(t, ResetValidatorSnapshots(path))
+
+	state, err = newState(path, hclog.NewNullLogger(), make(chan struct{}))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, state.db.Close())
+	})
+
+	// the snapshots are removed, while the rest of the state is kept
+	snapshot, err := state.EpochStore.getLastSnapshot()
+	require.NoError(t, err)
+	assert.Nil(t, snapshot)
+	assert.True(t, state.EpochStore.isEpochInserted(1))
+}
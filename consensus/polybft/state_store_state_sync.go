@@ -1,12 +1,14 @@
 package polybft
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
 	bolt "go.etcd.io/bbolt"
 )
 
@@ -17,6 +19,10 @@ var (
 	commitmentsBucket = []byte("commitments")
 	// bucket to store state sync proofs
 	stateSyncProofsBucket = []byte("stateSyncProofs")
+	// bucket to index state sync events by their receiver
+	stateSyncsByReceiverBucket = []byte("stateSyncsByReceiver")
+	// bucket to store the ids of the state syncs executed on the child chain
+	executedStateSyncsBucket = []byte("executedStateSyncs")
 	// bucket to store message votes (signatures)
 	messageVotesBucket = []byte("votes")
 
@@ -39,6 +45,12 @@ commitments/
 
 stateSyncProofs/
 |--> stateSyncProof.StateSync.Id -> *StateSyncProof (json marshalled)
+
+stateSyncsByReceiver/
+|--> (stateSyncEvent.Receiver+stateSyncEvent.Id) -> nil
+
+executedStateSyncs/
+|--> stateSyncEvent.Id -> nil
 */
 
 type StateSyncStore struct {
@@ -59,7 +71,28 @@ func (s *StateSyncStore) initialize(tx *bolt.Tx) error {
 		return fmt.Errorf("failed to create bucket=%s: %w", string(stateSyncProofsBucket), err)
 	}
 
-	return nil
+	if _, err := tx.CreateBucketIfNotExists(executedStateSyncsBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(executedStateSyncsBucket), err)
+	}
+
+	if tx.Bucket(stateSyncsByReceiverBucket) != nil {
+		return nil
+	}
+
+	indexBucket, err := tx.CreateBucket(stateSyncsByReceiverBucket)
+	if err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(stateSyncsByReceiverBucket), err)
+	}
+
+	// index the state sync events stored before the index was introduced
+	return tx.Bucket(stateSyncEventsBucket).ForEach(func(_, v []byte) error {
+		var event *contractsapi.StateSyncedEvent
+		if err := json.Unmarshal(v, &event); err != nil {
+			return err
+		}
+
+		return indexBucket.Put(stateSyncReceiverKey(event.Receiver, event.ID.Uint64()), nil)
+	})
 }
 
 // insertStateSyncEvent inserts a new state sync event to state event bucket in db
@@ -71,11 +104,143 @@ func (s *StateSyncStore) insertStateSyncEvent(event *contractsapi.StateSyncedEve
 		}
 
 		bucket := tx.Bucket(stateSyncEventsBucket)
+		if err := bucket.Put(common.EncodeUint64ToBytes(event.ID.Uint64()), raw); err != nil {
+			return err
+		}
 
-		return bucket.Put(common.EncodeUint64ToBytes(event.ID.Uint64()), raw)
+		return tx.Bucket(stateSyncsByReceiverBucket).Put(stateSyncReceiverKey(event.Receiver, event.ID.Uint64()), nil)
 	})
 }
 
+// removeStateSyncEvent removes the state sync event with the given id from db
+func (s *StateSyncStore) removeStateSyncEvent(stateSyncID uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(stateSyncEventsBucket)
+		key := common.EncodeUint64ToBytes(stateSyncID)
+
+		v := bucket.Get(key)
+		if v == nil {
+			return nil
+		}
+
+		var event *contractsapi.StateSyncedEvent
+		if err := json.Unmarshal(v, &event); err != nil {
+			return err
+		}
+
+		indexKey := stateSyncReceiverKey(event.Receiver, stateSyncID)
+		if err := tx.Bucket(stateSyncsByReceiverBucket).Delete(indexKey); err != nil {
+			return err
+		}
+
+		return bucket.Delete(key)
+	})
+}
+
+// getStateSyncEvent returns the state sync event with the given id, or nil if it is not stored
+func (s *StateSyncStore) getStateSyncEvent(stateSyncID uint64) (*contractsapi.StateSyncedEvent, error) {
+	var event *contractsapi.StateSyncedEvent
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(stateSyncEventsBucket).Get(common.EncodeUint64ToBytes(stateSyncID))
+		if v == nil {
+			return nil
+		}
+
+		return json.Unmarshal(v, &event)
+	})
+
+	return event, err
+}
+
+// getStateSyncEventsByReceiver returns at most limit state sync events sent to the given receiver,
+// starting from the given state sync id, sorted by id
+func (s *StateSyncStore) getStateSyncEventsByReceiver(
+	receiver types.Address, fromID uint64, limit int) ([]*contractsapi.StateSyncedEvent, error) {
+	var events []*contractsapi.StateSyncedEvent
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		eventsBucket := tx.Bucket(stateSyncEventsBucket)
+		c := tx.Bucket(stateSyncsByReceiverBucket).Cursor()
+		prefix := receiver.Bytes()
+
+		for k, _ := c.Seek(stateSyncReceiverKey(receiver, fromID)); bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			if len(events) == limit {
+				break
+			}
+
+			v := eventsBucket.Get(k[len(prefix):])
+			if v == nil {
+				continue
+			}
+
+			var event *contractsapi.StateSyncedEvent
+			if err := json.Unmarshal(v, &event); err != nil {
+				return err
+			}
+
+			events = append(events, event)
+		}
+
+		return nil
+	})
+
+	return events, err
+}
+
+// insertExecutedStateSyncs marks the state syncs with the given ids as executed on the child chain
+func (s *StateSyncStore) insertExecutedStateSyncs(stateSyncIDs []uint64) error {
+	if len(stateSyncIDs) == 0 {
+		return nil
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(executedStateSyncsBucket)
+		for _, id := range stateSyncIDs {
+			if err := bucket.Put(common.EncodeUint64ToBytes(id), nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// getStateSyncStatus returns how far the state sync with the given id has progressed on the child chain.
+// The executed state syncs are indexed only from the blocks finalized since the index was introduced,
+// so the state syncs executed in the earlier blocks are reported as committed
+func (s *StateSyncStore) getStateSyncStatus(stateSyncID uint64) (types.BridgeEventStatus, error) {
+	executed := false
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		executed = tx.Bucket(executedStateSyncsBucket).Get(common.EncodeUint64ToBytes(stateSyncID)) != nil
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if executed {
+		return types.BridgeEventExecuted, nil
+	}
+
+	if _, err := s.getCommitmentForStateSync(stateSyncID); err != nil {
+		if errors.Is(err, errNoCommitmentForStateSync) {
+			return types.BridgeEventPending, nil
+		}
+
+		return "", err
+	}
+
+	return types.BridgeEventCommitted, nil
+}
+
+// stateSyncReceiverKey returns the key of the state sync in the receiver index
+func stateSyncReceiverKey(receiver types.Address, stateSyncID uint64) []byte {
+	return bytes.Join([][]byte{receiver.Bytes(), common.EncodeUint64ToBytes(stateSyncID)}, nil)
+}
+
 // list iterates through all events in events bucket in db, un-marshals them, and returns as array
 func (s *StateSyncStore) list() ([]*contractsapi.StateSyncedEvent, error) {
 	events := []*contractsapi.StateSyncedEvent{}
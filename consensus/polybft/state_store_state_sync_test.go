@@ -190,6 +190,95 @@ func TestState_getCommitmentForStateSync(t *testing.T) {
 	}
 }
 
+func TestState_StateSync_GetByReceiverAndStatus(t *testing.T) {
+	t.Parallel()
+
+	var (
+		receiverA = types.StringToAddress("1")
+		receiverB = types.StringToAddress("2")
+	)
+
+	state := newTestState(t)
+
+	for i, receiver := range []types.Address{receiverA, receiverB, receiverA, receiverA} {
+		event := createTestStateSync(int64(i + 1))
+		event.Receiver = receiver
+
+		require.NoError(t, state.StateSyncStore.insertStateSyncEvent(event))
+	}
+
+	events, err := state.StateSyncStore.getStateSyncEventsByReceiver(receiverA, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	for i, id := range []int64{1, 3, 4} {
+		require.Equal(t, id, events[i].ID.Int64())
+	}
+
+	events, err = state.StateSyncStore.getStateSyncEventsByReceiver(receiverA, 2, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, int64(3), events[0].ID.Int64())
+
+	events, err = state.StateSyncStore.getStateSyncEventsByReceiver(receiverA, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, int64(1), events[0].ID.Int64())
+
+	events, err = state.StateSyncStore.getStateSyncEventsByReceiver(types.StringToAddress("3"), 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	status, err := state.StateSyncStore.getStateSyncStatus(1)
+	require.NoError(t, err)
+	require.Equal(t, types.BridgeEventPending, status)
+
+	require.NoError(t, state.StateSyncStore.insertCommitmentMessage(createTestCommitmentMessage(t, 1)))
+
+	status, err = state.StateSyncStore.getStateSyncStatus(1)
+	require.NoError(t, err)
+	require.Equal(t, types.BridgeEventCommitted, status)
+
+	require.NoError(t, state.StateSyncStore.insertExecutedStateSyncs([]uint64{1}))
+
+	status, err = state.StateSyncStore.getStateSyncStatus(1)
+	require.NoError(t, err)
+	require.Equal(t, types.BridgeEventExecuted, status)
+
+	event, err := state.StateSyncStore.getStateSyncEvent(2)
+	require.NoError(t, err)
+	require.Equal(t, receiverB, event.Receiver)
+
+	event, err = state.StateSyncStore.getStateSyncEvent(5)
+	require.NoError(t, err)
+	require.Nil(t, event)
+}
+
+func TestState_StateSync_IndexExistingEvents(t *testing.T) {
+	t.Parallel()
+
+	receiver := types.StringToAddress("1")
+	state := newTestState(t)
+
+	for i := int64(1); i <= 3; i++ {
+		event := createTestStateSync(i)
+		event.Receiver = receiver
+
+		require.NoError(t, state.StateSyncStore.insertStateSyncEvent(event))
+	}
+
+	// simulate the events stored before the receiver index was introduced
+	require.NoError(t, state.db.Update(func(tx *bbolt.Tx) error {
+		return tx.DeleteBucket(stateSyncsByReceiverBucket)
+	}))
+
+	require.NoError(t, state.initStorages())
+
+	events, err := state.StateSyncStore.getStateSyncEventsByReceiver(receiver, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+}
+
 func TestState_GetNestedBucketInEpoch(t *testing.T) {
 	t.Parallel()
 
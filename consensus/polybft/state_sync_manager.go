@@ -15,6 +15,7 @@ import (
 	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/tracker"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -34,6 +35,8 @@ type StateSyncManager interface {
 	Close()
 	Commitment() (*CommitmentMessageSigned, error)
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
+	GetStateSyncsByReceiver(receiver types.Address, fromID uint64, limit int) ([]*types.BridgeEvent, error)
+	GetStateSync(stateSyncID uint64) (*types.BridgeEvent, error)
 	PostBlock(req *PostBlockRequest) error
 	PostEpoch(req *PostEpochRequest) error
 }
@@ -51,6 +54,13 @@ func (n *dummyStateSyncManager) PostEpoch(req *PostEpochRequest) error         {
 func (n *dummyStateSyncManager) GetStateSyncProof(stateSyncID uint64) (types.Proof, error) {
 	return types.Proof{}, nil
 }
+func (n *dummyStateSyncManager) GetStateSyncsByReceiver(
+	receiver types.Address, fromID uint64, limit int) ([]*types.BridgeEvent, error) {
+	return nil, nil
+}
+func (n *dummyStateSyncManager) GetStateSync(stateSyncID uint64) (*types.BridgeEvent, error) {
+	return nil, nil
+}
 
 // stateSyncConfig holds the configuration data of state sync manager
 type stateSyncConfig struct {
@@ -125,6 +135,7 @@ func (s *stateSyncManager) initTracker() error {
 	evtTracker := tracker.NewEventTracker(
 		path.Join(s.config.dataDir, "/deposit.db"),
 		s.config.jsonrpcAddr,
+		nil,
 		ethgo.Address(s.config.stateSenderAddr),
 		s,
 		s.config.numBlockConfirmations,
@@ -220,12 +231,12 @@ func (s *stateSyncManager) verifyVoteSignature(valSet validator.ValidatorSet, si
 }
 
 // AddLog saves the received log from event tracker if it matches a state sync event ABI
-func (s *stateSyncManager) AddLog(eventLog *ethgo.Log) {
+func (s *stateSyncManager) AddLog(eventLog *ethgo.Log) error {
 	event := &contractsapi.StateSyncedEvent{}
 
 	doesMatch, err := event.ParseLog(eventLog)
 	if !doesMatch {
-		return
+		return nil
 	}
 
 	s.logger.Info(
@@ -238,18 +249,71 @@ func (s *stateSyncManager) AddLog(eventLog *ethgo.Log) {
 	if err != nil {
 		s.logger.Error("could not decode state sync event", "err", err)
 
-		return
+		return nil
 	}
 
 	if err := s.state.StateSyncStore.insertStateSyncEvent(event); err != nil {
-		s.logger.Error("could not save state sync event to boltDb", "err", err)
-
-		return
+		return fmt.Errorf("could not save state sync event to boltDb: %w", err)
 	}
 
 	if err := s.buildCommitment(); err != nil {
 		s.logger.Error("could not build a commitment on arrival of new state sync", "err", err, "stateSyncID", event.ID)
 	}
+
+	return nil
+}
+
+// RemoveLog invalidates the state sync event removed from the rootchain by a reorg,
+// unless it is already committed to the child chain
+func (s *stateSyncManager) RemoveLog(eventLog *ethgo.Log) {
+	event := &contractsapi.StateSyncedEvent{}
+
+	doesMatch, err := event.ParseLog(eventLog)
+	if !doesMatch {
+		return
+	}
+
+	if err != nil {
+		s.logger.Error("could not decode removed state sync event", "err", err)
+
+		return
+	}
+
+	s.logger.Warn(
+		"Remove State sync event",
+		"block", eventLog.BlockNumber,
+		"hash", eventLog.TransactionHash,
+		"index", eventLog.LogIndex,
+		"stateSyncID", event.ID,
+	)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stateSyncID := event.ID.Uint64()
+	if stateSyncID < s.nextCommittedIndex {
+		s.logger.Error("state sync event removed by a reorg is already committed", "stateSyncID", stateSyncID)
+
+		return
+	}
+
+	if err := s.state.StateSyncStore.removeStateSyncEvent(stateSyncID); err != nil {
+		s.logger.Error("could not remove state sync event from boltDb", "err", err, "stateSyncID", stateSyncID)
+
+		return
+	}
+
+	// discard the pending commitments containing the removed state sync,
+	// new ones are built once the state syncs replacing it arrive
+	pendingCommitments := make([]*PendingCommitment, 0, len(s.pendingCommitments))
+
+	for _, commitment := range s.pendingCommitments {
+		if commitment.EndID.Uint64() < stateSyncID {
+			pendingCommitments = append(pendingCommitments, commitment)
+		}
+	}
+
+	s.pendingCommitments = pendingCommitments
 }
 
 // Commitment returns a commitment to be submitted if there is a pending commitment with quorum
@@ -380,7 +444,14 @@ func (s *stateSyncManager) PostEpoch(req *PostEpochRequest) error {
 
 // PostBlock notifies state sync manager that a block was finalized,
 // so that it can build state sync proofs if a block has a commitment submission transaction
+// and keep track of the state syncs executed in the block.
+// The blocks finalized before the node was upgraded are not indexed, see getStateSyncStatus
 func (s *stateSyncManager) PostBlock(req *PostBlockRequest) error {
+	if err := s.state.StateSyncStore.insertExecutedStateSyncs(
+		getExecutedStateSyncsFromReceipts(req.FullBlock.Receipts)); err != nil {
+		return fmt.Errorf("insert executed state syncs error: %w", err)
+	}
+
 	commitment, err := getCommitmentMessageSignedTx(req.FullBlock.Block.Transactions)
 	if err != nil {
 		return err
@@ -443,6 +514,56 @@ func (s *stateSyncManager) GetStateSyncProof(stateSyncID uint64) (types.Proof, e
 	}, nil
 }
 
+// GetStateSyncsByReceiver returns at most limit state syncs sent to the given receiver,
+// starting from the given state sync id, along with their status
+func (s *stateSyncManager) GetStateSyncsByReceiver(
+	receiver types.Address, fromID uint64, limit int) ([]*types.BridgeEvent, error) {
+	events, err := s.state.StateSyncStore.getStateSyncEventsByReceiver(receiver, fromID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	stateSyncs := make([]*types.BridgeEvent, len(events))
+
+	for i, event := range events {
+		if stateSyncs[i], err = s.toBridgeEvent(event); err != nil {
+			return nil, err
+		}
+	}
+
+	return stateSyncs, nil
+}
+
+// GetStateSync returns the state sync with the given id along with its status
+func (s *stateSyncManager) GetStateSync(stateSyncID uint64) (*types.BridgeEvent, error) {
+	event, err := s.state.StateSyncStore.getStateSyncEvent(stateSyncID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, fmt.Errorf("could not find any state sync that has an id: %d", stateSyncID)
+	}
+
+	return s.toBridgeEvent(event)
+}
+
+// toBridgeEvent converts the state sync event to the bridge event
+func (s *stateSyncManager) toBridgeEvent(event *contractsapi.StateSyncedEvent) (*types.BridgeEvent, error) {
+	status, err := s.state.StateSyncStore.getStateSyncStatus(event.ID.Uint64())
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.BridgeEvent{
+		Type:     types.StateSyncBridgeEvent,
+		ID:       event.ID.Uint64(),
+		Sender:   event.Sender,
+		Receiver: event.Receiver,
+		Status:   status,
+	}, nil
+}
+
 // buildProofs builds state sync proofs for the submitted commitment and saves them in boltDb for later execution
 func (s *stateSyncManager) buildProofs(commitmentMsg *contractsapi.StateSyncCommitment) error {
 	from := commitmentMsg.StartID.Uint64()
@@ -577,3 +698,31 @@ func (s *stateSyncManager) multicast(msg interface{}) {
 		s.logger.Warn("failed to gossip bridge message", "err", err)
 	}
 }
+
+// getExecutedStateSyncsFromReceipts returns the ids of the state syncs successfully executed in the given receipts
+func getExecutedStateSyncsFromReceipts(receipts []*types.Receipt) []uint64 {
+	var stateSyncIDs []uint64
+
+	for _, receipt := range receipts {
+		if receipt.Status == nil || *receipt.Status != types.ReceiptSuccess {
+			continue
+		}
+
+		for _, log := range receipt.Logs {
+			if log.Address != contracts.StateReceiverContract {
+				continue
+			}
+
+			var result contractsapi.StateSyncResultEvent
+
+			doesMatch, err := result.ParseLog(convertLog(log))
+			if err != nil || !doesMatch || !result.Status {
+				continue
+			}
+
+			stateSyncIDs = append(stateSyncIDs, result.Counter.Uint64())
+		}
+	}
+
+	return stateSyncIDs
+}
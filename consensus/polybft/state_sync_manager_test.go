@@ -10,6 +10,8 @@ import (
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
 	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/merkle-tree"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -336,7 +338,7 @@ func TestStateSyncerManager_AddLog_BuildCommitments(t *testing.T) {
 	s := newTestStateSyncManager(t, vals.GetValidator("0"))
 
 	// empty log which is not an state sync
-	s.AddLog(&ethgo.Log{})
+	require.NoError(t, s.AddLog(&ethgo.Log{}))
 	stateSyncs, err := s.state.StateSyncStore.list()
 
 	require.NoError(t, err)
@@ -347,7 +349,7 @@ func TestStateSyncerManager_AddLog_BuildCommitments(t *testing.T) {
 	stateSyncEventID := stateSyncedEvent.Sig()
 
 	// log with the state sync topic but incorrect content
-	s.AddLog(&ethgo.Log{Topics: []ethgo.Hash{stateSyncEventID}})
+	require.NoError(t, s.AddLog(&ethgo.Log{Topics: []ethgo.Hash{stateSyncEventID}}))
 	stateSyncs, err = s.state.StateSyncStore.list()
 
 	require.NoError(t, err)
@@ -367,7 +369,7 @@ func TestStateSyncerManager_AddLog_BuildCommitments(t *testing.T) {
 		Data: data,
 	}
 
-	s.AddLog(goodLog)
+	require.NoError(t, s.AddLog(goodLog))
 
 	stateSyncs, err = s.state.StateSyncStore.getStateSyncEventsForCommitment(0, 0)
 	require.NoError(t, err)
@@ -379,7 +381,7 @@ func TestStateSyncerManager_AddLog_BuildCommitments(t *testing.T) {
 	// add one more log to have a minimum commitment
 	goodLog2 := goodLog.Copy()
 	goodLog2.Topics[1] = ethgo.BytesToHash([]byte{0x1}) // state sync index 1
-	s.AddLog(goodLog2)
+	require.NoError(t, s.AddLog(goodLog2))
 
 	require.Len(t, s.pendingCommitments, 2)
 	require.Equal(t, uint64(0), s.pendingCommitments[1].StartID.Uint64())
@@ -388,17 +390,76 @@ func TestStateSyncerManager_AddLog_BuildCommitments(t *testing.T) {
 	// add two more logs to have larger commitments
 	goodLog3 := goodLog.Copy()
 	goodLog3.Topics[1] = ethgo.BytesToHash([]byte{0x2}) // state sync index 2
-	s.AddLog(goodLog3)
+	require.NoError(t, s.AddLog(goodLog3))
 
 	goodLog4 := goodLog.Copy()
 	goodLog4.Topics[1] = ethgo.BytesToHash([]byte{0x3}) // state sync index 3
-	s.AddLog(goodLog4)
+	require.NoError(t, s.AddLog(goodLog4))
 
 	require.Len(t, s.pendingCommitments, 4)
 	require.Equal(t, uint64(0), s.pendingCommitments[3].StartID.Uint64())
 	require.Equal(t, uint64(3), s.pendingCommitments[3].EndID.Uint64())
 }
 
+func TestStateSyncerManager_RemoveLog(t *testing.T) {
+	t.Parallel()
+
+	vals := validator.NewTestValidators(t, 5)
+
+	s := newTestStateSyncManager(t, vals.GetValidator("0"))
+
+	var stateSyncedEvent contractsapi.StateSyncedEvent
+
+	data, err := abi.MustNewType("tuple(string a)").Encode([]string{"data"})
+	require.NoError(t, err)
+
+	createLog := func(stateSyncID byte) *ethgo.Log {
+		return &ethgo.Log{
+			Topics: []ethgo.Hash{
+				stateSyncedEvent.Sig(),
+				ethgo.BytesToHash([]byte{stateSyncID}),
+				ethgo.ZeroHash,
+				ethgo.ZeroHash,
+			},
+			Data: data,
+		}
+	}
+
+	for i := byte(0); i < 4; i++ {
+		require.NoError(t, s.AddLog(createLog(i)))
+	}
+
+	require.Len(t, s.pendingCommitments, 4)
+
+	// the first state sync is already committed
+	s.nextCommittedIndex = 1
+
+	// not a state sync log
+	s.RemoveLog(&ethgo.Log{})
+
+	s.RemoveLog(createLog(3))
+
+	stateSyncs, err := s.state.StateSyncStore.list()
+	require.NoError(t, err)
+	require.Len(t, stateSyncs, 3)
+	require.Len(t, s.pendingCommitments, 3)
+	require.Equal(t, uint64(2), s.pendingCommitments[2].EndID.Uint64())
+
+	// committed state sync is kept
+	s.RemoveLog(createLog(0))
+
+	stateSyncs, err = s.state.StateSyncStore.list()
+	require.NoError(t, err)
+	require.Len(t, stateSyncs, 3)
+
+	// the state sync replacing the removed one is committed again
+	require.NoError(t, s.AddLog(createLog(3)))
+
+	require.Len(t, s.pendingCommitments, 4)
+	require.Equal(t, uint64(1), s.pendingCommitments[3].StartID.Uint64())
+	require.Equal(t, uint64(3), s.pendingCommitments[3].EndID.Uint64())
+}
+
 func TestStateSyncerManager_EventTracker_Sync(t *testing.T) {
 	t.Parallel()
 
@@ -444,6 +505,70 @@ func TestStateSyncerManager_EventTracker_Sync(t *testing.T) {
 	require.Len(t, events, 10)
 }
 
+func TestStateSyncManager_PostBlock_ExecutedStateSyncs(t *testing.T) {
+	t.Parallel()
+
+	vals := validator.NewTestValidators(t, 5)
+	s := newTestStateSyncManager(t, vals.GetValidator("0"))
+
+	receiver := types.StringToAddress("1")
+
+	for i := int64(1); i <= 2; i++ {
+		event := createTestStateSync(i)
+		event.Receiver = receiver
+
+		require.NoError(t, s.state.StateSyncStore.insertStateSyncEvent(event))
+	}
+
+	createResultLog := func(stateSyncID uint64, success bool) *types.Log {
+		var result contractsapi.StateSyncResultEvent
+
+		status := types.ZeroHash
+		if success {
+			status = types.BytesToHash([]byte{1})
+		}
+
+		data, err := abi.MustNewType("tuple(bytes message)").Encode(map[string]interface{}{"message": []byte{}})
+		require.NoError(t, err)
+
+		return &types.Log{
+			Address: contracts.StateReceiverContract,
+			Topics: []types.Hash{
+				types.Hash(result.Sig()),
+				types.BytesToHash(common.EncodeUint64ToBytes(stateSyncID)),
+				status,
+			},
+			Data: data,
+		}
+	}
+
+	receipt := &types.Receipt{Logs: []*types.Log{createResultLog(1, true), createResultLog(2, false)}}
+	receipt.SetStatus(types.ReceiptSuccess)
+
+	req := &PostBlockRequest{
+		FullBlock: &types.FullBlock{
+			Block:    &types.Block{Header: &types.Header{Number: 1}},
+			Receipts: []*types.Receipt{receipt},
+		},
+	}
+
+	require.NoError(t, s.PostBlock(req))
+
+	stateSyncs, err := s.GetStateSyncsByReceiver(receiver, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, stateSyncs, 2)
+	require.Equal(t, types.BridgeEventExecuted, stateSyncs[0].Status)
+	require.Equal(t, types.BridgeEventPending, stateSyncs[1].Status)
+
+	stateSync, err := s.GetStateSync(1)
+	require.NoError(t, err)
+	require.Equal(t, types.StateSyncBridgeEvent, stateSync.Type)
+	require.Equal(t, receiver, stateSync.Receiver)
+
+	_, err = s.GetStateSync(3)
+	require.Error(t, err)
+}
+
 func TestStateSyncManager_Close(t *testing.T) {
 	t.Parallel()
 
@@ -5,86 +5,109 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net"
 	"path"
-	"strings"
+	"sync"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
 	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/tracker"
 	"github.com/0xPolygon/polygon-edge/txrelayer"
 	"github.com/0xPolygon/polygon-edge/types"
 
 	hcf "github.com/hashicorp/go-hclog"
 	"github.com/umbracle/ethgo"
-	"github.com/umbracle/ethgo/jsonrpc"
+	bolt "go.etcd.io/bbolt"
 )
 
+const (
+	// minRetryBackoff is the delay before retrying a state sync that failed to execute for the first time
+	minRetryBackoff = 2 * time.Second
+
+	// maxRetryBackoff is the maximum delay between the execution attempts of a state sync
+	maxRetryBackoff = 10 * time.Minute
+
+	// maxExecutionAttempts is the number of failed execution attempts after which a state sync is marked as failed
+	maxExecutionAttempts = 20
+
+	// executedStateSyncsRetention is the number of the latest state syncs whose execution status is kept
+	executedStateSyncsRetention = 10000
+
+	// storeFileName is the name of the file in the data directory the relayer stores the state syncs in
+	storeFileName = "state_sync_relayer.db"
+
+	// storeOpenTimeout is the time to wait for the store held by a running node to be released
+	storeOpenTimeout = time.Second
+)
+
+// ErrNodeRunning is returned when the store of the relayer is held by a running node
+var ErrNodeRunning = errors.New("the state sync relayer store is in use, the node must be stopped")
+
 type StateSyncRelayer struct {
 	dataDir                string
 	rpcEndpoint            string
+	credentials            *txrelayer.Credentials
 	stateReceiverAddr      ethgo.Address
 	eventTrackerStartBlock uint64
 	logger                 hcf.Logger
-	client                 *jsonrpc.Client
+	client                 *txrelayer.Client
 	txRelayer              txrelayer.TxRelayer
 	key                    ethgo.Key
+	store                  *stateSyncStore
+	notifyCh               chan struct{}
 	closeCh                chan struct{}
-}
-
-func sanitizeRPCEndpoint(rpcEndpoint string) string {
-	if rpcEndpoint == "" || strings.Contains(rpcEndpoint, "0.0.0.0") {
-		_, port, err := net.SplitHostPort(rpcEndpoint)
-		if err == nil {
-			rpcEndpoint = fmt.Sprintf("http://%s:%s", "127.0.0.1", port)
-		} else {
-			rpcEndpoint = txrelayer.DefaultRPCAddress
-		}
-	}
-
-	return rpcEndpoint
+	wg                     sync.WaitGroup
 }
 
 func NewRelayer(
 	dataDir string,
 	rpcEndpoint string,
+	credentials *txrelayer.Credentials,
 	stateReceiverAddr ethgo.Address,
 	stateReceiverTrackerStartBlock uint64,
 	logger hcf.Logger,
 	key ethgo.Key,
-) *StateSyncRelayer {
-	endpoint := sanitizeRPCEndpoint(rpcEndpoint)
+) (*StateSyncRelayer, error) {
+	endpoint := txrelayer.SanitizeRPCEndpoint(rpcEndpoint)
 
 	// create the JSON RPC client
-	client, err := jsonrpc.NewClient(endpoint)
+	client, err := txrelayer.NewClient(endpoint, credentials)
 	if err != nil {
-		logger.Error("Failed to create the JSON RPC client", "err", err)
+		return nil, fmt.Errorf("failed to create the JSON RPC client: %w", err)
+	}
 
-		return nil
+	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithClient(client.Client))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the tx relayer: %w", err)
 	}
 
-	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithClient(client))
+	store, err := newStateSyncStore(path.Join(dataDir, storeFileName), nil)
 	if err != nil {
-		logger.Error("Failed to create the tx relayer", "err", err)
+		return nil, fmt.Errorf("failed to open the state sync store: %w", err)
 	}
 
 	return &StateSyncRelayer{
 		dataDir:                dataDir,
 		rpcEndpoint:            endpoint,
+		credentials:            credentials,
 		stateReceiverAddr:      stateReceiverAddr,
 		logger:                 logger,
 		client:                 client,
 		txRelayer:              txRelayer,
 		key:                    key,
+		store:                  store,
+		notifyCh:               make(chan struct{}, 1),
 		closeCh:                make(chan struct{}),
 		eventTrackerStartBlock: stateReceiverTrackerStartBlock,
-	}
+	}, nil
 }
 
 func (r *StateSyncRelayer) Start() error {
 	et := tracker.NewEventTracker(
 		path.Join(r.dataDir, "/relayer.db"),
 		r.rpcEndpoint,
+		r.credentials,
 		r.stateReceiverAddr,
 		r,
 		0, // sidechain (Polygon POS) is instant finality, so no need to wait
@@ -99,28 +122,78 @@ func (r *StateSyncRelayer) Start() error {
 		cancelFn()
 	}()
 
+	// execute the state syncs left pending before the restart along with the new ones
+	r.wg.Add(1)
+
+	go r.run()
+
 	return et.Start(ctx)
 }
 
 // Stop function is used to tear down all the allocated resources
 func (r *StateSyncRelayer) Stop() {
 	close(r.closeCh)
+
+	r.wg.Wait()
+
+	if err := r.store.close(); err != nil {
+		r.logger.Error("Failed to close the state sync store", "err", err)
+	}
+
+	if err := r.client.Close(); err != nil {
+		r.logger.Error("Failed to close the JSON RPC client", "err", err)
+	}
+}
+
+// RequeueFailedStateSyncs marks the failed state syncs with the given ids (all the failed ones if no id is given)
+// tracked by the relayer of the node with the given data directory as pending,
+// so that the relayer executes them again once the node is started. Returns the ids of the requeued state syncs.
+// The node must be stopped, since the store of the relayer can be opened by a single process only
+func RequeueFailedStateSyncs(dataDir string, ids []uint64) ([]uint64, error) {
+	storePath := path.Join(dataDir, storeFileName)
+	if !common.FileExists(storePath) {
+		return nil, fmt.Errorf("the state sync relayer store %s does not exist", storePath)
+	}
+
+	store, err := newStateSyncStore(storePath, &bolt.Options{Timeout: storeOpenTimeout})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, ErrNodeRunning
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open the state sync store: %w", err)
+	}
+
+	defer store.close()
+
+	return store.requeue(ids...)
+}
+
+// StateSync returns the execution status of the state sync tracked by the relayer.
+// The executed state syncs are tracked only until executedStateSyncsRetention newer state syncs arrive
+func (r *StateSyncRelayer) StateSync(id uint64) (*StateSyncRelay, error) {
+	return r.store.get(id)
+}
+
+// PendingStateSyncs returns the state syncs awaiting execution, sorted by id
+func (r *StateSyncRelayer) PendingStateSyncs() ([]*StateSyncRelay, error) {
+	return r.store.pending()
 }
 
-func (r *StateSyncRelayer) AddLog(log *ethgo.Log) {
+// AddLog tracks the state syncs of the new commitment for execution.
+// Returns an error if they can not be stored, so that the log is delivered again
+func (r *StateSyncRelayer) AddLog(log *ethgo.Log) error {
 	r.logger.Debug("Received a log", "log", log)
 
 	var commitEvent contractsapi.NewCommitmentEvent
 
 	doesMatch, err := commitEvent.ParseLog(log)
 	if !doesMatch {
-		return
+		return nil
 	}
 
 	if err != nil {
 		r.logger.Error("Failed to parse log", "err", err)
 
-		return
+		return nil
 	}
 
 	startID := commitEvent.StartID.Uint64()
@@ -128,22 +201,130 @@ func (r *StateSyncRelayer) AddLog(log *ethgo.Log) {
 
 	r.logger.Info("Execute commitment", "Block", log.BlockNumber, "StartID", startID, "EndID", endID)
 
-	for i := startID; i <= endID; i++ {
-		// query the state sync proof
-		stateSyncProof, err := r.queryStateSyncProof(fmt.Sprintf("0x%x", i))
-		if err != nil {
-			r.logger.Error("Failed to query state sync proof", "err", err)
+	if err := r.store.add(startID, endID, log.BlockNumber); err != nil {
+		return fmt.Errorf("failed to store state syncs %d-%d: %w", startID, endID, err)
+	}
 
-			continue
+	// wake up the execution of the new state syncs
+	select {
+	case r.notifyCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// run executes the pending state syncs as they arrive, and retries the failed ones with backoff
+func (r *StateSyncRelayer) run() {
+	defer r.wg.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-r.closeCh:
+			return
+		case <-r.notifyCh:
+		case <-timer.C:
 		}
 
-		if err := r.executeStateSync(stateSyncProof); err != nil {
-			r.logger.Error("Failed to execute state sync", "err", err)
+		wait := r.executePending()
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+
+		timer.Reset(wait)
+	}
+}
+
+// executePending executes the pending state syncs due for execution, in order of their ids.
+// Returns the time until the next execution attempt of the remaining ones
+func (r *StateSyncRelayer) executePending() time.Duration {
+	relays, err := r.store.pending()
+	if err != nil {
+		r.logger.Error("Failed to read pending state syncs", "err", err)
+
+		return minRetryBackoff
+	}
+
+	wait := maxRetryBackoff
+	executed := false
+
+	defer func() {
+		if !executed {
+			return
+		}
+
+		if err := r.store.pruneExecuted(executedStateSyncsRetention); err != nil {
+			r.logger.Error("Failed to prune executed state syncs", "err", err)
+		}
+	}()
+
+	for _, relay := range relays {
+		select {
+		case <-r.closeCh:
+			return wait
+		default:
+		}
+
+		if time.Now().Before(relay.NextAttempt) {
+			wait = common.MinDuration(wait, time.Until(relay.NextAttempt))
 
 			continue
 		}
 
-		r.logger.Info("State sync executed", "ID", i)
+		r.executeRelay(relay)
+
+		switch relay.Status {
+		case StateSyncPending:
+			wait = common.MinDuration(wait, time.Until(relay.NextAttempt))
+		case StateSyncExecuted:
+			executed = true
+		}
+	}
+
+	return wait
+}
+
+// executeRelay executes the state sync, and records the outcome of the attempt
+func (r *StateSyncRelayer) executeRelay(relay *StateSyncRelay) {
+	// query the state sync proof
+	stateSyncProof, err := r.queryStateSyncProof(fmt.Sprintf("0x%x", relay.ID))
+	if err != nil {
+		err = fmt.Errorf("failed to query state sync proof: %w", err)
+	} else {
+		err = r.executeStateSync(stateSyncProof)
+	}
+
+	if err != nil {
+		relay.Attempts++
+		relay.LastError = err.Error()
+
+		if relay.Attempts >= maxExecutionAttempts {
+			// give up on the state sync which keeps failing
+			relay.Status = StateSyncFailed
+			relay.NextAttempt = time.Time{}
+		} else {
+			relay.NextAttempt = time.Now().Add(common.RetryBackoff(relay.Attempts, minRetryBackoff, maxRetryBackoff))
+		}
+
+		r.logger.Error("Failed to execute state sync", "ID", relay.ID, "attempts", relay.Attempts,
+			"status", relay.Status, "err", err)
+	} else {
+		relay.Status = StateSyncExecuted
+		relay.LastError = ""
+		relay.NextAttempt = time.Time{}
+
+		r.logger.Info("State sync executed", "ID", relay.ID)
+	}
+
+	if err := r.store.update(relay); err != nil {
+		r.logger.Error("Failed to update state sync", "ID", relay.ID, "err", err)
 	}
 }
 
@@ -1,8 +1,14 @@
 package statesyncrelayer
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/txrelayer"
@@ -74,56 +80,148 @@ func Test_executeStateSync(t *testing.T) {
 	txRelayer.AssertExpectations(t)
 }
 
-// Test sanitizeRPCEndpoint
-func Test_sanitizeRPCEndpoint(t *testing.T) {
+func TestStateSyncRelayer_Stop(t *testing.T) {
 	t.Parallel()
 
-	tests := []struct {
-		name     string
-		endpoint string
-		want     string
-	}{
-		{
-			"url with port",
-			"http://localhost:10001",
-			"http://localhost:10001",
-		},
-		{
-			"all interfaces with port without schema",
-			"0.0.0.0:10001",
-			"http://127.0.0.1:10001",
-		},
-		{
-			"url without port",
-			"http://127.0.0.1",
-			"http://127.0.0.1",
-		},
-		{
-			"empty endpoint",
-			"",
-			txrelayer.DefaultRPCAddress,
-		},
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	r, err := NewRelayer(t.TempDir(), txrelayer.DefaultRPCAddress, nil, ethgo.Address(contracts.StateReceiverContract), 0, hclog.NewNullLogger(), key)
+	require.NoError(t, err)
+
+	require.NotPanics(t, func() { r.Stop() })
+}
+
+func TestStateSyncRelayer_RetryFailedStateSyncs(t *testing.T) {
+	t.Parallel()
+
+	// JSON-RPC server serving the state sync proofs
+	proofServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":{"Data":[],"Metadata":{"StateSync":`+
+			`{"ID":1,"Sender":"0x0000000000000000000000000000000000000000",`+
+			`"Receiver":"0x0000000000000000000000000000000000000000","Data":""}}}}`)
+	}))
+	t.Cleanup(proofServer.Close)
+
+	client, err := txrelayer.NewClient(proofServer.URL, nil)
+	require.NoError(t, err)
+
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	txRelayer := &txRelayerMock{}
+	txRelayer.On("SendTransaction", mock.Anything, mock.Anything).
+		Return((*ethgo.Receipt)(nil), errors.New("nonce too low")).Once()
+	txRelayer.On("SendTransaction", mock.Anything, mock.Anything).
+		Return(&ethgo.Receipt{Status: uint64(types.ReceiptSuccess)}, nil).Once()
+
+	r := &StateSyncRelayer{
+		logger:    hclog.NewNullLogger(),
+		client:    client,
+		txRelayer: txRelayer,
+		key:       key,
+		store:     newTestStateSyncStore(t),
+		closeCh:   make(chan struct{}),
 	}
 
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
+	require.NoError(t, r.store.add(1, 1, 10))
+
+	// the failed state sync is scheduled for a retry
+	wait := r.executePending()
+	require.LessOrEqual(t, wait, minRetryBackoff)
+
+	relay, err := r.StateSync(1)
+	require.NoError(t, err)
+	require.Equal(t, StateSyncPending, relay.Status)
+	require.Equal(t, uint64(1), relay.Attempts)
+	require.Contains(t, relay.LastError, "nonce too low")
+	require.True(t, relay.NextAttempt.After(time.Now()))
+
+	// the state sync is not retried before the backoff elapses
+	r.executePending()
+	txRelayer.AssertNumberOfCalls(t, "SendTransaction", 1)
+
+	relay.NextAttempt = time.Now().Add(-time.Second)
+	require.NoError(t, r.store.update(relay))
+
+	require.Equal(t, maxRetryBackoff, r.executePending())
+
+	relay, err = r.StateSync(1)
+	require.NoError(t, err)
+	require.Equal(t, StateSyncExecuted, relay.Status)
+	require.Empty(t, relay.LastError)
+
+	pending, err := r.PendingStateSyncs()
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	txRelayer.AssertExpectations(t)
+}
+
+func TestStateSyncRelayer_FailAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	// JSON-RPC server failing to serve the state sync proofs
+	proofServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"state sync not committed"}}`)
+	}))
+	t.Cleanup(proofServer.Close)
+
+	client, err := txrelayer.NewClient(proofServer.URL, nil)
+	require.NoError(t, err)
 
-			if got := sanitizeRPCEndpoint(tt.endpoint); got != tt.want {
-				t.Errorf("sanitizeRPCEndpoint() = %v, want %v", got, tt.want)
-			}
-		})
+	r := &StateSyncRelayer{
+		logger:  hclog.NewNullLogger(),
+		client:  client,
+		store:   newTestStateSyncStore(t),
+		closeCh: make(chan struct{}),
 	}
+
+	require.NoError(t, r.store.add(1, 1, 10))
+	require.NoError(t, r.store.update(&StateSyncRelay{
+		ID:          1,
+		BlockNumber: 10,
+		Status:      StateSyncPending,
+		Attempts:    maxExecutionAttempts - 1,
+	}))
+
+	// the last attempt fails as well
+	require.Equal(t, maxRetryBackoff, r.executePending())
+
+	relay, err := r.StateSync(1)
+	require.NoError(t, err)
+	require.Equal(t, StateSyncFailed, relay.Status)
+	require.Equal(t, uint64(maxExecutionAttempts), relay.Attempts)
+	require.Contains(t, relay.LastError, "state sync not committed")
+	require.True(t, relay.NextAttempt.IsZero())
+
+	// the failed state sync is not retried anymore
+	pending, err := r.PendingStateSyncs()
+	require.NoError(t, err)
+	require.Empty(t, pending)
 }
 
-func TestStateSyncRelayer_Stop(t *testing.T) {
+func TestRequeueFailedStateSyncs(t *testing.T) {
 	t.Parallel()
 
-	key, err := wallet.GenerateKey()
+	dataDir := t.TempDir()
+
+	_, err := RequeueFailedStateSyncs(dataDir, nil)
+	require.ErrorContains(t, err, "does not exist")
+
+	store, err := newStateSyncStore(path.Join(dataDir, storeFileName), nil)
 	require.NoError(t, err)
 
-	r := NewRelayer("test-chain-1", txrelayer.DefaultRPCAddress, ethgo.Address(contracts.StateReceiverContract), 0, hclog.NewNullLogger(), key)
+	require.NoError(t, store.add(1, 1, 10))
+	require.NoError(t, store.update(&StateSyncRelay{ID: 1, BlockNumber: 10, Status: StateSyncFailed}))
 
-	require.NotPanics(t, func() { r.Stop() })
+	// the store is held by the running node
+	_, err = RequeueFailedStateSyncs(dataDir, nil)
+	require.ErrorIs(t, err, ErrNodeRunning)
+
+	require.NoError(t, store.close())
+
+	requeued, err := RequeueFailedStateSyncs(dataDir, nil)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1}, requeued)
 }
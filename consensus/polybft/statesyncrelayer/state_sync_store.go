@@ -0,0 +1,312 @@
+package statesyncrelayer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// bucket to store the state syncs tracked by the relayer
+	stateSyncsBucket = []byte("stateSyncs")
+	// bucket to index the ids of the state syncs awaiting execution
+	pendingStateSyncsBucket = []byte("pendingStateSyncs")
+
+	// ErrStateSyncNotFound is returned when the state sync is not tracked by the relayer
+	ErrStateSyncNotFound = errors.New("state sync not found")
+	// ErrStateSyncNotFailed is returned when a state sync which has not failed is requeued
+	ErrStateSyncNotFailed = errors.New("state sync has not failed")
+)
+
+/*
+Bolt DB schema:
+
+state syncs/
+|--> stateSync.ID -> *StateSyncRelay (json marshalled)
+
+pending state syncs/
+|--> stateSync.ID -> nil
+*/
+
+// StateSyncStatus is the execution status of a state sync
+type StateSyncStatus string
+
+const (
+	// StateSyncPending is the status of a state sync awaiting execution
+	StateSyncPending StateSyncStatus = "pending"
+	// StateSyncExecuted is the status of a state sync executed on the child chain
+	StateSyncExecuted StateSyncStatus = "executed"
+	// StateSyncFailed is the status of a state sync which failed to execute in all the attempts,
+	// it is not retried anymore
+	StateSyncFailed StateSyncStatus = "failed"
+)
+
+// StateSyncRelay keeps track of the execution of a state sync on the child chain
+type StateSyncRelay struct {
+	// ID is the id of the state sync
+	ID uint64
+	// BlockNumber is the child chain block in which the commitment of the state sync was submitted
+	BlockNumber uint64
+	// Status is the execution status of the state sync
+	Status StateSyncStatus
+	// Attempts is the number of failed execution attempts
+	Attempts uint64
+	// LastError is the error of the last failed execution attempt
+	LastError string
+	// NextAttempt is the time of the next execution attempt of a pending state sync
+	NextAttempt time.Time
+}
+
+// stateSyncStore is the persistent queue of the state syncs executed by the relayer
+type stateSyncStore struct {
+	db *bolt.DB
+}
+
+// newStateSyncStore opens the store at the given path, creating it if it doesn't exist
+func newStateSyncStore(path string, options *bolt.Options) (*stateSyncStore, error) {
+	db, err := bolt.Open(path, 0666, options)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{stateSyncsBucket, pendingStateSyncsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return fmt.Errorf("failed to create bucket=%s: %w", string(bucket), err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		db.Close()
+
+		return nil, err
+	}
+
+	return &stateSyncStore{db: db}, nil
+}
+
+// close closes the store
+func (s *stateSyncStore) close() error {
+	return s.db.Close()
+}
+
+// add inserts the state syncs of the given id range as pending,
+// the state syncs already tracked are left intact
+func (s *stateSyncStore) add(startID, endID, blockNumber uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(stateSyncsBucket)
+		pendingBucket := tx.Bucket(pendingStateSyncsBucket)
+
+		for id := startID; id <= endID; id++ {
+			key := common.EncodeUint64ToBytes(id)
+			if bucket.Get(key) != nil {
+				continue
+			}
+
+			raw, err := json.Marshal(&StateSyncRelay{
+				ID:          id,
+				BlockNumber: blockNumber,
+				Status:      StateSyncPending,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := bucket.Put(key, raw); err != nil {
+				return err
+			}
+
+			if err := pendingBucket.Put(key, nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// update overwrites the tracked state sync,
+// removing it from the pending ones if it is not pending anymore
+func (s *stateSyncStore) update(relay *StateSyncRelay) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		raw, err := json.Marshal(relay)
+		if err != nil {
+			return err
+		}
+
+		key := common.EncodeUint64ToBytes(relay.ID)
+
+		if err := tx.Bucket(stateSyncsBucket).Put(key, raw); err != nil {
+			return err
+		}
+
+		if relay.Status == StateSyncPending {
+			return tx.Bucket(pendingStateSyncsBucket).Put(key, nil)
+		}
+
+		return tx.Bucket(pendingStateSyncsBucket).Delete(key)
+	})
+}
+
+// get returns the tracked state sync by its id
+func (s *stateSyncStore) get(id uint64) (*StateSyncRelay, error) {
+	var relay *StateSyncRelay
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(stateSyncsBucket).Get(common.EncodeUint64ToBytes(id))
+		if raw == nil {
+			return ErrStateSyncNotFound
+		}
+
+		return json.Unmarshal(raw, &relay)
+	})
+
+	return relay, err
+}
+
+// pending returns the state syncs awaiting execution, sorted by id
+func (s *stateSyncStore) pending() ([]*StateSyncRelay, error) {
+	var relays []*StateSyncRelay
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(stateSyncsBucket)
+
+		return tx.Bucket(pendingStateSyncsBucket).ForEach(func(k, _ []byte) error {
+			raw := bucket.Get(k)
+			if raw == nil {
+				return fmt.Errorf("pending state sync %d is not tracked", common.EncodeBytesToUint64(k))
+			}
+
+			var relay *StateSyncRelay
+			if err := json.Unmarshal(raw, &relay); err != nil {
+				return err
+			}
+
+			relays = append(relays, relay)
+
+			return nil
+		})
+	})
+
+	return relays, err
+}
+
+// requeue marks the failed state syncs with the given ids (all the failed ones if no id is given) as pending,
+// so that their execution is attempted again maxExecutionAttempts times. Returns the ids of the requeued state syncs
+func (s *stateSyncStore) requeue(ids ...uint64) ([]uint64, error) {
+	var requeued []uint64
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(stateSyncsBucket)
+
+		var relays []*StateSyncRelay
+
+		if len(ids) == 0 {
+			err := bucket.ForEach(func(_, v []byte) error {
+				var relay *StateSyncRelay
+				if err := json.Unmarshal(v, &relay); err != nil {
+					return err
+				}
+
+				if relay.Status == StateSyncFailed {
+					relays = append(relays, relay)
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, id := range ids {
+			raw := bucket.Get(common.EncodeUint64ToBytes(id))
+			if raw == nil {
+				return fmt.Errorf("%w: %d", ErrStateSyncNotFound, id)
+			}
+
+			var relay *StateSyncRelay
+			if err := json.Unmarshal(raw, &relay); err != nil {
+				return err
+			}
+
+			if relay.Status != StateSyncFailed {
+				return fmt.Errorf("%w: %d is %s", ErrStateSyncNotFailed, id, relay.Status)
+			}
+
+			relays = append(relays, relay)
+		}
+
+		for _, relay := range relays {
+			relay.Status = StateSyncPending
+			relay.Attempts = 0
+			relay.NextAttempt = time.Time{}
+
+			raw, err := json.Marshal(relay)
+			if err != nil {
+				return err
+			}
+
+			key := common.EncodeUint64ToBytes(relay.ID)
+
+			if err := bucket.Put(key, raw); err != nil {
+				return err
+			}
+
+			if err := tx.Bucket(pendingStateSyncsBucket).Put(key, nil); err != nil {
+				return err
+			}
+
+			requeued = append(requeued, relay.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return requeued, nil
+}
+
+// pruneExecuted removes the executed state syncs, except for the given number of the latest tracked ones.
+// The pending and the failed state syncs are kept
+func (s *stateSyncStore) pruneExecuted(keep uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(stateSyncsBucket).Cursor()
+
+		lastKey, _ := cursor.Last()
+		if lastKey == nil || common.EncodeBytesToUint64(lastKey) < keep {
+			return nil
+		}
+
+		pruneUntil := common.EncodeBytesToUint64(lastKey) - keep
+
+		var keys [][]byte
+
+		for k, v := cursor.First(); k != nil && common.EncodeBytesToUint64(k) <= pruneUntil; k, v = cursor.Next() {
+			var relay *StateSyncRelay
+			if err := json.Unmarshal(v, &relay); err != nil {
+				return err
+			}
+
+			if relay.Status == StateSyncExecuted {
+				keys = append(keys, append([]byte(nil), k...))
+			}
+		}
+
+		for _, k := range keys {
+			if err := tx.Bucket(stateSyncsBucket).Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,155 @@
+package statesyncrelayer
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStateSyncStore(t *testing.T) *stateSyncStore {
+	t.Helper()
+
+	store, err := newStateSyncStore(path.Join(t.TempDir(), storeFileName), nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, store.close())
+	})
+
+	return store
+}
+
+func TestStateSyncStore_AddAndUpdate(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStateSyncStore(t)
+
+	require.NoError(t, store.add(1, 3, 10))
+
+	pending, err := store.pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 3)
+
+	for i, relay := range pending {
+		require.Equal(t, uint64(i+1), relay.ID)
+		require.Equal(t, uint64(10), relay.BlockNumber)
+		require.Equal(t, StateSyncPending, relay.Status)
+	}
+
+	// the executed state syncs are not pending anymore
+	pending[1].Status = StateSyncExecuted
+	require.NoError(t, store.update(pending[1]))
+
+	// the failed attempts are kept
+	pending[2].Attempts = 1
+	pending[2].LastError = "execution reverted"
+	pending[2].NextAttempt = time.Unix(1_000_000, 0)
+	require.NoError(t, store.update(pending[2]))
+
+	// the state syncs already tracked are not reset
+	require.NoError(t, store.add(2, 4, 11))
+
+	pending, err = store.pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 3)
+	require.Equal(t, []uint64{1, 3, 4}, []uint64{pending[0].ID, pending[1].ID, pending[2].ID})
+	require.Equal(t, uint64(1), pending[1].Attempts)
+	require.Equal(t, "execution reverted", pending[1].LastError)
+	require.True(t, pending[1].NextAttempt.Equal(time.Unix(1_000_000, 0)))
+	require.Equal(t, uint64(11), pending[2].BlockNumber)
+
+	relay, err := store.get(2)
+	require.NoError(t, err)
+	require.Equal(t, StateSyncExecuted, relay.Status)
+
+	_, err = store.get(5)
+	require.ErrorIs(t, err, ErrStateSyncNotFound)
+}
+
+func TestStateSyncStore_PruneExecuted(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStateSyncStore(t)
+
+	require.NoError(t, store.add(1, 6, 10))
+
+	for _, id := range []uint64{1, 2, 3, 5} {
+		relay, err := store.get(id)
+		require.NoError(t, err)
+
+		relay.Status = StateSyncExecuted
+		require.NoError(t, store.update(relay))
+	}
+
+	relay, err := store.get(4)
+	require.NoError(t, err)
+
+	relay.Status = StateSyncFailed
+	require.NoError(t, store.update(relay))
+
+	// only the state sync 6 is left pending
+	pending, err := store.pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, uint64(6), pending[0].ID)
+
+	// the executed state syncs older than the two latest ones are pruned
+	require.NoError(t, store.pruneExecuted(2))
+
+	for _, id := range []uint64{1, 2, 3} {
+		_, err := store.get(id)
+		require.ErrorIs(t, err, ErrStateSyncNotFound)
+	}
+
+	// the failed, the pending and the latest executed state syncs are kept
+	for _, id := range []uint64{4, 5, 6} {
+		_, err := store.get(id)
+		require.NoError(t, err)
+	}
+}
+
+func TestStateSyncStore_Requeue(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStateSyncStore(t)
+
+	require.NoError(t, store.add(1, 4, 10))
+
+	for _, id := range []uint64{1, 2, 3} {
+		relay, err := store.get(id)
+		require.NoError(t, err)
+
+		relay.Status = StateSyncFailed
+		relay.Attempts = maxExecutionAttempts
+		relay.LastError = "execution reverted"
+		require.NoError(t, store.update(relay))
+	}
+
+	// only the failed state syncs are requeued
+	_, err := store.requeue(1, 4)
+	require.ErrorIs(t, err, ErrStateSyncNotFailed)
+
+	_, err = store.requeue(5)
+	require.ErrorIs(t, err, ErrStateSyncNotFound)
+
+	requeued, err := store.requeue(2)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2}, requeued)
+
+	relay, err := store.get(2)
+	require.NoError(t, err)
+	require.Equal(t, StateSyncPending, relay.Status)
+	require.Zero(t, relay.Attempts)
+	require.Equal(t, "execution reverted", relay.LastError)
+
+	// the rest of the failed state syncs are requeued if no id is given
+	requeued, err = store.requeue()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 3}, requeued)
+
+	pending, err := store.pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 4)
+}
@@ -5,6 +5,7 @@ import (
 
 	ibftProto "github.com/0xPolygon/go-ibft/messages/proto"
 	polybftProto "github.com/0xPolygon/polygon-edge/consensus/polybft/proto"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
@@ -16,7 +17,7 @@ type BridgeTransport interface {
 
 // subscribeToIbftTopic subscribes to ibft topic
 func (p *Polybft) subscribeToIbftTopic() error {
-	return p.consensusTopic.Subscribe(func(obj interface{}, _ peer.ID) {
+	return p.consensusTopic.Subscribe(func(obj interface{}, from peer.ID) {
 		if !p.runtime.isActiveValidator() {
 			return
 		}
@@ -28,6 +29,13 @@ func (p *Polybft) subscribeToIbftTopic() error {
 			return
 		}
 
+		if msg.View == nil || len(msg.From) == 0 || len(msg.Signature) == 0 {
+			p.logger.Debug("consensus engine: malformed message received", "peer", from)
+			p.config.Network.ReportPeer(from, network.PenaltyInvalidConsensusMessage, "malformed consensus message")
+
+			return
+		}
+
 		p.ibft.AddMessage(msg)
 
 		p.logger.Debug(
@@ -40,7 +40,8 @@ func NewSigner(forks chain.ForksInTime, chainID uint64) TxSigner {
 
 	// London signer requires a fallback signer that is defined above.
 	// This is the reason why the london signer check is separated.
-	if forks.London {
+	// It also handles the access list transactions introduced in berlin.
+	if forks.London || forks.Berlin {
 		return NewLondonSigner(chainID, forks.Homestead, signer)
 	}
 
@@ -65,10 +66,11 @@ func encodeSignature(R, S, V *big.Int, isHomestead bool) ([]byte, error) {
 func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 	a := signerPool.Get()
 	isDynamicFeeTx := tx.Type == types.DynamicFeeTx
+	isTypedTx := tx.Type.HasAccessList()
 
 	v := a.NewArray()
 
-	if isDynamicFeeTx {
+	if isTypedTx {
 		v.Set(a.NewUint(chainID))
 	}
 
@@ -93,8 +95,8 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 
 	v.Set(a.NewCopyBytes(tx.Input))
 
-	if isDynamicFeeTx {
-		v.Set(a.NewArray())
+	if isTypedTx {
+		v.Set(tx.AccessList.MarshalRLPWith(a))
 	} else {
 		// EIP155
 		if chainID != 0 {
@@ -105,7 +107,7 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 	}
 
 	var hash []byte
-	if isDynamicFeeTx {
+	if isTypedTx {
 		hash = keccak.PrefixedKeccak256Rlp([]byte{byte(tx.Type)}, nil, v)
 	} else {
 		hash = keccak.Keccak256Rlp(nil, v)
@@ -7,7 +7,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
-// LondonSigner implements signer for EIP-1559
+// LondonSigner implements signer for EIP-1559 and EIP-2930 transactions
 type LondonSigner struct {
 	chainID        uint64
 	isHomestead    bool
@@ -30,8 +30,8 @@ func (e *LondonSigner) Hash(tx *types.Transaction) types.Hash {
 
 // Sender returns the transaction sender
 func (e *LondonSigner) Sender(tx *types.Transaction) (types.Address, error) {
-	// Apply fallback signer for non-dynamic-fee-txs
-	if tx.Type != types.DynamicFeeTx {
+	// Apply fallback signer for non-typed txs
+	if !tx.Type.HasAccessList() {
 		return e.fallbackSigner.Sender(tx)
 	}
 
@@ -52,8 +52,8 @@ func (e *LondonSigner) Sender(tx *types.Transaction) (types.Address, error) {
 
 // SignTx signs the transaction using the passed in private key
 func (e *LondonSigner) SignTx(tx *types.Transaction, pk *ecdsa.PrivateKey) (*types.Transaction, error) {
-	// Apply fallback signer for non-dynamic-fee-txs
-	if tx.Type != types.DynamicFeeTx {
+	// Apply fallback signer for non-typed txs
+	if !tx.Type.HasAccessList() {
 		return e.fallbackSigner.SignTx(tx, pk)
 	}
 
@@ -143,3 +143,43 @@ func Test_LondonSigner_Sender(t *testing.T) {
 		})
 	}
 }
+
+func Test_LondonSigner_AccessListTx(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateECDSAKey()
+	require.NoError(t, err)
+
+	to := types.StringToAddress("1")
+	signer := NewLondonSigner(100, true, NewEIP155Signer(100, true))
+
+	txn := &types.Transaction{
+		Type:     types.AccessListTx,
+		To:       &to,
+		Value:    big.NewInt(1),
+		GasPrice: big.NewInt(1),
+		Gas:      30000,
+		AccessList: types.TxAccessList{
+			{
+				Address:     types.StringToAddress("2"),
+				StorageKeys: []types.Hash{types.StringToHash("3")},
+			},
+		},
+	}
+
+	signedTx, err := signer.SignTx(txn, key)
+	require.NoError(t, err)
+
+	sender, err := signer.Sender(signedTx)
+	require.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), sender)
+
+	// the access list is part of the signed payload
+	tamperedTx := signedTx.Copy()
+	tamperedTx.AccessList[0].StorageKeys[0] = types.StringToHash("4")
+
+	tamperedSender, err := signer.Sender(tamperedTx)
+	if err == nil {
+		assert.NotEqual(t, sender, tamperedSender)
+	}
+}
@@ -43,6 +43,31 @@ func RetryForever(ctx context.Context, interval time.Duration, fn func(context.C
 	})
 }
 
+// RetryBackoff returns the delay before the given attempt of a failing operation,
+// starting from min and doubling with each failed attempt up to max
+func RetryBackoff(attempts uint64, min, max time.Duration) time.Duration {
+	backoff := min
+
+	for i := uint64(1); i < attempts && backoff < max; i++ {
+		backoff *= 2
+	}
+
+	if backoff > max {
+		return max
+	}
+
+	return backoff
+}
+
+// MinDuration returns the shorter duration
+func MinDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
 // Min returns the strictly lower number
 func Min(a, b uint64) uint64 {
 	if a < b {
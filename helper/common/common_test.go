@@ -132,3 +132,16 @@ func TestRetryForever_ReturnNilAfterFirstRun_ShouldEnd(t *testing.T) {
 	<-ctx.Done()
 	require.True(t, errors.Is(ctx.Err(), context.Canceled))
 }
+
+func TestRetryBackoff(t *testing.T) {
+	t.Parallel()
+
+	const (
+		min = 10 * time.Second
+		max = 30 * time.Minute
+	)
+
+	require.Equal(t, min, RetryBackoff(1, min, max))
+	require.Equal(t, 4*min, RetryBackoff(3, min, max))
+	require.Equal(t, max, RetryBackoff(100, min, max))
+}
@@ -4,6 +4,7 @@
 package ipc
 
 import (
+	"errors"
 	"net"
 	"os"
 	"path/filepath"
@@ -28,7 +29,8 @@ func Listen(path string) (net.Listener, error) {
 		return nil, err
 	}
 
-	if removeErr := os.Remove(path); removeErr != nil {
+	// remove the socket left behind by a previous run
+	if removeErr := os.Remove(path); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) {
 		return nil, removeErr
 	}
 
@@ -0,0 +1,158 @@
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+)
+
+// SecretLength is the length of the JWT secret (in bytes)
+const SecretLength = 32
+
+// maxIssuedAtSkew is the maximum allowed difference between the issuing time of a token and the current time
+const maxIssuedAtSkew = 60 * time.Second
+
+var (
+	ErrMalformedToken   = errors.New("malformed token")
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrTokenExpired     = errors.New("token expired")
+	ErrTokenNotValidYet = errors.New("token not valid yet")
+	ErrInvalidSecret    = fmt.Errorf("secret must be %d hex encoded bytes", SecretLength)
+)
+
+// header is the header of the HMAC-SHA256 signed tokens
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+type claims struct {
+	IssuedAt  int64 `json:"iat"`
+	ExpiresAt int64 `json:"exp,omitempty"`
+}
+
+// ReadSecret reads the hex encoded secret from the given file
+func ReadSecret(path string) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT secret: %w", err)
+	}
+
+	secret, err := hex.DecodeHex(strings.TrimSpace(string(raw)))
+	if err != nil || len(secret) != SecretLength {
+		return nil, ErrInvalidSecret
+	}
+
+	return secret, nil
+}
+
+// LoadOrCreateSecret reads the secret from the given file,
+// a new random secret is generated and written to the file if it doesn't exist
+func LoadOrCreateSecret(path string) ([]byte, bool, error) {
+	if _, err := os.Stat(path); err == nil || !errors.Is(err, os.ErrNotExist) {
+		secret, err := ReadSecret(path)
+
+		return secret, false, err
+	}
+
+	secret := make([]byte, SecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, false, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, false, err
+	}
+
+	if err := os.WriteFile(path, []byte(hex.EncodeToHex(secret)), 0600); err != nil {
+		return nil, false, fmt.Errorf("failed to write JWT secret: %w", err)
+	}
+
+	return secret, true, nil
+}
+
+// NewToken creates a token signed with the secret, issued at the given time.
+// The token expires after the given duration, if it is not zero
+func NewToken(secret []byte, issuedAt time.Time, expiresIn time.Duration) (string, error) {
+	c := claims{IssuedAt: issuedAt.Unix()}
+	if expiresIn > 0 {
+		c.ExpiresAt = issuedAt.Add(expiresIn).Unix()
+	}
+
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(raw)
+
+	return unsigned + "." + sign(secret, unsigned), nil
+}
+
+// Verify checks that the token is signed with the secret and that it is valid at the given time.
+// The tokens are valid only if they were issued at most a minute apart from the given time,
+// so that a leaked token can not be replayed later on, even if it has a far future expiration time.
+// The tokens with an expiration time are additionally valid only until they expire
+func Verify(token string, secret []byte, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ErrMalformedToken
+	}
+
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return ErrMalformedToken
+	}
+
+	var h struct {
+		Alg string `json:"alg"`
+	}
+
+	if err := json.Unmarshal(rawHeader, &h); err != nil || h.Alg != "HS256" {
+		return ErrMalformedToken
+	}
+
+	if !hmac.Equal([]byte(sign(secret, parts[0]+"."+parts[1])), []byte(parts[2])) {
+		return ErrInvalidSignature
+	}
+
+	rawClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ErrMalformedToken
+	}
+
+	var c claims
+	if err := json.Unmarshal(rawClaims, &c); err != nil {
+		return ErrMalformedToken
+	}
+
+	issuedAt := time.Unix(c.IssuedAt, 0)
+
+	if issuedAt.Sub(now) > maxIssuedAtSkew {
+		return ErrTokenNotValidYet
+	}
+
+	if now.Sub(issuedAt) > maxIssuedAtSkew {
+		return ErrTokenExpired
+	}
+
+	if c.ExpiresAt != 0 && !now.Before(time.Unix(c.ExpiresAt, 0)) {
+		return ErrTokenExpired
+	}
+
+	return nil
+}
+
+func sign(secret []byte, unsigned string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
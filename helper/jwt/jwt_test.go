@@ -0,0 +1,75 @@
+package jwt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	var (
+		secret      = []byte("0123456789abcdef0123456789abcdef")
+		otherSecret = []byte("fedcba9876543210fedcba9876543210")
+		now         = time.Unix(1_700_000_000, 0)
+	)
+
+	newToken := func(t *testing.T, secret []byte, issuedAt time.Time, expiresIn time.Duration) string {
+		t.Helper()
+
+		token, err := NewToken(secret, issuedAt, expiresIn)
+		require.NoError(t, err)
+
+		return token
+	}
+
+	cases := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"fresh token", newToken(t, secret, now.Add(-30*time.Second), 0), nil},
+		{"stale token", newToken(t, secret, now.Add(-2*time.Minute), 0), ErrTokenExpired},
+		{"token from the future", newToken(t, secret, now.Add(2*time.Minute), 0), ErrTokenNotValidYet},
+		{"unexpired token", newToken(t, secret, now.Add(-30*time.Second), time.Minute), nil},
+		{"expired token", newToken(t, secret, now.Add(-30*time.Second), 10*time.Second), ErrTokenExpired},
+		{"stale token with far future expiration", newToken(t, secret, now.Add(-time.Hour), 24*time.Hour), ErrTokenExpired},
+		{"different secret", newToken(t, otherSecret, now, 0), ErrInvalidSignature},
+		{"malformed token", "abc.def", ErrMalformedToken},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(t, Verify(c.token, secret, now), c.err)
+		})
+	}
+}
+
+func TestLoadOrCreateSecret(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "jwt.hex")
+
+	secret, created, err := LoadOrCreateSecret(path)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Len(t, secret, SecretLength)
+
+	loaded, created, err := LoadOrCreateSecret(path)
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, secret, loaded)
+
+	require.NoError(t, os.WriteFile(path, []byte("0x1234"), 0600))
+
+	_, err = ReadSecret(path)
+	assert.ErrorIs(t, err, ErrInvalidSecret)
+}
@@ -0,0 +1,160 @@
+package tlsconfig
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	ErrMissingCertificate = errors.New("both the certificate and its key must be set")
+	ErrInvalidCAFile      = errors.New("no valid CA certificates found")
+
+	errUnexpectedCertificate = errors.New("unexpected server certificate")
+)
+
+// NewServerConfig creates the TLS config of a server presenting the given certificate.
+// If the client CA file is set, the clients are required to present
+// a certificate signed by one of its CAs (mutual TLS), or the server certificate itself,
+// which is presented by the clients running in the same process as the server (see NewPinnedClientConfig)
+func NewServerConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, ErrMissingCertificate
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the server certificate: %w", err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		clientCAs, err := loadCertPool(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.ClientAuth = tls.RequireAnyClientCert
+		config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyClientCertificate(rawCerts, clientCAs, cert.Certificate[0])
+		}
+	}
+
+	return config, nil
+}
+
+// verifyClientCertificate verifies that the client certificate is signed by one of the client CAs,
+// using the rest of the certificates as intermediates, or that it is the server certificate itself
+func verifyClientCertificate(rawCerts [][]byte, clientCAs *x509.CertPool, serverCert []byte) error {
+	if len(rawCerts) == 0 {
+		return errors.New("missing client certificate")
+	}
+
+	if bytes.Equal(rawCerts[0], serverCert) {
+		return nil
+	}
+
+	certs := make([]*x509.Certificate, len(rawCerts))
+
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse the client certificate: %w", err)
+		}
+
+		certs[i] = cert
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         clientCAs,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(opts)
+
+	return err
+}
+
+// NewClientConfig creates the TLS config of a client. The server certificate is verified
+// against the CAs in the given file (or the system ones if not set). If the client certificate
+// is set, it is presented to the servers requiring mutual TLS
+func NewClientConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if caFile != "" {
+		var err error
+
+		if config.RootCAs, err = loadCertPool(caFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, ErrMissingCertificate
+		}
+
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// NewPinnedClientConfig creates the TLS config of a client running in the same process as the server
+// presenting the given certificate. Only that certificate is trusted, whatever its issuer and names,
+// and it is presented to the server as the client certificate as well, in case it requires mutual TLS
+func NewPinnedClientConfig(certFile, keyFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the server certificate: %w", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		// the chain and the names of the server certificate are not verified,
+		// since the certificate is compared to the pinned one instead
+		InsecureSkipVerify: true, //nolint:gosec
+		VerifyConnection: func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 || !bytes.Equal(state.PeerCertificates[0].Raw, cert.Certificate[0]) {
+				return errUnexpectedCertificate
+			}
+
+			return nil
+		},
+	}, nil
+}
+
+// loadCertPool loads the PEM encoded CA certificates from the given file
+func loadCertPool(path string) (*x509.CertPool, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CA certificates: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(raw) {
+		return nil, fmt.Errorf("%w in %s", ErrInvalidCAFile, path)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,169 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certFile string
+	keyFile  string
+}
+
+// newTestCert creates a certificate signed by the parent (self-signed if the parent is nil)
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signerCert, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+
+	raw, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+
+	rawKey, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	c := &testCert{
+		cert:     cert,
+		key:      key,
+		certFile: filepath.Join(dir, name+".crt"),
+		keyFile:  filepath.Join(dir, name+".key"),
+	}
+
+	require.NoError(t, os.WriteFile(c.certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw}), 0600))
+	require.NoError(t, os.WriteFile(c.keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: rawKey}), 0600))
+
+	return c
+}
+
+func TestMutualTLS(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ca        = newTestCert(t, "ca", nil)
+		server    = newTestCert(t, "server", ca)
+		client    = newTestCert(t, "client", ca)
+		otherCA   = newTestCert(t, "other-ca", nil)
+		untrusted = newTestCert(t, "untrusted", otherCA)
+	)
+
+	serverConfig, err := NewServerConfig(server.certFile, server.keyFile, ca.certFile)
+	require.NoError(t, err)
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	require.NoError(t, err)
+
+	defer lis.Close()
+
+	// handshakeWith returns the handshake error of either the client or the server
+	handshakeWith := func(t *testing.T, clientConfig *tls.Config) error {
+		t.Helper()
+
+		serverErrCh := make(chan error, 1)
+
+		go func() {
+			conn, err := lis.Accept()
+			if err != nil {
+				serverErrCh <- err
+
+				return
+			}
+
+			defer conn.Close()
+
+			tlsConn, _ := conn.(*tls.Conn)
+			serverErrCh <- tlsConn.Handshake()
+		}()
+
+		conn, err := tls.Dial("tcp", lis.Addr().String(), clientConfig)
+		if err == nil {
+			conn.Close()
+		}
+
+		if serverErr := <-serverErrCh; serverErr != nil {
+			return serverErr
+		}
+
+		return err
+	}
+
+	handshake := func(t *testing.T, caFile, certFile, keyFile string) error {
+		t.Helper()
+
+		clientConfig, err := NewClientConfig(caFile, certFile, keyFile)
+		require.NoError(t, err)
+
+		return handshakeWith(t, clientConfig)
+	}
+
+	// the clients in the same process as the server pin and present the server certificate
+	handshakePinned := func(t *testing.T, certFile, keyFile string) error {
+		t.Helper()
+
+		clientConfig, err := NewPinnedClientConfig(certFile, keyFile)
+		require.NoError(t, err)
+
+		return handshakeWith(t, clientConfig)
+	}
+
+	assert.NoError(t, handshake(t, ca.certFile, client.certFile, client.keyFile))
+	assert.Error(t, handshake(t, ca.certFile, untrusted.certFile, untrusted.keyFile))
+	assert.Error(t, handshake(t, ca.certFile, "", ""))
+	assert.Error(t, handshake(t, otherCA.certFile, client.certFile, client.keyFile))
+	assert.NoError(t, handshakePinned(t, server.certFile, server.keyFile))
+	assert.Error(t, handshakePinned(t, client.certFile, client.keyFile))
+}
+
+func TestNewClientConfig_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewClientConfig("", "client.crt", "")
+	assert.ErrorIs(t, err, ErrMissingCertificate)
+
+	invalidCA := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(invalidCA, []byte("not a certificate"), 0600))
+
+	_, err = NewClientConfig(invalidCA, "", "")
+	assert.ErrorIs(t, err, ErrInvalidCAFile)
+
+	_, err = NewServerConfig("", "", "")
+	assert.ErrorIs(t, err, ErrMissingCertificate)
+}
@@ -0,0 +1,51 @@
+package jsonrpc
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/jwt"
+)
+
+// jwtAuthMiddleware builds a middleware which rejects the requests
+// without a bearer token signed with the given secret (if the secret is set)
+func jwtAuthMiddleware(secret []byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if len(secret) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// CORS preflight requests never carry the credentials
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			auth := r.Header.Get("Authorization")
+			if !strings.HasPrefix(auth, "Bearer ") {
+				writeUnauthorized(w, "missing bearer token")
+
+				return
+			}
+
+			if err := jwt.Verify(strings.TrimPrefix(auth, "Bearer "), secret, time.Now()); err != nil {
+				writeUnauthorized(w, err.Error())
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeUnauthorized(w http.ResponseWriter, reason string) {
+	resp, _ := NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("unauthorized: "+reason)).Bytes()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	_, _ = w.Write(resp)
+}
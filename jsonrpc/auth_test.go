@@ -0,0 +1,66 @@
+package jsonrpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	secret := make([]byte, jwt.SecretLength)
+	secret[0] = 1
+
+	validToken, err := jwt.NewToken(secret, time.Now(), 0)
+	require.NoError(t, err)
+
+	expiredToken, err := jwt.NewToken(secret, time.Now().Add(-2*time.Hour), time.Hour)
+	require.NoError(t, err)
+
+	otherToken, err := jwt.NewToken(make([]byte, jwt.SecretLength), time.Now(), 0)
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	cases := []struct {
+		name   string
+		secret []byte
+		method string
+		auth   string
+		code   int
+	}{
+		{"no secret", nil, http.MethodPost, "", http.StatusOK},
+		{"missing token", secret, http.MethodPost, "", http.StatusUnauthorized},
+		{"not a bearer token", secret, http.MethodPost, validToken, http.StatusUnauthorized},
+		{"valid token", secret, http.MethodPost, "Bearer " + validToken, http.StatusOK},
+		{"expired token", secret, http.MethodPost, "Bearer " + expiredToken, http.StatusUnauthorized},
+		{"wrong secret", secret, http.MethodPost, "Bearer " + otherToken, http.StatusUnauthorized},
+		{"preflight request", secret, http.MethodOptions, "", http.StatusOK},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(c.method, "/", nil)
+			if c.auth != "" {
+				req.Header.Set("Authorization", c.auth)
+			}
+
+			recorder := httptest.NewRecorder()
+			jwtAuthMiddleware(c.secret)(next).ServeHTTP(recorder, req)
+
+			assert.Equal(t, c.code, recorder.Code)
+		})
+	}
+}
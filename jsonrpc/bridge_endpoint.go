@@ -4,10 +4,83 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// maxExitsPageSize is the maximum number of events returned by a single
+// bridge_getExitsBySender or bridge_getStateSyncsByReceiver call
+const maxExitsPageSize = 100
+
 // bridgeStore interface provides access to the methods needed by bridge endpoint
 type bridgeStore interface {
 	GenerateExitProof(exitID uint64) (types.Proof, error)
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
+	GetStateSyncStatus(stateSyncID uint64) (*StateSyncStatus, error)
+	GetPendingStateSyncs() ([]*StateSyncStatus, error)
+	GetStateSyncsByReceiver(receiver types.Address, fromID uint64, limit int) ([]*types.BridgeEvent, error)
+	GetExitsBySender(sender types.Address, fromID uint64, limit int) ([]*types.BridgeEvent, error)
+	GetBridgeEvent(eventType types.BridgeEventType, id uint64) (*types.BridgeEvent, error)
+}
+
+// StateSyncStatus is the execution status of a state sync relayed by the node
+type StateSyncStatus struct {
+	ID          uint64
+	BlockNumber uint64
+	Status      string
+	Attempts    uint64
+	LastError   string
+	NextAttempt uint64 // unix time of the next execution attempt, zero if none is scheduled
+}
+
+// stateSyncStatusResult is the JSON-RPC representation of StateSyncStatus
+type stateSyncStatusResult struct {
+	ID          argUint64  `json:"id"`
+	BlockNumber argUint64  `json:"blockNumber"`
+	Status      string     `json:"status"`
+	Attempts    argUint64  `json:"attempts"`
+	LastError   string     `json:"lastError,omitempty"`
+	NextAttempt *argUint64 `json:"nextAttempt,omitempty"`
+}
+
+func toStateSyncStatusResult(status *StateSyncStatus) *stateSyncStatusResult {
+	res := &stateSyncStatusResult{
+		ID:          argUint64(status.ID),
+		BlockNumber: argUint64(status.BlockNumber),
+		Status:      status.Status,
+		Attempts:    argUint64(status.Attempts),
+		LastError:   status.LastError,
+	}
+
+	if status.NextAttempt != 0 {
+		res.NextAttempt = argUintPtr(status.NextAttempt)
+	}
+
+	return res
+}
+
+// bridgeEventResult is the JSON-RPC representation of types.BridgeEvent
+type bridgeEventResult struct {
+	Type     types.BridgeEventType   `json:"type"`
+	ID       argUint64               `json:"id"`
+	Sender   types.Address           `json:"sender"`
+	Receiver types.Address           `json:"receiver"`
+	Status   types.BridgeEventStatus `json:"status"`
+}
+
+func toBridgeEventResult(event *types.BridgeEvent) *bridgeEventResult {
+	return &bridgeEventResult{
+		Type:     event.Type,
+		ID:       argUint64(event.ID),
+		Sender:   event.Sender,
+		Receiver: event.Receiver,
+		Status:   event.Status,
+	}
+}
+
+func toBridgeEventResults(events []*types.BridgeEvent) []*bridgeEventResult {
+	res := make([]*bridgeEventResult, len(events))
+	for i, event := range events {
+		res[i] = toBridgeEventResult(event)
+	}
+
+	return res
 }
 
 // Bridge is the bridge jsonrpc endpoint
@@ -24,3 +97,87 @@ func (b *Bridge) GenerateExitProof(exitID argUint64) (interface{}, error) {
 func (b *Bridge) GetStateSyncProof(stateSyncID argUint64) (interface{}, error) {
 	return b.store.GetStateSyncProof(uint64(stateSyncID))
 }
+
+// GetStateSyncStatus returns the execution status of the state sync relayed by the node
+func (b *Bridge) GetStateSyncStatus(stateSyncID argUint64) (interface{}, error) {
+	status, err := b.store.GetStateSyncStatus(uint64(stateSyncID))
+	if err != nil {
+		return nil, err
+	}
+
+	return toStateSyncStatusResult(status), nil
+}
+
+// GetPendingStateSyncs returns the state syncs awaiting execution by the node relayer
+func (b *Bridge) GetPendingStateSyncs() (interface{}, error) {
+	statuses, err := b.store.GetPendingStateSyncs()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*stateSyncStatusResult, len(statuses))
+	for i, status := range statuses {
+		res[i] = toStateSyncStatusResult(status)
+	}
+
+	return res, nil
+}
+
+// GetStateSyncsByReceiver returns the state syncs sent to the given receiver along with their status.
+// The state syncs are returned in pages of at most limit (capped at maxExitsPageSize) state syncs,
+// starting from the given state sync id; the next page starts from the id following the last returned state sync
+func (b *Bridge) GetStateSyncsByReceiver(
+	receiver types.Address, fromID *argUint64, limit *argUint64) (interface{}, error) {
+	from, size := pageBounds(fromID, limit)
+
+	stateSyncs, err := b.store.GetStateSyncsByReceiver(receiver, from, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return toBridgeEventResults(stateSyncs), nil
+}
+
+// GetExitsBySender returns the exits sent by the given sender along with their status.
+// The exits are returned in pages of at most limit (capped at maxExitsPageSize) exits, starting from the given exit id;
+// the next page starts from the id following the last returned exit
+func (b *Bridge) GetExitsBySender(sender types.Address, fromID *argUint64, limit *argUint64) (interface{}, error) {
+	from, size := pageBounds(fromID, limit)
+
+	exits, err := b.store.GetExitsBySender(sender, from, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return toBridgeEventResults(exits), nil
+}
+
+// pageBounds returns the first id and the size of the requested page of the bridge events
+func pageBounds(fromID *argUint64, limit *argUint64) (uint64, int) {
+	from, size := uint64(0), maxExitsPageSize
+
+	if fromID != nil {
+		from = uint64(*fromID)
+	}
+
+	if limit != nil && uint64(*limit) < uint64(size) {
+		size = int(*limit)
+	}
+
+	return from, size
+}
+
+// GetStatus returns the status of the state sync or the exit with the given id.
+// The event type is either "stateSync" or "exit", since the state syncs and the exits are numbered separately
+func (b *Bridge) GetStatus(eventType types.BridgeEventType, id argUint64) (interface{}, error) {
+	event, err := b.store.GetBridgeEvent(eventType, uint64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, nil
+	}
+
+	return toBridgeEventResult(event), nil
+}
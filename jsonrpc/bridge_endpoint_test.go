@@ -30,7 +30,7 @@ func TestBridgeEndpoint(t *testing.T) {
 		"id": 1
 	}`)
 
-	data, err := dispatcher.HandleWs(msg, mockConnection)
+	data, err := dispatcher.HandleWs(msg, mockConnection, "")
 	require.NoError(t, err)
 
 	resp := new(SuccessResponse)
@@ -44,11 +44,141 @@ func TestBridgeEndpoint(t *testing.T) {
 		"id": 1
 	}`)
 
-	data, err = dispatcher.HandleWs(msg, mockConnection)
+	data, err = dispatcher.HandleWs(msg, mockConnection, "")
 	require.NoError(t, err)
 
 	resp = new(SuccessResponse)
 	require.NoError(t, json.Unmarshal(data, resp))
 	require.Nil(t, resp.Error)
 	require.NotNil(t, resp.Result)
+
+	msg = []byte(`{
+		"method": "bridge_getStateSyncStatus",
+		"params": ["0x5"],
+		"id": 1
+	}`)
+
+	data, err = dispatcher.HandleWs(msg, mockConnection, "")
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+	require.JSONEq(t,
+		`{"id":"0x5","blockNumber":"0xa","status":"pending","attempts":"0x2",`+
+			`"lastError":"execution reverted","nextAttempt":"0x6553f100"}`,
+		string(resp.Result),
+	)
+
+	msg = []byte(`{
+		"method": "bridge_getPendingStateSyncs",
+		"params": [],
+		"id": 1
+	}`)
+
+	data, err = dispatcher.HandleWs(msg, mockConnection, "")
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+
+	var pending []*stateSyncStatusResult
+	require.NoError(t, json.Unmarshal(resp.Result, &pending))
+	require.Len(t, pending, 1)
+	require.Equal(t, argUint64(1), pending[0].ID)
+
+	msg = []byte(`{
+		"method": "bridge_getStateSyncsByReceiver",
+		"params": ["0x0000000000000000000000000000000000000002"],
+		"id": 1
+	}`)
+
+	data, err = dispatcher.HandleWs(msg, mockConnection, "")
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+	require.JSONEq(t,
+		`[{"type":"stateSync","id":"0x3","sender":"0x0000000000000000000000000000000000000001",`+
+			`"receiver":"0x0000000000000000000000000000000000000002","status":"executed"},`+
+			`{"type":"stateSync","id":"0x4","sender":"0x0000000000000000000000000000000000000001",`+
+			`"receiver":"0x0000000000000000000000000000000000000002","status":"executed"}]`,
+		string(resp.Result),
+	)
+
+	msg = []byte(`{
+		"method": "bridge_getStateSyncsByReceiver",
+		"params": ["0x0000000000000000000000000000000000000002", "0x4", "0x1"],
+		"id": 1
+	}`)
+
+	data, err = dispatcher.HandleWs(msg, mockConnection, "")
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+	require.JSONEq(t,
+		`[{"type":"stateSync","id":"0x4","sender":"0x0000000000000000000000000000000000000001",`+
+			`"receiver":"0x0000000000000000000000000000000000000002","status":"executed"}]`,
+		string(resp.Result),
+	)
+
+	msg = []byte(`{
+		"method": "bridge_getExitsBySender",
+		"params": ["0x0000000000000000000000000000000000000001"],
+		"id": 1
+	}`)
+
+	data, err = dispatcher.HandleWs(msg, mockConnection, "")
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+	require.JSONEq(t,
+		`[{"type":"exit","id":"0x4","sender":"0x0000000000000000000000000000000000000001",`+
+			`"receiver":"0x0000000000000000000000000000000000000002","status":"checkpointed"},`+
+			`{"type":"exit","id":"0x5","sender":"0x0000000000000000000000000000000000000001",`+
+			`"receiver":"0x0000000000000000000000000000000000000002","status":"checkpointed"}]`,
+		string(resp.Result),
+	)
+
+	msg = []byte(`{
+		"method": "bridge_getExitsBySender",
+		"params": ["0x0000000000000000000000000000000000000001", "0x5", "0x1"],
+		"id": 1
+	}`)
+
+	data, err = dispatcher.HandleWs(msg, mockConnection, "")
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+	require.JSONEq(t,
+		`[{"type":"exit","id":"0x5","sender":"0x0000000000000000000000000000000000000001",`+
+			`"receiver":"0x0000000000000000000000000000000000000002","status":"checkpointed"}]`,
+		string(resp.Result),
+	)
+
+	msg = []byte(`{
+		"method": "bridge_getStatus",
+		"params": ["exit", "0x7"],
+		"id": 1
+	}`)
+
+	data, err = dispatcher.HandleWs(msg, mockConnection, "")
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+	require.JSONEq(t,
+		`{"type":"exit","id":"0x7","sender":"0x0000000000000000000000000000000000000001",`+
+			`"receiver":"0x0000000000000000000000000000000000000002","status":"pending"}`,
+		string(resp.Result),
+	)
 }
@@ -2,12 +2,15 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -21,6 +24,15 @@ var (
 	ErrTraceGenesisBlock = errors.New("genesis is not traceable")
 	// ErrNoConfig is an error returns when config is empty
 	ErrNoConfig = errors.New("missing config object")
+	// ErrUnknownTracer is an error returned when the requested tracer is not supported
+	ErrUnknownTracer = errors.New("unknown tracer")
+)
+
+const (
+	// callTracerName is the name of the tracer building a tree of the executed calls
+	callTracerName = "callTracer"
+	// prestateTracerName is the name of the tracer collecting the state touched by the transaction
+	prestateTracerName = "prestateTracer"
 )
 
 type debugBlockchainStore interface {
@@ -69,11 +81,23 @@ type Debug struct {
 }
 
 type TraceConfig struct {
-	EnableMemory     bool    `json:"enableMemory"`
-	DisableStack     bool    `json:"disableStack"`
-	DisableStorage   bool    `json:"disableStorage"`
-	EnableReturnData bool    `json:"enableReturnData"`
-	Timeout          *string `json:"timeout"`
+	EnableMemory     bool            `json:"enableMemory"`
+	DisableStack     bool            `json:"disableStack"`
+	DisableStorage   bool            `json:"disableStorage"`
+	EnableReturnData bool            `json:"enableReturnData"`
+	Timeout          *string         `json:"timeout"`
+	Tracer           string          `json:"tracer"`
+	TracerConfig     json.RawMessage `json:"tracerConfig"`
+}
+
+// CallTracerConfig is the tracer specific config of the callTracer
+type CallTracerConfig struct {
+	OnlyTopCall bool `json:"onlyTopCall"`
+}
+
+// PrestateTracerConfig is the tracer specific config of the prestateTracer
+type PrestateTracerConfig struct {
+	DiffMode bool `json:"diffMode"`
 }
 
 func (d *Debug) TraceBlockByNumber(
@@ -166,12 +190,12 @@ func (d *Debug) TraceCall(
 	}
 
 	tracer, cancel, err := newTracer(config)
-	defer cancel()
-
 	if err != nil {
 		return nil, err
 	}
 
+	defer cancel()
+
 	return d.store.TraceCall(tx, header, tracer)
 }
 
@@ -184,12 +208,12 @@ func (d *Debug) traceBlock(
 	}
 
 	tracer, cancel, err := newTracer(config)
-	defer cancel()
-
 	if err != nil {
 		return nil, err
 	}
 
+	defer cancel()
+
 	return d.store.TraceBlock(block, tracer)
 }
 
@@ -214,12 +238,10 @@ func newTracer(config *TraceConfig) (
 		}
 	}
 
-	tracer := structtracer.NewStructTracer(structtracer.Config{
-		EnableMemory:     config.EnableMemory,
-		EnableStack:      !config.DisableStack,
-		EnableStorage:    !config.DisableStorage,
-		EnableReturnData: config.EnableReturnData,
-	})
+	tracer, err := newTracerByName(config)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), timeout)
 
@@ -234,3 +256,46 @@ func newTracer(config *TraceConfig) (
 	// cancellation of context is done by caller
 	return tracer, cancel, nil
 }
+
+// newTracerByName creates the tracer selected in the given config,
+// defaults to the struct logger if no tracer is specified
+func newTracerByName(config *TraceConfig) (tracer.Tracer, error) {
+	switch config.Tracer {
+	case "":
+		return structtracer.NewStructTracer(structtracer.Config{
+			EnableMemory:     config.EnableMemory,
+			EnableStack:      !config.DisableStack,
+			EnableStorage:    !config.DisableStorage,
+			EnableReturnData: config.EnableReturnData,
+		}), nil
+
+	case callTracerName:
+		tracerConfig := CallTracerConfig{}
+
+		if len(config.TracerConfig) > 0 {
+			if err := json.Unmarshal(config.TracerConfig, &tracerConfig); err != nil {
+				return nil, fmt.Errorf("invalid tracer config: %w", err)
+			}
+		}
+
+		return calltracer.NewCallTracer(calltracer.Config{
+			OnlyTopCall: tracerConfig.OnlyTopCall,
+		}), nil
+
+	case prestateTracerName:
+		tracerConfig := PrestateTracerConfig{}
+
+		if len(config.TracerConfig) > 0 {
+			if err := json.Unmarshal(config.TracerConfig, &tracerConfig); err != nil {
+				return nil, fmt.Errorf("invalid tracer config: %w", err)
+			}
+		}
+
+		return prestatetracer.NewPrestateTracer(prestatetracer.Config{
+			DiffMode: tracerConfig.DiffMode,
+		}), nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTracer, config.Tracer)
+	}
+}
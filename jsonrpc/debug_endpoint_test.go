@@ -8,6 +8,8 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -138,6 +140,16 @@ func TestDebugTraceConfigDecode(t *testing.T) {
 				Timeout:          &timeout15s,
 			},
 		},
+		{
+			input: `{
+				"tracer": "callTracer",
+				"tracerConfig": {"onlyTopCall": true}
+			}`,
+			expected: TraceConfig{
+				Tracer:       "callTracer",
+				TracerConfig: json.RawMessage(`{"onlyTopCall": true}`),
+			},
+		},
 		{
 			input: `{
 				"enableMemory": true,
@@ -715,6 +727,52 @@ func Test_newTracer(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("should create call tracer", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer:       callTracerName,
+			TracerConfig: json.RawMessage(`{"onlyTopCall": true}`),
+		})
+
+		t.Cleanup(func() {
+			cancel()
+		})
+
+		assert.NoError(t, err)
+		assert.IsType(t, &calltracer.CallTracer{}, tracer)
+		assert.True(t, tracer.(*calltracer.CallTracer).Config.OnlyTopCall) //nolint:forcetypeassert
+	})
+
+	t.Run("should create prestate tracer", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer:       prestateTracerName,
+			TracerConfig: json.RawMessage(`{"diffMode": true}`),
+		})
+
+		t.Cleanup(func() {
+			cancel()
+		})
+
+		assert.NoError(t, err)
+		assert.IsType(t, &prestatetracer.PrestateTracer{}, tracer)
+		assert.True(t, tracer.(*prestatetracer.PrestateTracer).Config.DiffMode) //nolint:forcetypeassert
+	})
+
+	t.Run("should return error if tracer is unknown", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer: "unknownTracer",
+		})
+
+		assert.Nil(t, tracer)
+		assert.Nil(t, cancel)
+		assert.ErrorIs(t, err, ErrUnknownTracer)
+	})
+
 	t.Run("should return error if arg is nil", func(t *testing.T) {
 		t.Parallel()
 
@@ -8,6 +8,7 @@ import (
 	"math"
 	"reflect"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/hashicorp/go-hclog"
@@ -46,6 +47,12 @@ type Dispatcher struct {
 	filterManager *FilterManager
 	endpoints     endpoints
 
+	// guard enforces the method allow / deny lists and rate limits
+	guard *methodGuard
+
+	// inFlight holds a slot per request being processed (nil if unlimited)
+	inFlight chan struct{}
+
 	params *dispatcherParams
 }
 
@@ -56,6 +63,13 @@ type dispatcherParams struct {
 	priceLimit              uint64
 	jsonRPCBatchLengthLimit uint64
 	blockRangeLimit         uint64
+
+	allowedMethods        []string
+	deniedMethods         []string
+	methodRateLimits      map[string]RateLimit
+	maxConcurrentRequests uint64
+
+	slowRequestThreshold time.Duration
 }
 
 func newDispatcher(
@@ -65,9 +79,14 @@ func newDispatcher(
 ) (*Dispatcher, error) {
 	d := &Dispatcher{
 		logger: logger.Named("dispatcher"),
+		guard:  newMethodGuard(params.allowedMethods, params.deniedMethods, params.methodRateLimits),
 		params: params,
 	}
 
+	if params.maxConcurrentRequests > 0 {
+		d.inFlight = make(chan struct{}, params.maxConcurrentRequests)
+	}
+
 	if store != nil {
 		d.filterManager = NewFilterManager(logger, store, params.blockRangeLimit)
 		go d.filterManager.Run()
@@ -193,15 +212,28 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 	}
 
 	var filterID string
-	if subscribeMethod == "newHeads" {
+
+	switch subscribeMethod {
+	case "newHeads":
 		filterID = d.filterManager.NewBlockFilter(conn)
-	} else if subscribeMethod == "logs" {
+	case "logs":
 		logQuery, err := decodeLogQueryFromInterface(params[1])
 		if err != nil {
 			return "", NewInternalError(err.Error())
 		}
 		filterID = d.filterManager.NewLogFilter(logQuery, conn)
-	} else {
+	case "newPendingTransactions":
+		// the optional flag selects the full transactions instead of their hashes
+		fullTx := false
+		if len(params) > 1 {
+			if fullTx, ok = params[1].(bool); !ok {
+				return "", NewInvalidParamsError("Invalid full transaction flag")
+			}
+		}
+		filterID = d.filterManager.NewPendingTxFilter(fullTx, conn)
+	case "syncing":
+		filterID = d.filterManager.NewSyncingFilter(conn)
+	default:
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
 
@@ -226,20 +258,55 @@ func (d *Dispatcher) handleUnsubscribe(req Request) (bool, Error) {
 	return d.filterManager.Uninstall(filterID), nil
 }
 
+// acquire takes a slot for the request to be processed,
+// returns false if the maximum number of concurrent requests is reached
+func (d *Dispatcher) acquire() bool {
+	if d.inFlight == nil {
+		return true
+	}
+
+	select {
+	case d.inFlight <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// release frees the slot taken by acquire
+func (d *Dispatcher) release() {
+	if d.inFlight != nil {
+		<-d.inFlight
+	}
+}
+
 func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
 	d.filterManager.RemoveFilterByWs(conn)
 }
 
-func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
+func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn, client string) ([]byte, error) {
 	var req Request
 	if err := json.Unmarshal(reqBody, &req); err != nil {
 		return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 	}
 
+	if !d.acquire() {
+		return NewRPCResponse(req.ID, "2.0", nil, errTooManyConcurrentRequests).Bytes()
+	}
+
+	defer d.release()
+
 	// if the request method is eth_subscribe we need to create a
 	// new filter with ws connection
 	if req.Method == "eth_subscribe" {
+		if err := d.guard.check(req.Method, client); err != nil {
+			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+		}
+
+		start := time.Now()
 		filterID, err := d.handleSubscribe(req, conn)
+		d.observeRequest(req, start, err)
+
 		if err != nil {
 			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
 		}
@@ -254,7 +321,14 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 	}
 
 	if req.Method == "eth_unsubscribe" {
+		if err := d.guard.check(req.Method, client); err != nil {
+			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+		}
+
+		start := time.Now()
 		ok, err := d.handleUnsubscribe(req)
+		d.observeRequest(req, start, err)
+
 		if err != nil {
 			return nil, err
 		}
@@ -273,7 +347,7 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 	}
 
 	// its a normal query that we handle with the dispatcher
-	resp, err := d.handleReq(req)
+	resp, err := d.handleReq(req, client)
 	if err != nil {
 		return nil, err
 	}
@@ -281,7 +355,13 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 	return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
 }
 
-func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
+func (d *Dispatcher) Handle(reqBody []byte, client string) ([]byte, error) {
+	if !d.acquire() {
+		return NewRPCResponse(nil, "2.0", nil, errTooManyConcurrentRequests).Bytes()
+	}
+
+	defer d.release()
+
 	x := bytes.TrimLeft(reqBody, " \t\r\n")
 	if len(x) == 0 {
 		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
@@ -297,7 +377,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 			return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 		}
 
-		resp, err := d.handleReq(req)
+		resp, err := d.handleReq(req, client)
 
 		return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
 	}
@@ -326,7 +406,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	responses := make([]Response, 0)
 
 	for _, req := range requests {
-		var response, err = d.handleReq(req)
+		var response, err = d.handleReq(req, client)
 		if err != nil {
 			errorResponse := NewRPCResponse(req.ID, "2.0", response, err)
 			responses = append(responses, errorResponse)
@@ -346,9 +426,56 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	return respBytes, nil
 }
 
-func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
+// handleReq handles a single request and records its metrics
+func (d *Dispatcher) handleReq(req Request, client string) ([]byte, Error) {
+	start := time.Now()
+	resp, err := d.dispatchReq(req, client)
+	d.observeRequest(req, start, err)
+
+	return resp, err
+}
+
+// observeRequest updates the request metrics and logs the request if it was too slow
+func (d *Dispatcher) observeRequest(req Request, start time.Time, err Error) {
+	method := req.Method
+	if !d.isServed(method) {
+		method = unknownMethod
+	}
+
+	updateRequestMetrics(method, start, err)
+
+	if d.params.slowRequestThreshold == 0 {
+		return
+	}
+
+	if elapsed := time.Since(start); elapsed >= d.params.slowRequestThreshold {
+		params := string(req.Params)
+		if len(params) > maxSlowRequestParamsLength {
+			params = params[:maxSlowRequestParamsLength] + "..."
+		}
+
+		d.logger.Warn("slow request", "method", req.Method, "id", req.ID, "elapsed", elapsed, "params", params)
+	}
+}
+
+// isServed reports whether the method is registered in the dispatcher
+func (d *Dispatcher) isServed(method string) bool {
+	if method == "eth_subscribe" || method == "eth_unsubscribe" {
+		return true
+	}
+
+	_, _, err := d.getFnHandler(Request{Method: method})
+
+	return err == nil
+}
+
+func (d *Dispatcher) dispatchReq(req Request, client string) ([]byte, Error) {
 	d.logger.Debug("request", "method", req.Method, "id", req.ID)
 
+	if err := d.guard.check(req.Method, client); err != nil {
+		return nil, err
+	}
+
 	service, fd, ferr := d.getFnHandler(req)
 	if ferr != nil {
 		return nil, ferr
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"encoding/json"
 	"math/big"
 	"reflect"
@@ -79,7 +80,7 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 		"method": "eth_subscribe",
 		"params": ["newHeads"]
 	}`)
-		if _, err := dispatcher.HandleWs(req, mockConnection); err != nil {
+		if _, err := dispatcher.HandleWs(req, mockConnection, ""); err != nil {
 			t.Fatal(err)
 		}
 
@@ -99,6 +100,58 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 			t.Fatal("\"newHeads\" event not received in 2 seconds")
 		}
 	})
+
+	t.Run("clients should be able to receive \"newPendingTransactions\" event thru eth_subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore()
+		dispatcher := newTestDispatcher(t,
+			hclog.NewNullLogger(),
+			store,
+			&dispatcherParams{
+				jsonRPCBatchLengthLimit: 20,
+				blockRangeLimit:         1000,
+			},
+		)
+		mockConnection, msgCh := newMockWsConnWithMsgCh()
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["newPendingTransactions"]
+	}`)
+		if _, err := dispatcher.HandleWs(req, mockConnection, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		tx := &types.Transaction{Hash: types.StringToHash("1")}
+		store.emitPendingTx(tx)
+
+		select {
+		case msg := <-msgCh:
+			assert.Contains(t, string(msg), tx.Hash.String())
+		case <-time.After(2 * time.Second):
+			t.Fatal("\"newPendingTransactions\" event not received in 2 seconds")
+		}
+	})
+
+	t.Run("invalid full transaction flag of \"newPendingTransactions\" is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), newMockStore(), &dispatcherParams{})
+		mockConnection, _ := newMockWsConnWithMsgCh()
+
+		resp, err := dispatcher.HandleWs(
+			[]byte(`{"method": "eth_subscribe", "params": ["newPendingTransactions", "yes"]}`),
+			mockConnection,
+			"",
+		)
+		require.NoError(t, err)
+
+		var res ErrorResponse
+
+		require.NoError(t, json.Unmarshal(resp, &res))
+		assert.Equal(t, -32602, res.Error.Code)
+	})
 }
 
 func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
@@ -161,7 +214,7 @@ func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
 		},
 	}
 	for _, c := range cases {
-		data, err := dispatcher.HandleWs(c.msg, mockConnection)
+		data, err := dispatcher.HandleWs(c.msg, mockConnection, "")
 		resp := new(SuccessResponse)
 		merr := json.Unmarshal(data, resp)
 
@@ -231,7 +284,7 @@ func TestDispatcherFuncDecode(t *testing.T) {
 		_, err := dispatcher.handleReq(Request{
 			Method: "mock_" + typ,
 			Params: []byte(msg),
-		})
+		}, "")
 		assert.NoError(t, err)
 
 		return <-srv.msgCh
@@ -291,7 +344,7 @@ func TestDispatcherFuncDecode(t *testing.T) {
 
 func TestDispatcherBatchRequest(t *testing.T) {
 	handle := func(dispatcher *Dispatcher, reqBody []byte) []byte {
-		res, _ := dispatcher.Handle(reqBody)
+		res, _ := dispatcher.Handle(reqBody, "")
 
 		return res
 	}
@@ -457,6 +510,140 @@ func TestDispatcherBatchRequest(t *testing.T) {
 	}
 }
 
+func TestDispatcher_MethodLimits(t *testing.T) {
+	t.Parallel()
+
+	handle := func(t *testing.T, dispatcher *Dispatcher, client, method string) *ObjectError {
+		t.Helper()
+
+		res, err := dispatcher.Handle(
+			[]byte(`{"id":1,"jsonrpc":"2.0","method":"`+method+`","params":[]}`),
+			client,
+		)
+		require.NoError(t, err)
+
+		var resp SuccessResponse
+
+		require.NoError(t, json.Unmarshal(res, &resp))
+
+		return resp.Error
+	}
+
+	t.Run("denied namespaces and methods are not served", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcher := newTestDispatcher(t,
+			hclog.NewNullLogger(),
+			newMockStore(),
+			&dispatcherParams{
+				deniedMethods: []string{"debug_", "eth_chainId"},
+			},
+		)
+
+		assert.Equal(t, -32601, handle(t, dispatcher, "", "debug_traceBlock").Code)
+		assert.Equal(t, -32601, handle(t, dispatcher, "", "eth_chainId").Code)
+		assert.Nil(t, handle(t, dispatcher, "", "eth_blockNumber"))
+	})
+
+	t.Run("only allowed namespaces and methods are served", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcher := newTestDispatcher(t,
+			hclog.NewNullLogger(),
+			newMockStore(),
+			&dispatcherParams{
+				allowedMethods: []string{"eth", "web3_clientVersion"},
+			},
+		)
+
+		assert.Nil(t, handle(t, dispatcher, "", "eth_blockNumber"))
+		assert.Nil(t, handle(t, dispatcher, "", "web3_clientVersion"))
+		assert.Equal(t, -32601, handle(t, dispatcher, "", "web3_sha3").Code)
+		assert.Equal(t, -32601, handle(t, dispatcher, "", "txpool_status").Code)
+	})
+
+	t.Run("method group rate limit is enforced", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcher := newTestDispatcher(t,
+			hclog.NewNullLogger(),
+			newMockStore(),
+			&dispatcherParams{
+				methodRateLimits: map[string]RateLimit{
+					"eth_blockNumber": {Rate: 0.001, Burst: 2},
+				},
+			},
+		)
+
+		assert.Nil(t, handle(t, dispatcher, "10.0.0.1", "eth_blockNumber"))
+		assert.Nil(t, handle(t, dispatcher, "10.0.0.1", "eth_blockNumber"))
+		assert.Equal(t, -32005, handle(t, dispatcher, "10.0.0.1", "eth_blockNumber").Code)
+
+		// other clients have their own limit
+		assert.Nil(t, handle(t, dispatcher, "10.0.0.2", "eth_blockNumber"))
+
+		// other methods are not limited
+		assert.Nil(t, handle(t, dispatcher, "", "eth_chainId"))
+	})
+
+	t.Run("concurrent requests are limited", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcher := newTestDispatcher(t,
+			hclog.NewNullLogger(),
+			newMockStore(),
+			&dispatcherParams{
+				maxConcurrentRequests: 1,
+			},
+		)
+
+		// occupy the only slot
+		require.True(t, dispatcher.acquire())
+
+		assert.Equal(t, -32005, handle(t, dispatcher, "", "eth_blockNumber").Code)
+
+		dispatcher.release()
+
+		assert.Nil(t, handle(t, dispatcher, "", "eth_blockNumber"))
+	})
+}
+
+func TestDispatcher_SlowRequestLog(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Output: &buf,
+		Level:  hclog.Warn,
+	})
+
+	dispatcher := newTestDispatcher(t,
+		logger,
+		newMockStore(),
+		&dispatcherParams{
+			slowRequestThreshold: time.Nanosecond,
+		},
+	)
+
+	_, err := dispatcher.Handle([]byte(`{"id":1,"jsonrpc":"2.0","method":"eth_blockNumber","params":[]}`), "")
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "slow request")
+	assert.Contains(t, buf.String(), "method=eth_blockNumber")
+}
+
+func TestDispatcher_IsServed(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), newMockStore(), &dispatcherParams{})
+
+	assert.True(t, dispatcher.isServed("eth_blockNumber"))
+	assert.True(t, dispatcher.isServed("eth_subscribe"))
+	assert.False(t, dispatcher.isServed("eth_unknownMethod"))
+	assert.False(t, dispatcher.isServed("foo"))
+}
+
 func newTestDispatcher(t *testing.T, logger hclog.Logger, store JSONRPCStore, params *dispatcherParams) *Dispatcher {
 	t.Helper()
 
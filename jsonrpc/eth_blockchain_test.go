@@ -6,11 +6,14 @@ import (
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	txpoolProto "github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_Block_GetBlockByNumber(t *testing.T) {
@@ -313,6 +316,171 @@ func TestEth_GasPrice(t *testing.T) {
 	assert.Equal(t, argUint64(store.averageGasPrice), res)
 }
 
+func TestEth_MaxPriorityFeePerGas(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns default tip if there are no transactions", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(0, hash1))
+
+		res, err := newTestEthEndpoint(store).MaxPriorityFeePerGas()
+		require.NoError(t, err)
+		assert.Equal(t, argUint64(defaultMaxPriorityFeePerGas), res)
+	})
+
+	t.Run("returns percentile of the tips in the latest blocks", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestFeeBlock(0, 10, nil))
+		store.add(newTestFeeBlock(1, 10, []*types.Transaction{
+			newTestDynamicFeeTx(100, 1),
+			newTestDynamicFeeTx(100, 5),
+			newTestLegacyTx(13),
+		}))
+		store.add(newTestFeeBlock(2, 10, []*types.Transaction{
+			newTestDynamicFeeTx(12, 4), // capped by the fee cap to 2
+			newTestDynamicFeeTx(100, 7),
+		}))
+
+		res, err := newTestEthEndpoint(store).MaxPriorityFeePerGas()
+		require.NoError(t, err)
+		// sorted tips: 1, 2, 3, 5, 7
+		assert.Equal(t, argUint64(3), res)
+	})
+
+	t.Run("skips state sync and system transactions", func(t *testing.T) {
+		t.Parallel()
+
+		systemTx := newTestLegacyTx(0)
+		systemTx.From = contracts.SystemCaller
+
+		store := newMockBlockStore()
+		store.add(newTestFeeBlock(0, 10, []*types.Transaction{
+			{Type: types.StateTx, GasPrice: big.NewInt(0)},
+			systemTx,
+			newTestDynamicFeeTx(100, 5),
+		}))
+
+		res, err := newTestEthEndpoint(store).MaxPriorityFeePerGas()
+		require.NoError(t, err)
+		assert.Equal(t, argUint64(5), res)
+	})
+}
+
+func TestEth_FeeHistory(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	store.add(newTestFeeBlock(0, 10, nil))
+	store.add(newTestFeeBlock(1, 20, []*types.Transaction{
+		newTestDynamicFeeTx(100, 5),
+		newTestDynamicFeeTx(100, 1),
+		newTestLegacyTx(23),
+	}))
+	store.add(newTestFeeBlock(2, 30, []*types.Transaction{
+		newTestDynamicFeeTx(100, 2),
+	}))
+
+	for _, b := range store.blocks {
+		gasUsed := uint64(0)
+		receipts := make([]*types.Receipt, len(b.Transactions))
+
+		for i := range b.Transactions {
+			receipts[i] = &types.Receipt{GasUsed: uint64(i+1) * 1000}
+			gasUsed += receipts[i].GasUsed
+		}
+
+		b.Header.GasUsed = gasUsed
+		b.Header.GasLimit = 10000
+		store.receipts[b.Hash()] = receipts
+	}
+
+	eth := newTestEthEndpoint(store)
+
+	t.Run("returns history of the latest blocks", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.FeeHistory(2, LatestBlockNumber, []float64{0, 50, 100})
+		require.NoError(t, err)
+
+		assert.Equal(t, &feeHistoryResult{
+			OldestBlock:   1,
+			BaseFeePerGas: []argUint64{20, 30, 31},
+			GasUsedRatio:  []float64{0.6, 0.1},
+			Reward: [][]argUint64{
+				// tips weighted by gas used: 1 (2000), 3 (3000), 5 (1000)
+				{1, 3, 5},
+				{2, 2, 2},
+			},
+		}, res)
+	})
+
+	t.Run("caps block count to the chain length", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.FeeHistory(10, BlockNumber(1), nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, &feeHistoryResult{
+			OldestBlock:   0,
+			BaseFeePerGas: []argUint64{10, 20, 21},
+			GasUsedRatio:  []float64{0, 0.6},
+		}, res)
+	})
+
+	t.Run("returns zero rewards for empty blocks", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.FeeHistory(1, EarliestBlockNumber, []float64{25, 75})
+		require.NoError(t, err)
+
+		//nolint:forcetypeassert
+		assert.Equal(t, [][]argUint64{{0, 0}}, res.(*feeHistoryResult).Reward)
+	})
+
+	t.Run("skips state sync and system transactions", func(t *testing.T) {
+		t.Parallel()
+
+		systemTx := newTestLegacyTx(0)
+		systemTx.From = contracts.SystemCaller
+
+		block := newTestFeeBlock(0, 10, []*types.Transaction{
+			{Type: types.StateTx, GasPrice: big.NewInt(0)},
+			newTestDynamicFeeTx(100, 5),
+			systemTx,
+			newTestDynamicFeeTx(100, 1),
+		})
+		block.Header.GasUsed = 10000
+		block.Header.GasLimit = 10000
+
+		store := newMockBlockStore()
+		store.add(block)
+		store.receipts[block.Hash()] = []*types.Receipt{
+			{GasUsed: 1000}, {GasUsed: 2000}, {GasUsed: 3000}, {GasUsed: 4000},
+		}
+
+		res, err := newTestEthEndpoint(store).FeeHistory(1, LatestBlockNumber, []float64{0, 50, 100})
+		require.NoError(t, err)
+
+		// tips weighted by gas used: 1 (4000), 5 (2000)
+		//nolint:forcetypeassert
+		assert.Equal(t, [][]argUint64{{1, 1, 5}}, res.(*feeHistoryResult).Reward)
+	})
+
+	t.Run("returns error for invalid percentiles", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := eth.FeeHistory(1, LatestBlockNumber, []float64{101})
+		assert.ErrorIs(t, err, ErrInvalidRewardPercentile)
+
+		_, err = eth.FeeHistory(1, LatestBlockNumber, []float64{50, 10})
+		assert.ErrorIs(t, err, ErrInvalidRewardPercentile)
+	})
+}
+
 func TestEth_Call(t *testing.T) {
 	t.Parallel()
 
@@ -574,6 +742,10 @@ func (m *mockBlockStore) GetAvgGasPrice() *big.Int {
 	return big.NewInt(m.averageGasPrice)
 }
 
+func (m *mockBlockStore) CalculateBaseFee(parent *types.Header) uint64 {
+	return parent.BaseFee + 1
+}
+
 func (m *mockBlockStore) ApplyTxn(header *types.Header, txn *types.Transaction, overrides types.StateOverride) (*runtime.ExecutionResult, error) {
 	return &runtime.ExecutionResult{
 		Err:         m.ethCallError,
@@ -585,10 +757,40 @@ func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
 
+func (m *mockBlockStore) SubscribeTxEvents(...txpoolProto.EventType) (<-chan *txpoolProto.TxPoolEvent, func()) {
+	return nil, nil
+}
+
 func (m *mockBlockStore) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
 
+func newTestFeeBlock(number, baseFee uint64, txs []*types.Transaction) *types.Block {
+	return &types.Block{
+		Header: &types.Header{
+			Number:  number,
+			Hash:    types.BytesToHash([]byte{byte(number + 1)}),
+			BaseFee: baseFee,
+		},
+		Transactions: txs,
+	}
+}
+
+func newTestDynamicFeeTx(gasFeeCap, gasTipCap int64) *types.Transaction {
+	return &types.Transaction{
+		Type:      types.DynamicFeeTx,
+		GasFeeCap: big.NewInt(gasFeeCap),
+		GasTipCap: big.NewInt(gasTipCap),
+	}
+}
+
+func newTestLegacyTx(gasPrice int64) *types.Transaction {
+	return &types.Transaction{
+		Type:     types.LegacyTx,
+		GasPrice: big.NewInt(gasPrice),
+	}
+}
+
 func newTestBlock(number uint64, hash types.Hash) *types.Block {
 	return &types.Block{
 		Header: &types.Header{
@@ -5,14 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"reflect"
+	"sort"
 
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -32,11 +37,29 @@ type Account struct {
 	Nonce   uint64
 }
 
+// StorageProof is the merkle proof of a storage slot of an account
+type StorageProof struct {
+	Key   types.Hash
+	Value types.Hash
+	Proof [][]byte
+}
+
+// AccountProof is the merkle proof of an account and a set of its storage slots
+type AccountProof struct {
+	Nonce         uint64
+	Balance       *big.Int
+	CodeHash      types.Hash
+	StorageRoot   types.Hash
+	Proof         [][]byte
+	StorageProofs []*StorageProof
+}
+
 type ethStateStore interface {
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(root types.Hash, addr types.Address) ([]byte, error)
+	GetProof(root types.Hash, addr types.Address, storageKeys []types.Hash) (*AccountProof, error)
 }
 
 type ethBlockchainStore interface {
@@ -61,6 +84,9 @@ type ethBlockchainStore interface {
 	// GetAvgGasPrice returns the average gas price
 	GetAvgGasPrice() *big.Int
 
+	// CalculateBaseFee calculates the base fee of the block following the given parent
+	CalculateBaseFee(parent *types.Header) uint64
+
 	// ApplyTxn applies a transaction object to the blockchain
 	ApplyTxn(header *types.Header, txn *types.Transaction, override types.StateOverride) (*runtime.ExecutionResult, error)
 
@@ -91,7 +117,23 @@ type Eth struct {
 }
 
 var (
-	ErrInsufficientFunds = errors.New("insufficient funds for execution")
+	ErrInsufficientFunds       = errors.New("insufficient funds for execution")
+	ErrInvalidRewardPercentile = errors.New("invalid reward percentile")
+)
+
+const (
+	// maxFeeHistoryBlockCount is the maximum number of blocks returned by eth_feeHistory
+	maxFeeHistoryBlockCount = 1024
+
+	// priorityFeeSampleBlocks is the number of latest blocks inspected
+	// when suggesting a max priority fee per gas
+	priorityFeeSampleBlocks = 20
+
+	// priorityFeePercentile is the percentile of the sampled tips suggested as max priority fee per gas
+	priorityFeePercentile = 60
+
+	// defaultMaxPriorityFeePerGas is the suggested tip when there are no transactions to sample (1 gwei)
+	defaultMaxPriorityFeePerGas = 1_000_000_000
 )
 
 // ChainId returns the chain id of the client
@@ -102,14 +144,9 @@ func (e *Eth) ChainId() (interface{}, error) {
 }
 
 func (e *Eth) Syncing() (interface{}, error) {
-	if syncProgression := e.store.GetSyncProgression(); syncProgression != nil {
+	if syncStatus := toSyncStatus(e.store.GetSyncProgression()); syncStatus != nil {
 		// Node is bulk syncing, return the status
-		return progression{
-			Type:          string(syncProgression.SyncType),
-			StartingBlock: argUint64(syncProgression.StartingBlock),
-			CurrentBlock:  argUint64(syncProgression.CurrentBlock),
-			HighestBlock:  argUint64(syncProgression.HighestBlock),
-		}, nil
+		return *syncStatus, nil
 	}
 
 	// Node is not bulk syncing
@@ -397,6 +434,44 @@ func (e *Eth) GetStorageAt(
 	return argBytesPtr(result), nil
 }
 
+// GetProof returns the merkle proof of the account and of the given storage slots
+// at the given block, as defined in EIP-1186
+func (e *Eth) GetProof(
+	address types.Address,
+	storageKeys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := e.store.GetProof(header.StateRoot, address, storageKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &accountProofResult{
+		Address:      address,
+		AccountProof: toArgBytesList(proof.Proof),
+		Balance:      argBigPtr(proof.Balance),
+		CodeHash:     proof.CodeHash,
+		Nonce:        argUint64(proof.Nonce),
+		StorageHash:  proof.StorageRoot,
+		StorageProof: make([]storageProofResult, len(proof.StorageProofs)),
+	}
+
+	for i, storageProof := range proof.StorageProofs {
+		result.StorageProof[i] = storageProofResult{
+			Key:   storageProof.Key,
+			Value: argBigPtr(new(big.Int).SetBytes(storageProof.Value.Bytes())),
+			Proof: toArgBytesList(storageProof.Proof),
+		}
+	}
+
+	return result, nil
+}
+
 // GasPrice returns the average gas price based on the last x blocks
 // taking into consideration operator defined price limit
 func (e *Eth) GasPrice() (interface{}, error) {
@@ -407,6 +482,207 @@ func (e *Eth) GasPrice() (interface{}, error) {
 	return argUint64(common.Max(e.priceLimit, avgGasPrice)), nil
 }
 
+// MaxPriorityFeePerGas returns a suggestion for the max priority fee per gas (tip)
+// of dynamic fee transactions, based on the tips paid in the last x blocks
+func (e *Eth) MaxPriorityFeePerGas() (interface{}, error) {
+	latest := e.store.Header()
+	if latest == nil {
+		return nil, ErrLatestNotFound
+	}
+
+	tips := make([]uint64, 0)
+
+	for i := uint64(0); i < priorityFeeSampleBlocks && i <= latest.Number; i++ {
+		block, ok := e.store.GetBlockByNumber(latest.Number-i, true)
+		if !ok {
+			break
+		}
+
+		for _, tx := range block.Transactions {
+			// the zero priced state sync and system transactions would pull the suggestion down
+			if isSystemTx(tx) {
+				continue
+			}
+
+			tips = append(tips, effectiveTip(tx, block.Header.BaseFee))
+		}
+	}
+
+	if len(tips) == 0 {
+		return argUint64(defaultMaxPriorityFeePerGas), nil
+	}
+
+	sort.Slice(tips, func(i, j int) bool {
+		return tips[i] < tips[j]
+	})
+
+	return argUint64(tips[(len(tips)-1)*priorityFeePercentile/100]), nil
+}
+
+// FeeHistory returns the base fee per gas, the gas used ratio and the requested
+// percentiles of the effective priority fees for a range of blocks ending with newestBlock
+func (e *Eth) FeeHistory(
+	blockCount argUint64,
+	newestBlock BlockNumber,
+	rewardPercentiles []float64,
+) (interface{}, error) {
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("%w: %f", ErrInvalidRewardPercentile, p)
+		}
+
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return nil, fmt.Errorf("%w: #%d:%f > #%d:%f",
+				ErrInvalidRewardPercentile, i-1, rewardPercentiles[i-1], i, p)
+		}
+	}
+
+	if blockCount == 0 {
+		return &feeHistoryResult{
+			GasUsedRatio: []float64{},
+		}, nil
+	}
+
+	newest, err := GetNumericBlockNumber(newestBlock, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	count := common.Min(uint64(blockCount), maxFeeHistoryBlockCount)
+	count = common.Min(count, newest+1)
+	oldest := newest + 1 - count
+
+	result := &feeHistoryResult{
+		OldestBlock:   argUint64(oldest),
+		BaseFeePerGas: make([]argUint64, 0, count+1),
+		GasUsedRatio:  make([]float64, 0, count),
+	}
+
+	if len(rewardPercentiles) > 0 {
+		result.Reward = make([][]argUint64, 0, count)
+	}
+
+	var header *types.Header
+
+	for number := oldest; number <= newest; number++ {
+		block, ok := e.store.GetBlockByNumber(number, true)
+		if !ok {
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+
+		header = block.Header
+
+		gasUsedRatio := float64(0)
+		if header.GasLimit > 0 {
+			gasUsedRatio = float64(header.GasUsed) / float64(header.GasLimit)
+		}
+
+		result.BaseFeePerGas = append(result.BaseFeePerGas, argUint64(header.BaseFee))
+		result.GasUsedRatio = append(result.GasUsedRatio, gasUsedRatio)
+
+		if len(rewardPercentiles) == 0 {
+			continue
+		}
+
+		rewards, err := e.blockRewards(block, rewardPercentiles)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Reward = append(result.Reward, rewards)
+	}
+
+	// the last entry is the base fee of the block following the newest one
+	result.BaseFeePerGas = append(result.BaseFeePerGas, argUint64(e.store.CalculateBaseFee(header)))
+
+	return result, nil
+}
+
+// blockRewards returns the effective priority fees paid in the block at the given percentiles,
+// where each transaction is weighted by the gas it used.
+// The state sync and system transactions are skipped, since they are not paid by the users
+func (e *Eth) blockRewards(block *types.Block, percentiles []float64) ([]argUint64, error) {
+	rewards := make([]argUint64, len(percentiles))
+
+	if len(block.Transactions) == 0 {
+		return rewards, nil
+	}
+
+	receipts, err := e.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("receipts not found for block #%d", block.Number())
+	}
+
+	type txTip struct {
+		gasUsed uint64
+		tip     uint64
+	}
+
+	tips := make([]txTip, 0, len(block.Transactions))
+	totalGasUsed := uint64(0)
+
+	for i, tx := range block.Transactions {
+		if isSystemTx(tx) {
+			continue
+		}
+
+		tips = append(tips, txTip{
+			gasUsed: receipts[i].GasUsed,
+			tip:     effectiveTip(tx, block.Header.BaseFee),
+		})
+		totalGasUsed += receipts[i].GasUsed
+	}
+
+	if len(tips) == 0 {
+		return rewards, nil
+	}
+
+	sort.SliceStable(tips, func(i, j int) bool {
+		return tips[i].tip < tips[j].tip
+	})
+
+	txIndex := 0
+	sumGasUsed := tips[0].gasUsed
+
+	for i, p := range percentiles {
+		threshold := uint64(float64(totalGasUsed) * p / 100)
+		for sumGasUsed < threshold && txIndex < len(tips)-1 {
+			txIndex++
+			sumGasUsed += tips[txIndex].gasUsed
+		}
+
+		rewards[i] = argUint64(tips[txIndex].tip)
+	}
+
+	return rewards, nil
+}
+
+// isSystemTx reports whether the transaction is a state sync or a system transaction,
+// which are not paid by the users
+func isSystemTx(tx *types.Transaction) bool {
+	return tx.Type == types.StateTx || tx.From == contracts.SystemCaller
+}
+
+// effectiveTip returns the priority fee per gas the transaction pays on top of the base fee
+func effectiveTip(tx *types.Transaction, baseFee uint64) uint64 {
+	tip := tx.EffectiveTip(baseFee)
+
+	if tx.GasFeeCap == nil || tx.GasTipCap == nil {
+		// legacy transactions pay the whole gas price above the base fee as a tip
+		tip = tip.Sub(tip, new(big.Int).SetUint64(baseFee))
+	}
+
+	if tip.Sign() < 0 {
+		return 0
+	}
+
+	return tip.Uint64()
+}
+
 type overrideAccount struct {
 	Nonce     *argUint64                 `json:"nonce"`
 	Code      *argBytes                  `json:"code"`
@@ -675,6 +951,71 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 	return argUint64(highEnd), nil
 }
 
+// CreateAccessList creates an EIP-2930 access list for the given transaction,
+// based on the state at the referenced block
+func (e *Eth) CreateAccessList(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := DecodeTxn(arg, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+	if transaction.Gas == 0 {
+		transaction.Gas = header.GasLimit
+	}
+
+	// the sender, the receiver and the precompiles are always warm,
+	// so they are left out of the list unless any of their slots were accessed
+	forksInTime := e.store.GetForksInTime(header.Number)
+	excluded := append(
+		precompiled.NewPrecompiled().Addrs(&forksInTime),
+		transaction.From,
+	)
+
+	if transaction.To != nil {
+		excluded = append(excluded, *transaction.To)
+	} else {
+		excluded = append(excluded, crypto.CreateAddress(transaction.From, transaction.Nonce))
+	}
+
+	prevAccessList := runtime.NewAccessList()
+	prevAccessList.PrepareAccessList(transaction.From, transaction.To, nil, transaction.AccessList)
+
+	// Every new access list can touch new addresses and slots,
+	// so the transaction is applied until the access list is stable
+	for {
+		accessList := prevAccessList.ToTxAccessList(excluded...)
+
+		txn := transaction.Copy()
+		txn.AccessList = accessList
+
+		result, applyErr := e.store.ApplyTxn(header, txn, nil)
+		if applyErr != nil {
+			return nil, fmt.Errorf("failed to apply transaction: %w", applyErr)
+		}
+
+		if reflect.DeepEqual(result.AccessList.ToTxAccessList(excluded...), accessList) {
+			res := &accessListResult{
+				AccessList: accessList,
+				GasUsed:    argUint64(result.GasUsed),
+			}
+
+			if result.Failed() {
+				res.Error = result.Err.Error()
+			}
+
+			return res, nil
+		}
+
+		prevAccessList = result.AccessList
+	}
+}
+
 // GetFilterLogs returns an array of logs for the specified filter
 func (e *Eth) GetFilterLogs(id string) (interface{}, error) {
 	logFilter, err := e.filterManager.GetLogFilterFromID(id)
@@ -776,6 +1117,11 @@ func (e *Eth) NewBlockFilter() (interface{}, error) {
 	return e.filterManager.NewBlockFilter(nil), nil
 }
 
+// NewPendingTransactionFilter creates a filter in the node, to notify when new pending transactions arrive
+func (e *Eth) NewPendingTransactionFilter() (interface{}, error) {
+	return e.filterManager.NewPendingTxFilter(false, nil), nil
+}
+
 // GetFilterChanges is a polling method for a filter, which returns an array of logs which occurred since last poll.
 func (e *Eth) GetFilterChanges(id string) (interface{}, error) {
 	return e.filterManager.GetFilterChanges(id)
@@ -11,6 +11,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -611,6 +612,80 @@ func getExampleStore() *mockSpecialStore {
 // TestEth_EstimateGas_GasLimit tests eth_estimateGas, by using
 // the latest block gas limit for the upper bound, or the specified
 // gas limit in the transaction
+func TestEth_State_GetProof(t *testing.T) {
+	t.Parallel()
+
+	slot := types.StringToHash("0x1")
+	stateRoot := types.StringToHash("0x2")
+
+	store := &mockSpecialStore{
+		account: &mockAccount{
+			address: addr0,
+			account: &Account{
+				Balance: big.NewInt(100),
+				Nonce:   5,
+			},
+			storage: map[types.Hash][]byte{
+				slot: {0x3},
+			},
+		},
+		block: &types.Block{
+			Header: &types.Header{
+				Hash:      types.ZeroHash,
+				Number:    0,
+				StateRoot: stateRoot,
+			},
+		},
+	}
+
+	eth := newTestEthEndpoint(store)
+	latest := LatestBlockNumber
+	invalid := BlockNumber(0x1)
+
+	t.Run("returns account and storage proofs", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetProof(addr0, []types.Hash{slot}, BlockNumberOrHash{BlockNumber: &latest})
+		require.NoError(t, err)
+
+		assert.Equal(t, &accountProofResult{
+			Address:      addr0,
+			AccountProof: []argBytes{stateRoot.Bytes()},
+			Balance:      argBigPtr(big.NewInt(100)),
+			CodeHash:     types.EmptyCodeHash,
+			Nonce:        5,
+			StorageHash:  stateRoot,
+			StorageProof: []storageProofResult{
+				{
+					Key:   slot,
+					Value: argBigPtr(big.NewInt(3)),
+					Proof: []argBytes{slot.Bytes()},
+				},
+			},
+		}, res)
+	})
+
+	t.Run("returns empty account for missing account", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetProof(addr1, nil, BlockNumberOrHash{BlockNumber: &latest})
+		require.NoError(t, err)
+
+		//nolint:forcetypeassert
+		proof := res.(*accountProofResult)
+		assert.Equal(t, argUint64(0), proof.Nonce)
+		assert.Equal(t, types.EmptyRootHash, proof.StorageHash)
+		assert.Empty(t, proof.StorageProof)
+	})
+
+	t.Run("returns error for unknown block", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := eth.GetProof(addr0, nil, BlockNumberOrHash{BlockNumber: &invalid})
+		assert.Error(t, err)
+	})
+}
+
 func TestEth_EstimateGas_GasLimit(t *testing.T) {
 	t.Parallel()
 
@@ -765,6 +840,66 @@ func TestEth_EstimateGas_Errors(t *testing.T) {
 	assert.ErrorIs(t, estimateErr, ErrInsufficientFunds)
 }
 
+func TestEth_CreateAccessList(t *testing.T) {
+	t.Parallel()
+
+	var (
+		slot    = types.StringToHash("1")
+		storage = types.StringToAddress("100")
+		callee  = types.StringToAddress("101")
+	)
+
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	applied := 0
+
+	// The contract reads the slot of the storage contract, which then calls the callee
+	// only when the storage contract is in the access list already
+	store.applyTxnHook = func(
+		header *types.Header,
+		txn *types.Transaction,
+	) (*runtime.ExecutionResult, error) {
+		applied++
+
+		accessList := runtime.NewAccessList()
+		accessList.PrepareAccessList(txn.From, txn.To, nil, txn.AccessList)
+
+		if accessList.ContainsAddress(storage) {
+			accessList.AddAddress(callee)
+		}
+
+		accessList.AddSlot(storage, slot)
+
+		return &runtime.ExecutionResult{
+			GasUsed:    30000,
+			AccessList: accessList,
+		}, nil
+	}
+
+	res, err := ethEndpoint.CreateAccessList(constructMockTx(nil, nil), BlockNumberOrHash{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, applied)
+	assert.Equal(
+		t,
+		&accessListResult{
+			AccessList: types.TxAccessList{
+				{
+					Address:     storage,
+					StorageKeys: []types.Hash{slot},
+				},
+				{
+					Address:     callee,
+					StorageKeys: []types.Hash{},
+				},
+			},
+			GasUsed: argUint64(30000),
+		},
+		res,
+	)
+}
+
 type mockSpecialStore struct {
 	ethStore
 	account *mockAccount
@@ -836,6 +971,35 @@ func (m *mockSpecialStore) GetCode(root types.Hash, addr types.Address) ([]byte,
 	return m.account.code, nil
 }
 
+func (m *mockSpecialStore) GetProof(root types.Hash, addr types.Address, storageKeys []types.Hash) (*AccountProof, error) {
+	if root != m.block.Header.StateRoot {
+		return nil, ErrStateNotFound
+	}
+
+	proof := &AccountProof{
+		Balance:     big.NewInt(0),
+		CodeHash:    types.EmptyCodeHash,
+		StorageRoot: types.EmptyRootHash,
+		Proof:       [][]byte{root.Bytes()},
+	}
+
+	if m.account.address == addr {
+		proof.Nonce = m.account.account.Nonce
+		proof.Balance = m.account.account.Balance
+		proof.StorageRoot = root
+	}
+
+	for _, key := range storageKeys {
+		proof.StorageProofs = append(proof.StorageProofs, &StorageProof{
+			Key:   key,
+			Value: types.BytesToHash(m.account.storage[key]),
+			Proof: [][]byte{key.Bytes()},
+		})
+	}
+
+	return proof, nil
+}
+
 func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return chain.ForksInTime{}
 }
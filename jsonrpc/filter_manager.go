@@ -6,11 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"reflect"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	txpoolProto "github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -30,12 +33,15 @@ var (
 // defaultTimeout is the timeout to remove the filters that don't have a web socket stream
 var defaultTimeout = 1 * time.Minute
 
+// syncStatusInterval is the interval of checking the sync status for the syncing subscriptions
+const syncStatusInterval = time.Second
+
 const (
 	// The index in heap which is indicating the element is not in the heap
 	NoIndexInHeap = -1
 )
 
-// filter is an interface that BlockFilter and LogFilter implement
+// filter is an interface that all the filters (block, log, pending transaction and syncing) implement
 type filter interface {
 	// hasWSConn returns the flag indicating the filter has web socket stream
 	hasWSConn() bool
@@ -105,6 +111,61 @@ func (f *filterBase) writeMessageToWs(msg string) error {
 	)
 }
 
+// writeUpdatesToWs sends the given updates to websocket stream, one message per update
+func (f *filterBase) writeUpdatesToWs(updates []interface{}) error {
+	for _, update := range updates {
+		raw, err := json.Marshal(update)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(raw)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// maxQueuedFilterUpdates is the maximum number of the updates stored by a filter,
+// the oldest ones are dropped if the filter is not polled frequently enough
+const maxQueuedFilterUpdates = 4096
+
+// updateQueue stores the updates of a filter until they are taken
+type updateQueue struct {
+	sync.Mutex
+
+	updates []interface{}
+}
+
+// push appends the update to the queue, dropping the oldest one if the queue is full
+func (q *updateQueue) push(update interface{}) {
+	q.Lock()
+	defer q.Unlock()
+
+	if len(q.updates) >= maxQueuedFilterUpdates {
+		q.updates = q.updates[1:]
+	}
+
+	q.updates = append(q.updates, update)
+}
+
+// take returns all the queued updates and empties the queue
+func (q *updateQueue) take() []interface{} {
+	q.Lock()
+	defer q.Unlock()
+
+	updates := q.updates
+	q.updates = nil
+
+	if updates == nil {
+		// the polling clients expect an empty array instead of null
+		return []interface{}{}
+	}
+
+	return updates
+}
+
 // blockFilter is a filter to store the updates of block
 type blockFilter struct {
 	filterBase
@@ -212,6 +273,47 @@ func (f *logFilter) sendUpdates() error {
 	return nil
 }
 
+// pendingTxFilter is a filter to store the transactions added to the pending (executable) ones in the tx pool
+type pendingTxFilter struct {
+	filterBase
+	updateQueue
+
+	// fullTx indicates whether the updates are the full transactions instead of their hashes
+	fullTx bool
+}
+
+// getUpdates returns the stored pending transactions
+func (f *pendingTxFilter) getUpdates() (interface{}, error) {
+	return f.take(), nil
+}
+
+// sendUpdates writes the stored pending transactions to web socket stream
+func (f *pendingTxFilter) sendUpdates() error {
+	return f.writeUpdatesToWs(f.take())
+}
+
+// syncingFilter is a filter to store the changes of the sync status
+type syncingFilter struct {
+	filterBase
+	updateQueue
+}
+
+// getUpdates returns the stored sync statuses
+func (f *syncingFilter) getUpdates() (interface{}, error) {
+	return f.take(), nil
+}
+
+// sendUpdates writes the stored sync statuses to web socket stream
+func (f *syncingFilter) sendUpdates() error {
+	return f.writeUpdatesToWs(f.take())
+}
+
+// syncingResult is the notification of the syncing subscriptions while the node is syncing
+type syncingResult struct {
+	Syncing bool         `json:"syncing"`
+	Status  *progression `json:"status"`
+}
+
 // filterManagerStore provides methods required by FilterManager
 type filterManagerStore interface {
 	// Header returns the current header of the chain (genesis if empty)
@@ -228,6 +330,15 @@ type filterManagerStore interface {
 
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// SubscribeTxEvents subscribes for the tx pool events of the given types
+	SubscribeTxEvents(eventTypes ...txpoolProto.EventType) (<-chan *txpoolProto.TxPoolEvent, func())
+
+	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
+	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
+
+	// GetSyncProgression retrieves the current sync progression, if any
+	GetSyncProgression() *progress.Progression
 }
 
 // FilterManager manages all running filters
@@ -243,9 +354,22 @@ type FilterManager struct {
 	blockStream     *blockStream
 	blockRangeLimit uint64
 
+	// txEventCh receives the new pending transactions from the tx pool subscription,
+	// which exists only while there is a pending transaction filter
+	txEventCh            chan *txpoolProto.TxPoolEvent
+	cancelTxSubscription func()
+	pendingTxFilters     int
+
+	// syncStatus is the last sync status sent to the syncing filters
+	// (nil if the node is not syncing), accessed only by the worker
+	syncStatus *progression
+
 	filters  map[string]filter
 	timeouts timeHeapImpl
 
+	// subscriptions is the number of the filters with a WS connection
+	subscriptions int
+
 	updateCh chan struct{}
 	closeCh  chan struct{}
 }
@@ -258,6 +382,7 @@ func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeL
 		blockRangeLimit: blockRangeLimit,
 		filters:         make(map[string]filter),
 		timeouts:        timeHeapImpl{},
+		txEventCh:       make(chan *txpoolProto.TxPoolEvent),
 		updateCh:        make(chan struct{}),
 		closeCh:         make(chan struct{}),
 	}
@@ -273,6 +398,8 @@ func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeL
 	// start the head watcher
 	m.subscription = store.SubscribeEvents()
 
+	m.syncStatus = toSyncStatus(store.GetSyncProgression())
+
 	return m
 }
 
@@ -293,6 +420,9 @@ func (f *FilterManager) Run() {
 
 	var timeoutCh <-chan time.Time
 
+	syncTicker := time.NewTicker(syncStatusInterval)
+	defer syncTicker.Stop()
+
 	for {
 		// check for the next filter to be removed
 		filterID, filterExpiresAt := f.nextTimeoutFilter()
@@ -309,6 +439,18 @@ func (f *FilterManager) Run() {
 				f.logger.Error("failed to dispatch event", "err", err)
 			}
 
+		case evnt := <-f.txEventCh:
+			// new pending transaction
+			if err := f.dispatchPendingTx(types.StringToHash(evnt.TxHash)); err != nil {
+				f.logger.Error("failed to dispatch pending transaction", "err", err)
+			}
+
+		case <-syncTicker.C:
+			// check the sync status for the syncing filters
+			if err := f.dispatchSyncStatus(); err != nil {
+				f.logger.Error("failed to dispatch sync status", "err", err)
+			}
+
 		case <-timeoutCh:
 			// timeout for filter
 			// if filter still exists
@@ -328,9 +470,39 @@ func (f *FilterManager) Run() {
 
 // Close closed closeCh so that terminate worker
 func (f *FilterManager) Close() {
+	f.Lock()
+	f.unsubscribeTxEventsLocked()
+	f.Unlock()
+
 	close(f.closeCh)
 }
 
+// subscribeTxEventsLocked subscribes for the new pending transactions of the tx pool,
+// which are forwarded to the worker [NOT Thread Safe]
+func (f *FilterManager) subscribeTxEventsLocked() {
+	eventCh, cancel := f.store.SubscribeTxEvents(txpoolProto.EventType_PROMOTED)
+	f.cancelTxSubscription = cancel
+
+	go func() {
+		// the channel is closed once the subscription is cancelled
+		for evnt := range eventCh {
+			select {
+			case f.txEventCh <- evnt:
+			case <-f.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+// unsubscribeTxEventsLocked cancels the tx pool subscription, if any [NOT Thread Safe]
+func (f *FilterManager) unsubscribeTxEventsLocked() {
+	if f.cancelTxSubscription != nil {
+		f.cancelTxSubscription()
+		f.cancelTxSubscription = nil
+	}
+}
+
 // NewBlockFilter adds new BlockFilter
 func (f *FilterManager) NewBlockFilter(ws wsConn) string {
 	filter := &blockFilter{
@@ -359,6 +531,34 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 	return f.addFilter(filter)
 }
 
+// NewPendingTxFilter adds new PendingTxFilter, returning either
+// the hashes or the full pending transactions
+func (f *FilterManager) NewPendingTxFilter(fullTx bool, ws wsConn) string {
+	filter := &pendingTxFilter{
+		filterBase: newFilterBase(ws),
+		fullTx:     fullTx,
+	}
+
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
+	return f.addFilter(filter)
+}
+
+// NewSyncingFilter adds new SyncingFilter
+func (f *FilterManager) NewSyncingFilter(ws wsConn) string {
+	filter := &syncingFilter{
+		filterBase: newFilterBase(ws),
+	}
+
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
+	return f.addFilter(filter)
+}
+
 // Exists checks the filter with given ID exists
 func (f *FilterManager) Exists(id string) bool {
 	f.RLock()
@@ -551,6 +751,19 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 
 	delete(f.filters, id)
 
+	if filter.hasWSConn() {
+		f.subscriptions--
+	}
+
+	if _, ok := filter.(*pendingTxFilter); ok {
+		// the tx pool events are not needed without the pending transaction filters
+		if f.pendingTxFilters--; f.pendingTxFilters == 0 {
+			f.unsubscribeTxEventsLocked()
+		}
+	}
+
+	updateFilterMetrics(len(f.filters)-f.subscriptions, f.subscriptions)
+
 	if removed := f.timeouts.removeFilter(filter.getFilterBase()); removed {
 		f.emitSignalToUpdateCh()
 	}
@@ -591,8 +804,18 @@ func (f *FilterManager) addFilter(filter filter) string {
 	// Set timeout and add to heap if filter doesn't have web socket connection
 	if !filter.hasWSConn() {
 		f.addFilterTimeout(base)
+	} else {
+		f.subscriptions++
 	}
 
+	if _, ok := filter.(*pendingTxFilter); ok {
+		if f.pendingTxFilters++; f.pendingTxFilters == 1 {
+			f.subscribeTxEventsLocked()
+		}
+	}
+
+	updateFilterMetrics(len(f.filters)-f.subscriptions, f.subscriptions)
+
 	return base.id
 }
 
@@ -705,6 +928,103 @@ func (f *FilterManager) appendLogsToFilters(header *block) error {
 	return nil
 }
 
+// dispatchPendingTx is an event handler for new pending transaction event
+func (f *FilterManager) dispatchPendingTx(hash types.Hash) error {
+	if !f.appendPendingTxToFilters(hash) {
+		return nil
+	}
+
+	return f.flushWsFilters()
+}
+
+// appendPendingTxToFilters makes each PendingTxFilter append the pending transaction,
+// it returns false if there is no such filter
+func (f *FilterManager) appendPendingTxToFilters(hash types.Hash) bool {
+	f.RLock()
+	defer f.RUnlock()
+
+	if f.pendingTxFilters == 0 {
+		return false
+	}
+
+	// the full transaction is looked up once, only if it is needed by any filter
+	var tx *transaction
+
+	for _, filter := range f.filters {
+		txFilter, ok := filter.(*pendingTxFilter)
+		if !ok {
+			continue
+		}
+
+		if !txFilter.fullTx {
+			txFilter.push(hash)
+
+			continue
+		}
+
+		if tx == nil {
+			pendingTx, found := f.store.GetPendingTx(hash)
+			if !found {
+				// the transaction has already left the pool
+				continue
+			}
+
+			tx = toPendingTransaction(pendingTx)
+		}
+
+		txFilter.push(tx)
+	}
+
+	return true
+}
+
+// dispatchSyncStatus sends the sync status to the syncing filters if it has changed
+func (f *FilterManager) dispatchSyncStatus() error {
+	syncStatus := toSyncStatus(f.store.GetSyncProgression())
+
+	if reflect.DeepEqual(syncStatus, f.syncStatus) {
+		return nil
+	}
+
+	f.syncStatus = syncStatus
+
+	// the status of a finished sync is false, same as the result of eth_syncing
+	var update interface{} = false
+	if syncStatus != nil {
+		update = &syncingResult{Syncing: true, Status: syncStatus}
+	}
+
+	f.appendSyncStatusToFilters(update)
+
+	return f.flushWsFilters()
+}
+
+// appendSyncStatusToFilters makes each SyncingFilter append the sync status
+func (f *FilterManager) appendSyncStatusToFilters(update interface{}) {
+	f.RLock()
+	defer f.RUnlock()
+
+	for _, filter := range f.filters {
+		if syncFilter, ok := filter.(*syncingFilter); ok {
+			syncFilter.push(update)
+		}
+	}
+}
+
+// toSyncStatus converts the sync progression, it returns nil if the node is not syncing
+func toSyncStatus(syncProgression *progress.Progression) *progression {
+	if syncProgression == nil {
+		return nil
+	}
+
+	return &progression{
+		Type:          string(syncProgression.SyncType),
+		StartingBlock: argUint64(syncProgression.StartingBlock),
+		CurrentBlock:  argUint64(syncProgression.CurrentBlock),
+		HighestBlock:  argUint64(syncProgression.HighestBlock),
+	}
+}
+
 // flushWsFilters make each filters with web socket connection write the updates to web socket stream
 // flushWsFilters also removes the filters if flushWsFilters notices the connection is closed
 func (f *FilterManager) flushWsFilters() error {
@@ -2,16 +2,19 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
 	"net"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
@@ -546,6 +549,151 @@ func TestFilterWebsocket(t *testing.T) {
 	}
 }
 
+func TestFilterPendingTx(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	pollingID := m.NewPendingTxFilter(false, nil)
+
+	mock, msgCh := newMockWsConnWithMsgCh()
+	m.NewPendingTxFilter(true, mock)
+
+	tx := &types.Transaction{
+		Nonce:    1,
+		GasPrice: big.NewInt(1),
+		Gas:      21000,
+		Value:    big.NewInt(10),
+		V:        big.NewInt(1),
+		R:        big.NewInt(2),
+		S:        big.NewInt(3),
+	}
+	tx.ComputeHash()
+
+	store.emitPendingTx(tx)
+
+	select {
+	case msg := <-msgCh:
+		var notification struct {
+			Params struct {
+				Result transaction `json:"result"`
+			} `json:"params"`
+		}
+
+		require.NoError(t, json.Unmarshal(msg, &notification))
+		assert.Equal(t, tx.Hash, notification.Params.Result.Hash)
+		assert.Equal(t, argUint64(tx.Nonce), notification.Params.Result.Nonce)
+	case <-time.After(2 * time.Second):
+		t.Fatal("pending transaction not sent")
+	}
+
+	changes, err := m.GetFilterChanges(pollingID)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{tx.Hash}, changes)
+
+	// the updates are taken by the previous poll
+	changes, err = m.GetFilterChanges(pollingID)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestFilterPendingTx_Subscription(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	// the tx pool events are not subscribed without the pending transaction filters
+	m.NewBlockFilter(nil)
+	assert.Equal(t, int64(0), atomic.LoadInt64(&store.txSubscriptions))
+
+	first := m.NewPendingTxFilter(false, nil)
+	second := m.NewPendingTxFilter(true, nil)
+	assert.Equal(t, int64(1), atomic.LoadInt64(&store.txSubscriptions))
+
+	assert.True(t, m.Uninstall(first))
+	assert.Equal(t, int64(1), atomic.LoadInt64(&store.txSubscriptions))
+
+	assert.True(t, m.Uninstall(second))
+	assert.Equal(t, int64(0), atomic.LoadInt64(&store.txSubscriptions))
+}
+
+func TestUpdateQueue_Capped(t *testing.T) {
+	t.Parallel()
+
+	q := &updateQueue{}
+
+	for i := 0; i < maxQueuedFilterUpdates+10; i++ {
+		q.push(i)
+	}
+
+	updates := q.take()
+	require.Len(t, updates, maxQueuedFilterUpdates)
+
+	// the oldest updates are dropped
+	assert.Equal(t, 10, updates[0])
+	assert.Equal(t, maxQueuedFilterUpdates+9, updates[len(updates)-1])
+}
+
+func TestFilterSyncing(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	mock, msgCh := newMockWsConnWithMsgCh()
+	m.NewSyncingFilter(mock)
+
+	readResult := func() string {
+		t.Helper()
+
+		select {
+		case msg := <-msgCh:
+			var notification struct {
+				Params struct {
+					Result json.RawMessage `json:"result"`
+				} `json:"params"`
+			}
+
+			require.NoError(t, json.Unmarshal(msg, &notification))
+
+			return string(notification.Params.Result)
+		case <-time.After(3 * syncStatusInterval):
+			t.Fatal("sync status not sent")
+		}
+
+		return ""
+	}
+
+	store.syncProgression.Store(&progress.Progression{
+		SyncType:      progress.ChainSyncBulk,
+		StartingBlock: 1,
+		CurrentBlock:  5,
+		HighestBlock:  10,
+	})
+
+	assert.JSONEq(
+		t,
+		`{"syncing":true,"status":{"type":"bulk-sync","startingBlock":"0x1","currentBlock":"0x5","highestBlock":"0xa"}}`,
+		readResult(),
+	)
+
+	store.syncProgression.Store(nil)
+
+	assert.Equal(t, "false", readResult())
+}
+
 type mockWsConn struct {
 	SetFilterIDFn  func(string)
 	GetFilterIDFn  func() string
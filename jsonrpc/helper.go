@@ -237,6 +237,10 @@ func DecodeTxn(arg *txnArgs, store nonceGetter) (*types.Transaction, error) {
 		txn.To = arg.To
 	}
 
+	if arg.AccessList != nil {
+		txn.AccessList = arg.AccessList.Copy()
+	}
+
 	txn.ComputeHash()
 
 	return txn, nil
@@ -0,0 +1,155 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/0xPolygon/polygon-edge/helper/ipc"
+	"github.com/hashicorp/go-hclog"
+)
+
+// maxIPCConcurrentRequests is the maximum number of requests of a single IPC connection
+// handled at once, the next requests are not read until one of them is done
+const maxIPCConcurrentRequests = 16
+
+// ipcClient is the client identifier of the IPC requests, since they come from the local machine
+const ipcClient = "ipc"
+
+// ipcWrapper is a wrapping object for the IPC connection and logger.
+// It implements wsConn, so the IPC clients can use the subscriptions as well
+type ipcWrapper struct {
+	sync.Mutex
+
+	conn     net.Conn     // the actual IPC connection
+	logger   hclog.Logger // module logger
+	filterID string       // filter ID
+}
+
+func (w *ipcWrapper) SetFilterID(filterID string) {
+	w.filterID = filterID
+}
+
+func (w *ipcWrapper) GetFilterID() string {
+	return w.filterID
+}
+
+// WriteMessage writes out the message to the IPC peer, followed by a new line.
+// The message type is ignored, as the IPC connection is a plain stream
+func (w *ipcWrapper) WriteMessage(_ int, data []byte) error {
+	// the messages are delimited by new lines, so the (indented)
+	// JSON messages, e.g. the subscription notifications, are compacted
+	buf := new(bytes.Buffer)
+	if err := json.Compact(buf, data); err != nil {
+		buf.Reset()
+		buf.Write(data)
+	}
+
+	buf.WriteByte('\n')
+
+	w.Lock()
+	defer w.Unlock()
+
+	_, writeErr := w.conn.Write(buf.Bytes())
+	if writeErr != nil {
+		w.logger.Error(
+			fmt.Sprintf("Unable to write IPC message, %s", writeErr.Error()),
+		)
+	}
+
+	return writeErr
+}
+
+func (j *JSONRPC) setupIPC() error {
+	lis, err := ipc.Listen(j.config.IPCPath)
+	if err != nil {
+		return err
+	}
+
+	j.ipcListener = lis
+
+	j.logger.Info("ipc server started", "path", j.config.IPCPath)
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					j.logger.Error("closed ipc listener", "err", err)
+				}
+
+				return
+			}
+
+			go j.handleIPC(conn)
+		}
+	}()
+
+	return nil
+}
+
+// handleIPC serves the JSON-RPC requests sent over the IPC connection.
+// The requests (single or batch) are read as a stream of JSON values,
+// while the responses and the subscription notifications are written
+// as new line delimited JSON values
+func (j *JSONRPC) handleIPC(conn net.Conn) {
+	defer func() {
+		if err := conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			j.logger.Error(
+				fmt.Sprintf("Unable to gracefully close IPC connection, %s", err.Error()),
+			)
+		}
+	}()
+
+	wrapConn := &ipcWrapper{conn: conn, logger: j.logger}
+	decoder := json.NewDecoder(conn)
+	inFlight := make(chan struct{}, maxIPCConcurrentRequests)
+
+	updateConnectionMetrics(serverIPC, atomic.AddInt64(&j.ipcConnections, 1))
+	defer func() {
+		updateConnectionMetrics(serverIPC, atomic.AddInt64(&j.ipcConnections, -1))
+	}()
+
+	j.logger.Debug("IPC connection established")
+
+	for {
+		var message json.RawMessage
+
+		if err := decoder.Decode(&message); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+				j.logger.Debug("Closing IPC connection gracefully")
+			} else {
+				// the stream can not be recovered after a malformed message
+				j.logger.Error(fmt.Sprintf("Unable to read IPC message, %s", err.Error()))
+
+				resp, _ := NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
+
+				_ = wrapConn.WriteMessage(0, resp)
+			}
+
+			j.dispatcher.RemoveFilterByWs(wrapConn)
+
+			return
+		}
+
+		inFlight <- struct{}{}
+
+		go func() {
+			defer func() { <-inFlight }()
+
+			resp, handleErr := j.dispatcher.HandleWs(message, wrapConn, ipcClient)
+			if handleErr != nil {
+				j.logger.Error(fmt.Sprintf("Unable to handle IPC request, %s", handleErr.Error()))
+
+				resp, _ = NewRPCResponse(nil, "2.0", nil, NewInternalError(handleErr.Error())).Bytes()
+			}
+
+			_ = wrapConn.WriteMessage(0, resp)
+		}()
+	}
+}
@@ -1,12 +1,14 @@
 package jsonrpc
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/versioning"
@@ -37,15 +39,23 @@ func (s serverType) String() string {
 
 // JSONRPC is an API consensus
 type JSONRPC struct {
-	logger     hclog.Logger
-	config     *Config
-	dispatcher dispatcher
+	logger      hclog.Logger
+	config      *Config
+	dispatcher  dispatcher
+	ipcListener net.Listener
+
+	// clientLimiter limits the requests per client IP (nil if disabled)
+	clientLimiter *clientRateLimiter
+
+	// number of the open WS and IPC connections
+	wsConnections  int64
+	ipcConnections int64
 }
 
 type dispatcher interface {
 	RemoveFilterByWs(conn wsConn)
-	HandleWs(reqBody []byte, conn wsConn) ([]byte, error)
-	Handle(reqBody []byte) ([]byte, error)
+	HandleWs(reqBody []byte, conn wsConn, client string) ([]byte, error)
+	Handle(reqBody []byte, client string) ([]byte, error)
 }
 
 // JSONRPCStore defines all the methods required
@@ -68,6 +78,34 @@ type Config struct {
 	PriceLimit               uint64
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+
+	// IPCPath is the path of the IPC socket (IPC is disabled if empty)
+	IPCPath string
+
+	// RateLimit is the rate limit of the HTTP and WS requests per client IP
+	RateLimit RateLimit
+
+	// MethodRateLimits are the rate limits of the method groups, keyed by
+	// a namespace (e.g. debug) or a method name (e.g. eth_getLogs)
+	MethodRateLimits map[string]RateLimit
+
+	// AllowedMethods are the only namespaces or methods served (all if empty)
+	AllowedMethods []string
+
+	// DeniedMethods are the namespaces or methods which are never served
+	DeniedMethods []string
+
+	// MaxConcurrentRequests is the maximum number of requests processed at once (unlimited if 0)
+	MaxConcurrentRequests uint64
+
+	// SlowRequestThreshold is the duration after which the requests are logged as slow (disabled if 0)
+	SlowRequestThreshold time.Duration
+
+	// TLSConfig enables serving the HTTP and WS requests over TLS (if set)
+	TLSConfig *tls.Config
+
+	// JWTSecret enables the JWT authentication of the HTTP and WS requests (if set)
+	JWTSecret []byte
 }
 
 // NewJSONRPC returns the JSONRPC http server
@@ -81,6 +119,11 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 			priceLimit:              config.PriceLimit,
 			jsonRPCBatchLengthLimit: config.BatchLengthLimit,
 			blockRangeLimit:         config.BlockRangeLimit,
+			allowedMethods:          config.AllowedMethods,
+			deniedMethods:           config.DeniedMethods,
+			methodRateLimits:        config.MethodRateLimits,
+			maxConcurrentRequests:   config.MaxConcurrentRequests,
+			slowRequestThreshold:    config.SlowRequestThreshold,
 		},
 	)
 
@@ -94,22 +137,47 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 		dispatcher: d,
 	}
 
+	if config.RateLimit.Enabled() {
+		srv.clientLimiter = newClientRateLimiter(config.RateLimit)
+	}
+
 	// start http server
 	if err := srv.setupHTTP(); err != nil {
 		return nil, err
 	}
 
+	// start ipc server
+	if srv.config.IPCPath != "" {
+		if err := srv.setupIPC(); err != nil {
+			return nil, err
+		}
+	}
+
 	return srv, nil
 }
 
-func (j *JSONRPC) setupHTTP() error {
-	j.logger.Info("http server started", "addr", j.config.Addr.String())
+// Close stops the IPC server and removes its socket
+func (j *JSONRPC) Close() error {
+	if j.ipcListener == nil {
+		return nil
+	}
+
+	return j.ipcListener.Close()
+}
 
+func (j *JSONRPC) setupHTTP() error {
 	lis, err := net.Listen("tcp", j.config.Addr.String())
 	if err != nil {
 		return err
 	}
 
+	if j.config.TLSConfig != nil {
+		lis = tls.NewListener(lis, j.config.TLSConfig)
+	}
+
+	j.logger.Info("http server started", "addr", j.config.Addr.String(),
+		"tls", j.config.TLSConfig != nil, "jwt", len(j.config.JWTSecret) > 0)
+
 	// NewServeMux must be used, as it disables all debug features.
 	// For some strange reason, with DefaultServeMux debug/vars is always enabled (but not debug/pprof).
 	// If pprof need to be enabled, this should be DefaultServeMux
@@ -117,9 +185,11 @@ func (j *JSONRPC) setupHTTP() error {
 
 	// The middleware factory returns a handler, so we need to wrap the handler function properly.
 	jsonRPCHandler := http.HandlerFunc(j.handle)
-	mux.Handle("/", middlewareFactory(j.config)(jsonRPCHandler))
+	authMiddleware := jwtAuthMiddleware(j.config.JWTSecret)
+
+	mux.Handle("/", middlewareFactory(j.config, j.clientLimiter)(authMiddleware(jsonRPCHandler)))
 
-	mux.HandleFunc("/ws", j.handleWs)
+	mux.Handle("/ws", authMiddleware(http.HandlerFunc(j.handleWs)))
 
 	srv := http.Server{
 		Handler:           mux,
@@ -135,10 +205,21 @@ func (j *JSONRPC) setupHTTP() error {
 	return nil
 }
 
-// The middlewareFactory builds a middleware which enables CORS using the provided config.
-func middlewareFactory(config *Config) func(http.Handler) http.Handler {
+// The middlewareFactory builds a middleware which enables CORS using the provided config
+// and rejects the requests of the clients exceeding the rate limit (if the limiter is set).
+func middlewareFactory(config *Config, limiter *clientRateLimiter) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if limiter != nil && !limiter.allow(clientIP(r)) {
+				resp, _ := NewRPCResponse(nil, "2.0", nil, errRateLimitExceeded).Bytes()
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write(resp)
+
+				return
+			}
+
 			origin := r.Header.Get("Origin")
 
 			for _, allowedOrigin := range config.AccessControlAllowOrigin {
@@ -159,6 +240,19 @@ func middlewareFactory(config *Config) func(http.Handler) http.Handler {
 	}
 }
 
+// clientIP returns the IP address of the client sending the request.
+// It is taken from the remote address of the connection only,
+// the X-Forwarded-For header is ignored, since it can be set by any client.
+// Hence, all the requests coming through a reverse proxy share the limits of the proxy address
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
 // wsUpgrader defines upgrade parameters for the WS connection
 var wsUpgrader = websocket.Upgrader{
 	// Uses the default HTTP buffer sizes for Read / Write buffers.
@@ -230,6 +324,12 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 	}(ws)
 
 	wrapConn := &wsWrapper{ws: ws, logger: j.logger}
+	client := clientIP(req)
+
+	updateConnectionMetrics(serverWS, atomic.AddInt64(&j.wsConnections, 1))
+	defer func() {
+		updateConnectionMetrics(serverWS, atomic.AddInt64(&j.wsConnections, -1))
+	}()
 
 	j.logger.Info("Websocket connection established")
 	// Run the listen loop
@@ -254,21 +354,30 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 			break
 		}
 
-		if isSupportedWSType(msgType) {
-			go func() {
-				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
-				if handleErr != nil {
-					j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
-
-					_ = wrapConn.WriteMessage(
-						msgType,
-						[]byte(fmt.Sprintf("WS Handle error: %s", handleErr.Error())),
-					)
-				} else {
-					_ = wrapConn.WriteMessage(msgType, resp)
-				}
-			}()
+		if !isSupportedWSType(msgType) {
+			continue
 		}
+
+		if j.clientLimiter != nil && !j.clientLimiter.allow(client) {
+			resp, _ := NewRPCResponse(nil, "2.0", nil, errRateLimitExceeded).Bytes()
+			_ = wrapConn.WriteMessage(msgType, resp)
+
+			continue
+		}
+
+		go func() {
+			resp, handleErr := j.dispatcher.HandleWs(message, wrapConn, client)
+			if handleErr != nil {
+				j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
+
+				_ = wrapConn.WriteMessage(
+					msgType,
+					[]byte(fmt.Sprintf("WS Handle error: %s", handleErr.Error())),
+				)
+			} else {
+				_ = wrapConn.WriteMessage(msgType, resp)
+			}
+		}()
 	}
 }
 
@@ -303,7 +412,7 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	// log request
 	j.logger.Debug("handle", "request", string(data))
 
-	resp, err := j.dispatcher.Handle(data)
+	resp, err := j.dispatcher.Handle(data, clientIP(req))
 
 	if err != nil {
 		_, _ = w.Write([]byte(err.Error()))
@@ -1,14 +1,24 @@
 package jsonrpc
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
+	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/0xPolygon/polygon-edge/helper/ipc"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/versioning"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/go-hclog"
 )
@@ -32,6 +42,178 @@ func TestHTTPServer(t *testing.T) {
 	}
 }
 
+// blockingDispatcher blocks the WS requests until released, counting the ones in progress
+type blockingDispatcher struct {
+	dispatcher
+
+	inProgress    int64
+	maxInProgress int64
+	releaseCh     chan struct{}
+}
+
+func (d *blockingDispatcher) HandleWs(_ []byte, _ wsConn, _ string) ([]byte, error) {
+	n := atomic.AddInt64(&d.inProgress, 1)
+	defer atomic.AddInt64(&d.inProgress, -1)
+
+	for {
+		max := atomic.LoadInt64(&d.maxInProgress)
+		if n <= max || atomic.CompareAndSwapInt64(&d.maxInProgress, max, n) {
+			break
+		}
+	}
+
+	<-d.releaseCh
+
+	return []byte(`{}`), nil
+}
+
+func (d *blockingDispatcher) RemoveFilterByWs(_ wsConn) {}
+
+func TestIPCServer_ConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	d := &blockingDispatcher{releaseCh: make(chan struct{})}
+	srv := &JSONRPC{logger: hclog.NewNullLogger(), config: &Config{}, dispatcher: d}
+
+	server, client := net.Pipe()
+	defer client.Close()
+
+	go srv.handleIPC(server)
+
+	requests := 2 * maxIPCConcurrentRequests
+
+	go func() {
+		for i := 0; i < requests; i++ {
+			_, _ = client.Write([]byte(`{"jsonrpc":"2.0","method":"eth_chainId","id":1}`))
+		}
+	}()
+
+	// the responses are read, so that the released requests don't block on writing them
+	go func() {
+		_, _ = io.Copy(io.Discard, client)
+	}()
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&d.inProgress) == maxIPCConcurrentRequests
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for i := 0; i < requests; i++ {
+		d.releaseCh <- struct{}{}
+	}
+
+	assert.Equal(t, int64(maxIPCConcurrentRequests), atomic.LoadInt64(&d.maxInProgress))
+}
+
+func TestIPCServer(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+	port, err := tests.GetFreePort()
+	require.NoError(t, err)
+
+	config := &Config{
+		Store:   store,
+		Addr:    &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port},
+		ChainID: 100,
+		IPCPath: filepath.Join(t.TempDir(), "jsonrpc.ipc"),
+	}
+
+	srv, err := NewJSONRPC(hclog.NewNullLogger(), config)
+	require.NoError(t, err)
+
+	defer srv.Close()
+
+	conn, err := ipc.DialTimeout(config.IPCPath, 5*time.Second)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+
+	readResponse := func(t *testing.T) map[string]interface{} {
+		t.Helper()
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+
+		var resp map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(line, &resp))
+
+		return resp
+	}
+
+	// requests are not required to be new line delimited
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`))
+	require.NoError(t, err)
+
+	resp := readResponse(t)
+	assert.Equal(t, float64(1), resp["id"])
+	assert.Equal(t, "0x64", resp["result"])
+
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":2,"method":"eth_subscribe","params":["newHeads"]}`))
+	require.NoError(t, err)
+
+	resp = readResponse(t)
+	assert.Equal(t, float64(2), resp["id"])
+
+	subscriptionID, ok := resp["result"].(string)
+	require.True(t, ok)
+
+	store.emitEvent(&mockEvent{
+		NewChain: []*mockHeader{
+			{
+				header: &types.Header{
+					Hash: types.StringToHash("1"),
+				},
+			},
+		},
+	})
+
+	resp = readResponse(t)
+	assert.Equal(t, "eth_subscription", resp["method"])
+
+	params, ok := resp["params"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, subscriptionID, params["subscription"])
+}
+
+func TestMiddlewareFactory_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	limiter := newClientRateLimiter(RateLimit{Rate: 0.001, Burst: 1})
+	handler := middlewareFactory(&Config{}, limiter)(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	serve := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = remoteAddr
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusOK, serve("10.0.0.1:1000").Code)
+
+	// the same IP with a different port is the same client
+	recorder := serve("10.0.0.1:2000")
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+
+	var resp ErrorResponse
+
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	assert.Equal(t, -32005, resp.Error.Code)
+
+	assert.Equal(t, http.StatusOK, serve("10.0.0.2:1000").Code)
+}
+
 func Test_handleGetRequest(t *testing.T) {
 	var (
 		chainName = "polygon-edge-test"
@@ -0,0 +1,174 @@
+package jsonrpc
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// clientLimiterTTL is the time after which the rate limiter of an inactive client is released
+const clientLimiterTTL = 10 * time.Minute
+
+// RateLimit is the configuration of a token bucket rate limiter
+type RateLimit struct {
+	// Rate is the number of requests per second (the limit is disabled if 0)
+	Rate float64
+
+	// Burst is the maximum number of requests allowed at once
+	// (the rate rounded up if not set)
+	Burst int
+}
+
+// Enabled reports whether the rate limit is set
+func (l RateLimit) Enabled() bool {
+	return l.Rate > 0
+}
+
+func (l RateLimit) newLimiter() *rate.Limiter {
+	burst := l.Burst
+	if burst <= 0 {
+		burst = int(math.Ceil(l.Rate))
+	}
+
+	return rate.NewLimiter(rate.Limit(l.Rate), burst)
+}
+
+// clientLimiter is the rate limiter of a single client
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// clientRateLimiter limits the requests of every client (IP) separately
+type clientRateLimiter struct {
+	lock sync.Mutex
+
+	limit     RateLimit
+	clients   map[string]*clientLimiter
+	lastPrune time.Time
+}
+
+func newClientRateLimiter(limit RateLimit) *clientRateLimiter {
+	return &clientRateLimiter{
+		limit:     limit,
+		clients:   make(map[string]*clientLimiter),
+		lastPrune: time.Now(),
+	}
+}
+
+// allow reports whether the client can send a request now
+func (l *clientRateLimiter) allow(client string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+
+	if now.Sub(l.lastPrune) > clientLimiterTTL {
+		l.prune(now)
+	}
+
+	c, ok := l.clients[client]
+	if !ok {
+		c = &clientLimiter{limiter: l.limit.newLimiter()}
+		l.clients[client] = c
+	}
+
+	c.lastSeen = now
+
+	return c.limiter.AllowN(now, 1)
+}
+
+// prune releases the limiters of the inactive clients
+func (l *clientRateLimiter) prune(now time.Time) {
+	for client, c := range l.clients {
+		if now.Sub(c.lastSeen) > clientLimiterTTL {
+			delete(l.clients, client)
+		}
+	}
+
+	l.lastPrune = now
+}
+
+// methodMatcher matches the JSON-RPC methods either by their
+// namespace (e.g. debug) or by their full name (e.g. eth_getLogs)
+type methodMatcher map[string]struct{}
+
+func newMethodMatcher(entries []string) methodMatcher {
+	m := make(methodMatcher, len(entries))
+
+	for _, entry := range entries {
+		m[strings.TrimSuffix(entry, "_")] = struct{}{}
+	}
+
+	return m
+}
+
+// match returns the most specific entry matching the method
+func (m methodMatcher) match(method string) (string, bool) {
+	if _, ok := m[method]; ok {
+		return method, true
+	}
+
+	namespace := strings.SplitN(method, "_", 2)[0]
+	if _, ok := m[namespace]; ok {
+		return namespace, true
+	}
+
+	return "", false
+}
+
+// methodGuard decides whether the JSON-RPC methods can be served
+// based on the allowed / denied methods and the rate limits of the method groups.
+// The rate limits of the method groups are applied to every client separately
+type methodGuard struct {
+	allowed methodMatcher
+	denied  methodMatcher
+
+	groups   methodMatcher
+	limiters map[string]*clientRateLimiter
+}
+
+func newMethodGuard(allowed, denied []string, limits map[string]RateLimit) *methodGuard {
+	g := &methodGuard{
+		allowed:  newMethodMatcher(allowed),
+		denied:   newMethodMatcher(denied),
+		groups:   make(methodMatcher, len(limits)),
+		limiters: make(map[string]*clientRateLimiter, len(limits)),
+	}
+
+	for group, limit := range limits {
+		if !limit.Enabled() {
+			continue
+		}
+
+		group = strings.TrimSuffix(group, "_")
+
+		g.groups[group] = struct{}{}
+		g.limiters[group] = newClientRateLimiter(limit)
+	}
+
+	return g
+}
+
+// check returns an error if the method is disabled
+// or the client has exceeded the rate limit of the method
+func (g *methodGuard) check(method, client string) Error {
+	if _, denied := g.denied.match(method); denied {
+		return NewMethodNotFoundError(method)
+	}
+
+	if len(g.allowed) > 0 {
+		if _, allowed := g.allowed.match(method); !allowed {
+			return NewMethodNotFoundError(method)
+		}
+	}
+
+	if group, ok := g.groups.match(method); ok && !g.limiters[group].allow(client) {
+		return NewLimitExceededError("rate limit exceeded for " + group)
+	}
+
+	return nil
+}
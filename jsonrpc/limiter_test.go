@@ -0,0 +1,50 @@
+package jsonrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	limiter := newClientRateLimiter(RateLimit{Rate: 0.001, Burst: 2})
+
+	assert.True(t, limiter.allow("10.0.0.1"))
+	assert.True(t, limiter.allow("10.0.0.1"))
+	assert.False(t, limiter.allow("10.0.0.1"))
+
+	// every client has its own bucket
+	assert.True(t, limiter.allow("10.0.0.2"))
+
+	// inactive clients are released
+	limiter.prune(time.Now().Add(clientLimiterTTL + time.Second))
+	assert.Empty(t, limiter.clients)
+}
+
+func TestMethodMatcher(t *testing.T) {
+	t.Parallel()
+
+	matcher := newMethodMatcher([]string{"debug_", "eth_getLogs", "txpool"})
+
+	cases := []struct {
+		method string
+		entry  string
+		match  bool
+	}{
+		{"debug_traceBlock", "debug", true},
+		{"eth_getLogs", "eth_getLogs", true},
+		{"txpool_status", "txpool", true},
+		{"eth_getBalance", "", false},
+		{"debugger_test", "", false},
+	}
+
+	for _, c := range cases {
+		entry, match := matcher.match(c.method)
+
+		assert.Equal(t, c.match, match, c.method)
+		assert.Equal(t, c.entry, entry, c.method)
+	}
+}
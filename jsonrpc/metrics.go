@@ -0,0 +1,52 @@
+package jsonrpc
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/armon/go-metrics"
+)
+
+const (
+	// jsonRPCMetrics is a prefix used for the JSON-RPC related metrics
+	jsonRPCMetrics = "jsonrpc"
+
+	// unknownMethod is the method label of the requests for the methods
+	// which are not served, so that the label values remain bounded
+	unknownMethod = "unknown"
+
+	// maxSlowRequestParamsLength is the maximum length of the params logged for a slow request
+	maxSlowRequestParamsLength = 512
+)
+
+// updateRequestMetrics updates the count, the latency and the errors of the method requests
+func updateRequestMetrics(method string, start time.Time, err Error) {
+	labels := []metrics.Label{{Name: "method", Value: method}}
+
+	metrics.IncrCounterWithLabels([]string{jsonRPCMetrics, "requests"}, 1, labels)
+	metrics.MeasureSinceWithLabels([]string{jsonRPCMetrics, "request_duration"}, start, labels)
+
+	if err != nil {
+		metrics.IncrCounterWithLabels(
+			[]string{jsonRPCMetrics, "errors"},
+			1,
+			append(labels, metrics.Label{Name: "code", Value: strconv.Itoa(err.ErrorCode())}),
+		)
+	}
+}
+
+// updateFilterMetrics updates the number of the installed filters,
+// polled by the clients and pushed to the subscribers respectively
+func updateFilterMetrics(filters, subscriptions int) {
+	metrics.SetGauge([]string{jsonRPCMetrics, "filters"}, float32(filters))
+	metrics.SetGauge([]string{jsonRPCMetrics, "subscriptions"}, float32(subscriptions))
+}
+
+// updateConnectionMetrics updates the number of the open connections of the given transport
+func updateConnectionMetrics(transport serverType, open int64) {
+	metrics.SetGaugeWithLabels(
+		[]string{jsonRPCMetrics, "open_connections"},
+		float32(open),
+		[]metrics.Label{{Name: "transport", Value: transport.String()}},
+	)
+}
@@ -3,8 +3,11 @@ package jsonrpc
 import (
 	"math/big"
 	"sync"
+	"sync/atomic"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	txpoolProto "github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -50,6 +53,12 @@ type mockStore struct {
 	receipts     map[types.Hash][]*types.Receipt
 	accounts     map[types.Address]*Account
 
+	txEvents        chan *txpoolProto.TxPoolEvent
+	txSubscriptions int64
+	pendingTxsLock  sync.Mutex
+	pendingTxs      map[types.Hash]*types.Transaction
+	syncProgression atomic.Pointer[progress.Progression]
+
 	// headers is the list of historical headers
 	historicalHeaders []*types.Header
 }
@@ -59,6 +68,8 @@ func newMockStore() *mockStore {
 		header:       &types.Header{Number: 0},
 		subscription: blockchain.NewMockSubscription(),
 		accounts:     map[types.Address]*Account{},
+		txEvents:     make(chan *txpoolProto.TxPoolEvent),
+		pendingTxs:   map[types.Hash]*types.Transaction{},
 	}
 	m.addHeader(m.header)
 
@@ -108,6 +119,39 @@ func (m *mockStore) emitEvent(evnt *mockEvent) {
 	m.subscription.Push(bEvnt)
 }
 
+// emitPendingTx adds the transaction to the pending ones and sends its tx pool event
+func (m *mockStore) emitPendingTx(tx *types.Transaction) {
+	m.pendingTxsLock.Lock()
+	m.pendingTxs[tx.Hash] = tx
+	m.pendingTxsLock.Unlock()
+
+	m.txEvents <- &txpoolProto.TxPoolEvent{
+		Type:   txpoolProto.EventType_PROMOTED,
+		TxHash: tx.Hash.String(),
+	}
+}
+
+func (m *mockStore) SubscribeTxEvents(...txpoolProto.EventType) (<-chan *txpoolProto.TxPoolEvent, func()) {
+	atomic.AddInt64(&m.txSubscriptions, 1)
+
+	return m.txEvents, func() {
+		atomic.AddInt64(&m.txSubscriptions, -1)
+	}
+}
+
+func (m *mockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
+	m.pendingTxsLock.Lock()
+	defer m.pendingTxsLock.Unlock()
+
+	tx, ok := m.pendingTxs[txHash]
+
+	return tx, ok
+}
+
+func (m *mockStore) GetSyncProgression() *progress.Progression {
+	return m.syncProgression.Load()
+}
+
 func (m *mockStore) GetAccount(root types.Hash, addr types.Address) (*Account, error) {
 	if acc, ok := m.accounts[addr]; ok {
 		return acc, nil
@@ -197,6 +241,64 @@ func (m *mockStore) GetStateSyncProof(stateSyncID uint64) (types.Proof, error) {
 	return ssp, nil
 }
 
+func (m *mockStore) GetStateSyncStatus(stateSyncID uint64) (*StateSyncStatus, error) {
+	return &StateSyncStatus{
+		ID:          stateSyncID,
+		BlockNumber: 10,
+		Status:      "pending",
+		Attempts:    2,
+		LastError:   "execution reverted",
+		NextAttempt: 1700000000,
+	}, nil
+}
+
+func (m *mockStore) GetPendingStateSyncs() ([]*StateSyncStatus, error) {
+	status, _ := m.GetStateSyncStatus(1)
+
+	return []*StateSyncStatus{status}, nil
+}
+
+func (m *mockStore) GetStateSyncsByReceiver(
+	receiver types.Address, fromID uint64, limit int) ([]*types.BridgeEvent, error) {
+	stateSyncs := make([]*types.BridgeEvent, 0, limit)
+
+	for id := fromID; id <= 4 && len(stateSyncs) < limit; id++ {
+		if id < 3 {
+			continue
+		}
+
+		stateSyncs = append(stateSyncs, &types.BridgeEvent{Type: types.StateSyncBridgeEvent, ID: id,
+			Sender: types.StringToAddress("1"), Receiver: receiver, Status: types.BridgeEventExecuted})
+	}
+
+	return stateSyncs, nil
+}
+
+func (m *mockStore) GetExitsBySender(sender types.Address, fromID uint64, limit int) ([]*types.BridgeEvent, error) {
+	exits := make([]*types.BridgeEvent, 0, limit)
+
+	for id := fromID; id <= 5 && len(exits) < limit; id++ {
+		if id < 4 {
+			continue
+		}
+
+		exits = append(exits, &types.BridgeEvent{Type: types.ExitBridgeEvent, ID: id, Sender: sender,
+			Receiver: types.StringToAddress("2"), Status: types.BridgeEventCheckpointed})
+	}
+
+	return exits, nil
+}
+
+func (m *mockStore) GetBridgeEvent(eventType types.BridgeEventType, id uint64) (*types.BridgeEvent, error) {
+	return &types.BridgeEvent{
+		Type:     eventType,
+		ID:       id,
+		Sender:   types.StringToAddress("1"),
+		Receiver: types.StringToAddress("2"),
+		Status:   types.BridgeEventPending,
+	}, nil
+}
+
 func (m *mockStore) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
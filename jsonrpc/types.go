@@ -15,23 +15,24 @@ type transactionOrHash interface {
 }
 
 type transaction struct {
-	Nonce       argUint64      `json:"nonce"`
-	GasPrice    argBig         `json:"gasPrice"`
-	GasTipCap   *argBig        `json:"gasTipCap,omitempty"`
-	GasFeeCap   *argBig        `json:"gasFeeCap,omitempty"`
-	Gas         argUint64      `json:"gas"`
-	To          *types.Address `json:"to"`
-	Value       argBig         `json:"value"`
-	Input       argBytes       `json:"input"`
-	V           argBig         `json:"v"`
-	R           argBig         `json:"r"`
-	S           argBig         `json:"s"`
-	Hash        types.Hash     `json:"hash"`
-	From        types.Address  `json:"from"`
-	BlockHash   *types.Hash    `json:"blockHash"`
-	BlockNumber *argUint64     `json:"blockNumber"`
-	TxIndex     *argUint64     `json:"transactionIndex"`
-	Type        argUint64      `json:"type"`
+	Nonce       argUint64          `json:"nonce"`
+	GasPrice    argBig             `json:"gasPrice"`
+	GasTipCap   *argBig            `json:"gasTipCap,omitempty"`
+	GasFeeCap   *argBig            `json:"gasFeeCap,omitempty"`
+	Gas         argUint64          `json:"gas"`
+	To          *types.Address     `json:"to"`
+	Value       argBig             `json:"value"`
+	Input       argBytes           `json:"input"`
+	V           argBig             `json:"v"`
+	R           argBig             `json:"r"`
+	S           argBig             `json:"s"`
+	Hash        types.Hash         `json:"hash"`
+	From        types.Address      `json:"from"`
+	BlockHash   *types.Hash        `json:"blockHash"`
+	BlockNumber *argUint64         `json:"blockNumber"`
+	TxIndex     *argUint64         `json:"transactionIndex"`
+	Type        argUint64          `json:"type"`
+	AccessList  types.TxAccessList `json:"accessList,omitempty"`
 }
 
 func (t transaction) getHash() types.Hash { return t.Hash }
@@ -80,6 +81,10 @@ func toTransaction(
 		res.GasFeeCap = &gasFeeCap
 	}
 
+	if t.Type.HasAccessList() {
+		res.AccessList = t.AccessList.Copy()
+	}
+
 	if blockNumber != nil {
 		res.BlockNumber = blockNumber
 	}
@@ -323,17 +328,59 @@ func encodeToHex(b []byte) []byte {
 
 // txnArgs is the transaction argument for the rpc endpoints
 type txnArgs struct {
-	From      *types.Address
-	To        *types.Address
-	Gas       *argUint64
-	GasPrice  *argBytes
-	GasTipCap *argBytes
-	GasFeeCap *argBytes
-	Value     *argBytes
-	Data      *argBytes
-	Input     *argBytes
-	Nonce     *argUint64
-	Type      *argUint64
+	From       *types.Address
+	To         *types.Address
+	Gas        *argUint64
+	GasPrice   *argBytes
+	GasTipCap  *argBytes
+	GasFeeCap  *argBytes
+	Value      *argBytes
+	Data       *argBytes
+	Input      *argBytes
+	Nonce      *argUint64
+	Type       *argUint64
+	AccessList *types.TxAccessList
+}
+
+// accessListResult is the result of the eth_createAccessList call
+type accessListResult struct {
+	AccessList types.TxAccessList `json:"accessList"`
+	GasUsed    argUint64          `json:"gasUsed"`
+	Error      string             `json:"error,omitempty"`
+}
+
+// accountProofResult is the result of the eth_getProof call
+type accountProofResult struct {
+	Address      types.Address        `json:"address"`
+	AccountProof []argBytes           `json:"accountProof"`
+	Balance      *argBig              `json:"balance"`
+	CodeHash     types.Hash           `json:"codeHash"`
+	Nonce        argUint64            `json:"nonce"`
+	StorageHash  types.Hash           `json:"storageHash"`
+	StorageProof []storageProofResult `json:"storageProof"`
+}
+
+type storageProofResult struct {
+	Key   types.Hash `json:"key"`
+	Value *argBig    `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
+func toArgBytesList(list [][]byte) []argBytes {
+	res := make([]argBytes, len(list))
+	for i, b := range list {
+		res[i] = argBytes(b)
+	}
+
+	return res
+}
+
+// feeHistoryResult is the result of the eth_feeHistory call
+type feeHistoryResult struct {
+	OldestBlock   argUint64     `json:"oldestBlock"`
+	BaseFeePerGas []argUint64   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio  []float64     `json:"gasUsedRatio"`
+	Reward        [][]argUint64 `json:"reward,omitempty"`
 }
 
 type progression struct {
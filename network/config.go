@@ -2,9 +2,11 @@ package network
 
 import (
 	"net"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -20,6 +22,10 @@ type Config struct {
 	MaxOutboundPeers int64                  // the maximum number of outbound peer connections
 	Chain            *chain.Chain           // the reference to the chain configuration
 	SecretsManager   secrets.SecretsManager // the secrets manager used for key storage
+	AllowList        []peer.ID              // if not empty, the only peers allowed to connect
+	DenyList         []peer.ID              // the peers never allowed to connect
+	BanThreshold     int64                  // the score at which a misbehaving peer gets banned
+	BanDuration      time.Duration          // the duration of the peer bans, zero bans peers permanently
 }
 
 func DefaultConfig() *Config {
@@ -36,5 +42,7 @@ func DefaultConfig() *Config {
 		// The default ratio for outbound / inbound connections is 0.25
 		MaxInboundPeers:  32,
 		MaxOutboundPeers: 8,
+		BanThreshold:     DefaultBanThreshold,
+		BanDuration:      DefaultBanDuration,
 	}
 }
@@ -22,6 +22,7 @@ const (
 
 type Topic struct {
 	logger hclog.Logger
+	server *Server // the networking server, used for penalizing the senders of malformed messages
 
 	topic     *pubsub.Topic
 	typ       reflect.Type
@@ -106,6 +107,7 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 			obj := t.createObj()
 			if err := proto.Unmarshal(msg.Data, obj); err != nil {
 				t.logger.Error("failed to unmarshal topic", "err", err)
+				t.server.ReportPeer(msg.GetFrom(), PenaltyMalformedMessage, "malformed gossip message")
 
 				return
 			}
@@ -123,6 +125,7 @@ func (s *Server) NewTopic(protoID string, obj proto.Message) (*Topic, error) {
 
 	tt := &Topic{
 		logger:  s.logger.Named(protoID),
+		server:  s,
 		topic:   topic,
 		typ:     reflect.TypeOf(obj).Elem(),
 		closeCh: make(chan struct{}),
@@ -0,0 +1,369 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+const (
+	// DefaultBanThreshold is the default score at which a misbehaving peer gets banned
+	DefaultBanThreshold int64 = -100
+
+	// DefaultBanDuration is the default duration of the bans issued by the peer scoring
+	DefaultBanDuration = time.Hour
+
+	// scoreRecoveryInterval is the time it takes a penalized peer to regain a single point of its score
+	scoreRecoveryInterval = 10 * time.Second
+
+	// bansFileName is the name of the file in the networking data directory the bans are persisted to
+	bansFileName = "bans.json"
+)
+
+// PeerPenalty is the amount by which the score of a misbehaving peer is lowered
+type PeerPenalty int64
+
+const (
+	// PenaltyMalformedMessage is the penalty for gossip messages that can't be decoded
+	PenaltyMalformedMessage PeerPenalty = 20
+
+	// PenaltyInvalidTx is the penalty for gossiping invalid transactions
+	PenaltyInvalidTx PeerPenalty = 10
+
+	// PenaltyInvalidConsensusMessage is the penalty for gossiping malformed consensus messages
+	PenaltyInvalidConsensusMessage PeerPenalty = 25
+
+	// PenaltyInvalidBlock is the penalty for serving blocks that fail verification during sync
+	PenaltyInvalidBlock PeerPenalty = 50
+)
+
+var (
+	ErrPeerNotBanned = errors.New("peer is not banned")
+	ErrBanSelf       = errors.New("unable to ban the local node")
+)
+
+// PeerBan is a ban of a peer
+type PeerBan struct {
+	PeerID peer.ID   `json:"peerId"` // the ID of the banned peer
+	Reason string    `json:"reason"` // the reason of the ban
+	Expiry time.Time `json:"expiry"` // the time the ban expires at, zero for permanent bans
+}
+
+// isActive checks if the ban is still in effect at the given time
+func (b *PeerBan) isActive(now time.Time) bool {
+	return b.Expiry.IsZero() || now.Before(b.Expiry)
+}
+
+// peerScore is the score of a peer, it recovers over time up to zero
+type peerScore struct {
+	value   int64
+	updated time.Time
+}
+
+// recover regains the points of the score for the time elapsed since the last update
+func (s *peerScore) recover(now time.Time) {
+	recovered := int64(now.Sub(s.updated) / scoreRecoveryInterval)
+	if recovered <= 0 {
+		return
+	}
+
+	s.value += recovered
+	if s.value > 0 {
+		s.value = 0
+	}
+
+	s.updated = s.updated.Add(time.Duration(recovered) * scoreRecoveryInterval)
+}
+
+// peerScoreBook keeps track of the peer scores and bans,
+// and gates the connections of banned and statically disallowed peers.
+// The bans are persisted in the networking data directory, so they survive restarts
+// (a node running without a data directory keeps them only in memory)
+type peerScoreBook struct {
+	logger hclog.Logger
+
+	// bansPath is the file the bans are persisted to, empty if they are kept only in memory
+	bansPath string
+
+	banThreshold int64         // the score at which a peer gets banned
+	banDuration  time.Duration // the duration of the issued bans, zero for permanent bans
+
+	allowList map[peer.ID]struct{} // if not empty, the only peers allowed to connect
+	denyList  map[peer.ID]struct{} // the peers never allowed to connect
+
+	scores map[peer.ID]*peerScore
+	bans   map[peer.ID]*PeerBan
+	lock   sync.Mutex
+
+	now func() time.Time
+}
+
+// newPeerScoreBook returns a new peer score book for the networking configuration,
+// with the bans persisted in the data directory, if any
+func newPeerScoreBook(logger hclog.Logger, config *Config) (*peerScoreBook, error) {
+	book := &peerScoreBook{
+		logger:       logger,
+		banThreshold: config.BanThreshold,
+		banDuration:  config.BanDuration,
+		allowList:    make(map[peer.ID]struct{}, len(config.AllowList)),
+		denyList:     make(map[peer.ID]struct{}, len(config.DenyList)),
+		scores:       make(map[peer.ID]*peerScore),
+		bans:         make(map[peer.ID]*PeerBan),
+		now:          time.Now,
+	}
+
+	for _, id := range config.AllowList {
+		book.allowList[id] = struct{}{}
+	}
+
+	for _, id := range config.DenyList {
+		book.denyList[id] = struct{}{}
+	}
+
+	if config.DataDir != "" {
+		book.bansPath = filepath.Join(config.DataDir, bansFileName)
+
+		if err := book.loadBans(); err != nil {
+			return nil, err
+		}
+	}
+
+	return book, nil
+}
+
+// loadBans reads the active persisted bans
+func (b *peerScoreBook) loadBans() error {
+	data, err := os.ReadFile(b.bansPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read peer bans: %w", err)
+	}
+
+	var bans []*PeerBan
+	if err := json.Unmarshal(data, &bans); err != nil {
+		return fmt.Errorf("failed to decode peer bans %s: %w", b.bansPath, err)
+	}
+
+	now := b.now()
+
+	for _, ban := range bans {
+		if ban.isActive(now) {
+			b.bans[ban.PeerID] = ban
+		}
+	}
+
+	return nil
+}
+
+// persistBansLocked writes the active bans to the bans file, if the bans are persisted [NOT Thread Safe]
+func (b *peerScoreBook) persistBansLocked() {
+	if b.bansPath == "" {
+		return
+	}
+
+	now := b.now()
+	bans := make([]*PeerBan, 0, len(b.bans))
+
+	for _, ban := range b.bans {
+		if ban.isActive(now) {
+			bans = append(bans, ban)
+		}
+	}
+
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].PeerID < bans[j].PeerID
+	})
+
+	if err := writeBansFile(b.bansPath, bans); err != nil {
+		b.logger.Error("failed to persist peer bans", "err", err)
+	}
+}
+
+// writeBansFile replaces the bans file atomically
+func writeBansFile(path string, bans []*PeerBan) error {
+	data, err := json.Marshal(bans)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// penalize lowers the score of the peer, and bans it if the score drops to the ban threshold.
+// Returns the issued ban, if any [Thread safe]
+func (b *peerScoreBook) penalize(id peer.ID, penalty PeerPenalty, reason string) *PeerBan {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.now()
+
+	if ban, ok := b.bans[id]; ok && ban.isActive(now) {
+		return nil
+	}
+
+	score, ok := b.scores[id]
+	if !ok {
+		score = &peerScore{updated: now}
+		b.scores[id] = score
+	}
+
+	score.recover(now)
+	score.value -= int64(penalty)
+
+	if score.value > b.banThreshold {
+		return nil
+	}
+
+	return b.banLocked(id, b.banDuration, reason)
+}
+
+// ban bans the peer for the given duration, zero duration bans the peer permanently [Thread safe]
+func (b *peerScoreBook) ban(id peer.ID, duration time.Duration, reason string) *PeerBan {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.banLocked(id, duration, reason)
+}
+
+func (b *peerScoreBook) banLocked(id peer.ID, duration time.Duration, reason string) *PeerBan {
+	ban := &PeerBan{
+		PeerID: id,
+		Reason: reason,
+	}
+
+	if duration > 0 {
+		ban.Expiry = b.now().Add(duration)
+	}
+
+	b.bans[id] = ban
+
+	// the peer starts with a clean score once the ban is over
+	delete(b.scores, id)
+
+	b.persistBansLocked()
+
+	return ban
+}
+
+// unban lifts the ban of the peer [Thread safe]
+func (b *peerScoreBook) unban(id peer.ID) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	ban, ok := b.bans[id]
+	if !ok {
+		return ErrPeerNotBanned
+	}
+
+	delete(b.bans, id)
+
+	b.persistBansLocked()
+
+	if !ban.isActive(b.now()) {
+		return ErrPeerNotBanned
+	}
+
+	return nil
+}
+
+// bannedPeers returns the active bans, sorted by peer ID, and drops the expired ones [Thread safe]
+func (b *peerScoreBook) bannedPeers() []*PeerBan {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.now()
+	bans := make([]*PeerBan, 0, len(b.bans))
+
+	for id, ban := range b.bans {
+		if !ban.isActive(now) {
+			delete(b.bans, id)
+
+			continue
+		}
+
+		bans = append(bans, ban)
+	}
+
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].PeerID < bans[j].PeerID
+	})
+
+	return bans
+}
+
+// isAllowed checks if the peer is allowed to connect, i.e. it is not banned,
+// not denylisted, and it is allowlisted if the allowlist is set [Thread safe]
+func (b *peerScoreBook) isAllowed(id peer.ID) bool {
+	if _, denied := b.denyList[id]; denied {
+		return false
+	}
+
+	if len(b.allowList) > 0 {
+		if _, allowed := b.allowList[id]; !allowed {
+			return false
+		}
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	ban, ok := b.bans[id]
+	if !ok {
+		return true
+	}
+
+	if ban.isActive(b.now()) {
+		return false
+	}
+
+	delete(b.bans, id)
+
+	return true
+}
+
+// peerScoreBook gates the connections of the libp2p host
+var _ connmgr.ConnectionGater = (*peerScoreBook)(nil)
+
+// InterceptPeerDial rejects dialing disallowed peers
+func (b *peerScoreBook) InterceptPeerDial(id peer.ID) bool {
+	return b.isAllowed(id)
+}
+
+// InterceptAddrDial rejects dialing disallowed peers
+func (b *peerScoreBook) InterceptAddrDial(id peer.ID, _ multiaddr.Multiaddr) bool {
+	return b.isAllowed(id)
+}
+
+// InterceptAccept accepts all inbound connections, as the remote peer is not known yet
+func (b *peerScoreBook) InterceptAccept(network.ConnMultiaddrs) bool {
+	return true
+}
+
+// InterceptSecured rejects the connections of disallowed peers once the remote peer is known
+func (b *peerScoreBook) InterceptSecured(_ network.Direction, id peer.ID, _ network.ConnMultiaddrs) bool {
+	return b.isAllowed(id)
+}
+
+// InterceptUpgraded accepts all upgraded connections, as they were already secured
+func (b *peerScoreBook) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
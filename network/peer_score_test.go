@@ -0,0 +1,279 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPeerScoreBook returns a peer score book with a controllable clock
+func newTestPeerScoreBook(t *testing.T, config *Config) (*peerScoreBook, *time.Time) {
+	t.Helper()
+
+	now := time.Unix(1_000_000, 0)
+
+	book, err := newPeerScoreBook(hclog.NewNullLogger(), config)
+	require.NoError(t, err)
+
+	book.now = func() time.Time {
+		return now
+	}
+
+	return book, &now
+}
+
+func TestPeerScoreBook_Penalize(t *testing.T) {
+	t.Parallel()
+
+	const id = peer.ID("A")
+
+	book, now := newTestPeerScoreBook(t, &Config{
+		BanThreshold: -100,
+		BanDuration:  time.Hour,
+	})
+
+	// the peer gets banned once its score drops to the threshold
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, book.penalize(id, PenaltyInvalidConsensusMessage, "invalid"))
+	}
+
+	ban := book.penalize(id, PenaltyInvalidConsensusMessage, "invalid")
+	require.NotNil(t, ban)
+	assert.Equal(t, id, ban.PeerID)
+	assert.Equal(t, "invalid", ban.Reason)
+	assert.Equal(t, now.Add(time.Hour), ban.Expiry)
+	assert.False(t, book.isAllowed(id))
+	assert.Equal(t, []*PeerBan{ban}, book.bannedPeers())
+
+	// penalties of a banned peer don't issue new bans
+	assert.Nil(t, book.penalize(id, PenaltyInvalidBlock, "invalid block"))
+
+	// the ban expires
+	*now = now.Add(time.Hour)
+
+	assert.True(t, book.isAllowed(id))
+	assert.Empty(t, book.bannedPeers())
+
+	// the peer starts with a clean score after the ban
+	assert.Nil(t, book.penalize(id, PenaltyInvalidBlock, "invalid block"))
+}
+
+func TestPeerScoreBook_Recover(t *testing.T) {
+	t.Parallel()
+
+	const id = peer.ID("A")
+
+	book, now := newTestPeerScoreBook(t, &Config{
+		BanThreshold: -100,
+	})
+
+	assert.Nil(t, book.penalize(id, PenaltyInvalidBlock, "invalid block"))
+
+	// the score recovers over time
+	*now = now.Add(10 * scoreRecoveryInterval)
+
+	assert.Nil(t, book.penalize(id, PenaltyInvalidBlock, "invalid block"))
+	assert.Equal(t, int64(-90), book.scores[id].value)
+
+	// zero ban duration bans the peer permanently
+	ban := book.penalize(id, PenaltyMalformedMessage, "malformed message")
+	require.NotNil(t, ban)
+	assert.True(t, ban.Expiry.IsZero())
+
+	*now = now.Add(24 * 365 * time.Hour)
+
+	assert.False(t, book.isAllowed(id))
+}
+
+func TestPeerScoreBook_BanUnban(t *testing.T) {
+	t.Parallel()
+
+	const id = peer.ID("A")
+
+	book, now := newTestPeerScoreBook(t, DefaultConfig())
+
+	assert.ErrorIs(t, book.unban(id), ErrPeerNotBanned)
+
+	book.ban(id, time.Minute, "operator")
+	assert.False(t, book.isAllowed(id))
+
+	require.NoError(t, book.unban(id))
+	assert.True(t, book.isAllowed(id))
+	assert.Empty(t, book.bannedPeers())
+
+	// expired bans can't be lifted
+	book.ban(id, time.Minute, "operator")
+
+	*now = now.Add(time.Minute)
+
+	assert.ErrorIs(t, book.unban(id), ErrPeerNotBanned)
+}
+
+func TestPeerScoreBook_PersistBans(t *testing.T) {
+	t.Parallel()
+
+	ids := make([]peer.ID, 3)
+
+	for i := range ids {
+		key, _ := GenerateTestLibp2pKey(t)
+
+		id, err := peer.IDFromPrivateKey(key)
+		require.NoError(t, err)
+
+		ids[i] = id
+	}
+
+	config := &Config{DataDir: t.TempDir()}
+
+	book, err := newPeerScoreBook(hclog.NewNullLogger(), config)
+	require.NoError(t, err)
+
+	book.ban(ids[0], 0, "permanent")
+	book.ban(ids[1], time.Hour, "temporary")
+	book.ban(ids[2], time.Hour, "lifted")
+	require.NoError(t, book.unban(ids[2]))
+
+	// the bans survive the restart
+	restarted, err := newPeerScoreBook(hclog.NewNullLogger(), config)
+	require.NoError(t, err)
+
+	bans := make(map[peer.ID]*PeerBan)
+	for _, ban := range restarted.bannedPeers() {
+		bans[ban.PeerID] = ban
+	}
+
+	require.Len(t, bans, 2)
+	require.Contains(t, bans, ids[0])
+	require.Contains(t, bans, ids[1])
+
+	assert.True(t, bans[ids[0]].Expiry.IsZero())
+	assert.Equal(t, "permanent", bans[ids[0]].Reason)
+	assert.False(t, bans[ids[1]].Expiry.IsZero())
+
+	assert.True(t, restarted.isAllowed(ids[2]))
+}
+
+func TestPeerScoreBook_AllowDenyLists(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		allowList []peer.ID
+		denyList  []peer.ID
+		allowed   map[peer.ID]bool
+	}{
+		{
+			name:    "no lists",
+			allowed: map[peer.ID]bool{"A": true, "B": true},
+		},
+		{
+			name:     "denylist",
+			denyList: []peer.ID{"A"},
+			allowed:  map[peer.ID]bool{"A": false, "B": true},
+		},
+		{
+			name:      "allowlist",
+			allowList: []peer.ID{"A"},
+			allowed:   map[peer.ID]bool{"A": true, "B": false},
+		},
+		{
+			name:      "denylist overrides allowlist",
+			allowList: []peer.ID{"A", "B"},
+			denyList:  []peer.ID{"A"},
+			allowed:   map[peer.ID]bool{"A": false, "B": true},
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			book, err := newPeerScoreBook(hclog.NewNullLogger(), &Config{
+				AllowList: testCase.allowList,
+				DenyList:  testCase.denyList,
+			})
+			require.NoError(t, err)
+
+			for id, allowed := range testCase.allowed {
+				assert.Equal(t, allowed, book.isAllowed(id), id)
+				assert.Equal(t, allowed, book.InterceptPeerDial(id), id)
+				assert.Equal(t, allowed, book.InterceptSecured(0, id, nil), id)
+			}
+		})
+	}
+}
+
+func TestPeerBan(t *testing.T) {
+	servers, createErr := createServers(2, nil)
+	require.NoError(t, createErr)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	require.NoError(t, JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout))
+
+	// the local node can't be banned
+	assert.ErrorIs(t, servers[1].BanPeer(servers[1].host.ID(), 0, "self"), ErrBanSelf)
+
+	// banning the peer disconnects it
+	require.NoError(t, servers[1].BanPeer(servers[0].host.ID(), time.Hour, "test"))
+
+	disconnectCtx, disconnectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer disconnectFn()
+
+	_, err := WaitUntilPeerDisconnectsFrom(disconnectCtx, servers[1], servers[0].host.ID())
+	require.NoError(t, err)
+
+	bans := servers[1].BannedPeers()
+	require.Len(t, bans, 1)
+	assert.Equal(t, servers[0].host.ID(), bans[0].PeerID)
+
+	// the banning node refuses to dial the banned peer
+	smallTimeout := 5 * time.Second
+	assert.Error(t, JoinAndWait(servers[1], servers[0], smallTimeout, smallTimeout))
+	assert.False(t, servers[1].scores.InterceptSecured(0, servers[0].host.ID(), nil))
+
+	// the peer can reconnect once the ban is lifted
+	require.NoError(t, servers[1].UnbanPeer(servers[0].host.ID()))
+	assert.Empty(t, servers[1].BannedPeers())
+	assert.NoError(t, JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout))
+}
+
+func TestPeerDenyList(t *testing.T) {
+	key, dir := GenerateTestLibp2pKey(t)
+
+	deniedID, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	servers, createErr := createServers(2, map[int]*CreateServerParams{
+		0: {
+			ConfigCallback: func(c *Config) {
+				c.DataDir = dir
+			},
+		},
+		1: {
+			ConfigCallback: func(c *Config) {
+				c.DenyList = []peer.ID{deniedID}
+			},
+		},
+	})
+	require.NoError(t, createErr)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	require.Equal(t, deniedID, servers[0].host.ID())
+
+	smallTimeout := 5 * time.Second
+	assert.Error(t, JoinAndWait(servers[0], servers[1], smallTimeout, smallTimeout))
+	assert.Error(t, JoinAndWait(servers[1], servers[0], smallTimeout, smallTimeout))
+}
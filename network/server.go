@@ -88,6 +88,8 @@ type Server struct {
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
+
+	scores *peerScoreBook // the peer scores, bans, and allow and deny lists
 }
 
 // NewServer returns a new instance of the networking server
@@ -118,12 +120,19 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		return addrs
 	}
 
+	scores, err := newPeerScoreBook(logger, config)
+	if err != nil {
+		return nil, err
+	}
+
 	host, err := libp2p.New(
 		// Use noise as the encryption protocol
 		libp2p.Security(noise.ID, noise.New),
 		libp2p.ListenAddrs(listenAddr),
 		libp2p.AddrsFactory(addrsFactory),
 		libp2p.Identity(key),
+		// Reject the connections of banned and disallowed peers
+		libp2p.ConnectionGater(scores),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create libp2p stack: %w", err)
@@ -154,6 +163,7 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 			config.MaxInboundPeers,
 			config.MaxOutboundPeers,
 		),
+		scores: scores,
 	}
 
 	// start gossip protocol
@@ -660,6 +670,12 @@ func (s *Server) AddrInfo() *peer.AddrInfo {
 }
 
 func (s *Server) addToDialQueue(addr *peer.AddrInfo, priority common.DialPriority) {
+	if !s.scores.isAllowed(addr.ID) {
+		s.logger.Debug("skipping dial of disallowed peer", "id", addr.ID.String())
+
+		return
+	}
+
 	s.dialQueue.AddTask(addr, priority)
 	s.emitEvent(addr.ID, peerEvent.PeerAddedToDialQueue)
 }
@@ -0,0 +1,61 @@
+package network
+
+import (
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ReportPeer lowers the score of a misbehaving peer,
+// and bans and disconnects it once the score drops to the ban threshold [Thread safe]
+func (s *Server) ReportPeer(peerID peer.ID, penalty PeerPenalty, reason string) {
+	// the node receives its own gossip messages as well
+	if peerID == s.host.ID() {
+		return
+	}
+
+	s.logger.Debug("peer penalized", "id", peerID.String(), "penalty", penalty, "reason", reason)
+
+	ban := s.scores.penalize(peerID, penalty, reason)
+	if ban == nil {
+		return
+	}
+
+	s.logger.Warn("peer banned", "id", peerID.String(), "reason", reason, "expiry", ban.Expiry)
+	metrics.IncrCounter([]string{networkMetrics, "banned_peers"}, 1)
+
+	s.DisconnectFromPeer(peerID, reason)
+}
+
+// BanPeer bans and disconnects the peer for the given duration,
+// zero duration bans the peer permanently [Thread safe]
+func (s *Server) BanPeer(peerID peer.ID, duration time.Duration, reason string) error {
+	if peerID == s.host.ID() {
+		return ErrBanSelf
+	}
+
+	ban := s.scores.ban(peerID, duration, reason)
+
+	s.logger.Info("peer banned", "id", peerID.String(), "reason", reason, "expiry", ban.Expiry)
+
+	s.DisconnectFromPeer(peerID, reason)
+
+	return nil
+}
+
+// UnbanPeer lifts the ban of the peer [Thread safe]
+func (s *Server) UnbanPeer(peerID peer.ID) error {
+	if err := s.scores.unban(peerID); err != nil {
+		return err
+	}
+
+	s.logger.Info("peer unbanned", "id", peerID.String())
+
+	return nil
+}
+
+// BannedPeers returns the currently banned peers [Thread safe]
+func (s *Server) BannedPeers() []*PeerBan {
+	return s.scores.bannedPeers()
+}
@@ -0,0 +1,185 @@
+package encryptedlocal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/umbracle/ethgo/keystore"
+
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/local"
+)
+
+const (
+	// DefaultPasswordEnv is the environment variable with the keystore password,
+	// used if neither the password file nor the password environment variable is configured
+	DefaultPasswordEnv = "EDGE_SECRETS_PASSWORD"
+
+	// defaultScryptN is the scrypt CPU/memory cost of the keystore key derivation
+	defaultScryptN = 1 << 18
+
+	// scryptP is the scrypt parallelization of the keystore key derivation
+	scryptP = 1
+)
+
+var (
+	errNoPath        = errors.New("no path specified for encrypted local secrets manager")
+	errEmptyPassword = errors.New("the password of the encrypted local secrets manager is empty")
+)
+
+// EncryptedLocalSecretsManager is a SecretsManager that stores secrets locally on disk,
+// in the same layout as the local SecretsManager, encrypted into password-protected
+// keystore files (Web3 Secret Storage v3)
+type EncryptedLocalSecretsManager struct {
+	// The local SecretsManager storing the encrypted secrets
+	secrets.SecretsManager
+
+	// Password of the keystore files
+	password string
+
+	// scrypt CPU/memory cost of the newly encrypted secrets
+	scryptN int
+
+	// Decrypted secrets, the key derivation is too expensive to repeat on every read
+	decrypted     map[string][]byte
+	decryptedLock sync.Mutex
+}
+
+// SecretsManagerFactory implements the factory method.
+// The path to the base working directory is taken from the config if it's specified there,
+// otherwise from the params
+func SecretsManagerFactory(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (secrets.SecretsManager, error) {
+	var extra map[string]interface{}
+	if config != nil {
+		extra = config.Extra
+	}
+
+	path, err := getPath(extra, params.Extra)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := readPassword(extra)
+	if err != nil {
+		return nil, err
+	}
+
+	localManager, err := local.SecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+		Logger: params.Logger.Named(string(secrets.EncryptedLocal)),
+		Extra: map[string]interface{}{
+			secrets.Path: path,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedLocalSecretsManager{
+		SecretsManager: localManager,
+		password:       password,
+		scryptN:        defaultScryptN,
+		decrypted:      make(map[string][]byte),
+	}, nil
+}
+
+// GetSecret reads the keystore file of the secret from disk and decrypts it
+func (e *EncryptedLocalSecretsManager) GetSecret(name string) ([]byte, error) {
+	e.decryptedLock.Lock()
+	defer e.decryptedLock.Unlock()
+
+	if secret, ok := e.decrypted[name]; ok {
+		return secret, nil
+	}
+
+	encrypted, err := e.SecretsManager.GetSecret(name)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := keystore.DecryptV3(encrypted, e.password)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt secret %s, %w", name, err)
+	}
+
+	e.decrypted[name] = secret
+
+	return secret, nil
+}
+
+// SetSecret encrypts the secret and saves it to disk as a keystore file
+func (e *EncryptedLocalSecretsManager) SetSecret(name string, value []byte) error {
+	encrypted, err := keystore.EncryptV3(value, e.password, e.scryptN, scryptP)
+	if err != nil {
+		return fmt.Errorf("unable to encrypt secret %s, %w", name, err)
+	}
+
+	return e.SecretsManager.SetSecret(name, encrypted)
+}
+
+// RemoveSecret removes the keystore file of the secret from disk
+func (e *EncryptedLocalSecretsManager) RemoveSecret(name string) error {
+	e.decryptedLock.Lock()
+	delete(e.decrypted, name)
+	e.decryptedLock.Unlock()
+
+	return e.SecretsManager.RemoveSecret(name)
+}
+
+// getPath returns the path to the base working directory from the first extra map specifying it
+func getPath(extras ...map[string]interface{}) (string, error) {
+	for _, extra := range extras {
+		raw, ok := extra[secrets.Path]
+		if !ok {
+			continue
+		}
+
+		path, ok := raw.(string)
+		if !ok {
+			return "", errors.New("invalid type assertion")
+		}
+
+		if path != "" {
+			return path, nil
+		}
+	}
+
+	return "", errNoPath
+}
+
+// readPassword reads the keystore password from the configured file or environment variable
+func readPassword(extra map[string]interface{}) (string, error) {
+	var password string
+
+	if passwordFile, ok := extra[secrets.PasswordFile]; ok {
+		content, err := os.ReadFile(fmt.Sprintf("%s", passwordFile))
+		if err != nil {
+			return "", fmt.Errorf("unable to read password file, %w", err)
+		}
+
+		password = strings.TrimRight(string(content), "\r\n")
+	} else {
+		env := DefaultPasswordEnv
+		if passwordEnv, ok := extra[secrets.PasswordEnv]; ok {
+			env = fmt.Sprintf("%s", passwordEnv)
+		}
+
+		value, ok := os.LookupEnv(env)
+		if !ok {
+			return "", fmt.Errorf("no %s specified and environment variable %s is not set", secrets.PasswordFile, env)
+		}
+
+		password = value
+	}
+
+	if password == "" {
+		return "", errEmptyPassword
+	}
+
+	return password, nil
+}
@@ -0,0 +1,169 @@
+package encryptedlocal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+// testScryptN is the scrypt cost used to keep the tests fast
+const testScryptN = 1 << 4
+
+func newTestSecretsManager(t *testing.T, dir string, extra map[string]interface{}) *EncryptedLocalSecretsManager {
+	t.Helper()
+
+	manager, err := SecretsManagerFactory(
+		&secrets.SecretsManagerConfig{Type: secrets.EncryptedLocal, Extra: extra},
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+			Extra: map[string]interface{}{
+				secrets.Path: dir,
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	encrypted := manager.(*EncryptedLocalSecretsManager) //nolint:forcetypeassert
+	encrypted.scryptN = testScryptN
+
+	return encrypted
+}
+
+func TestEncryptedLocalSecretsManagerFactory(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "password")
+
+	require.NoError(t, os.WriteFile(passwordFile, []byte("secret\n"), 0600))
+
+	t.Setenv("CUSTOM_PASSWORD", "custom")
+	t.Setenv(DefaultPasswordEnv, "default")
+
+	testTable := []struct {
+		name         string
+		configExtra  map[string]interface{}
+		paramsExtra  map[string]interface{}
+		expectedPath string
+		password     string
+		shouldFail   bool
+	}{
+		{
+			name:         "password from file",
+			configExtra:  map[string]interface{}{secrets.PasswordFile: passwordFile},
+			paramsExtra:  map[string]interface{}{secrets.Path: dir},
+			expectedPath: dir,
+			password:     "secret",
+		},
+		{
+			name:         "password from configured environment variable",
+			configExtra:  map[string]interface{}{secrets.PasswordEnv: "CUSTOM_PASSWORD"},
+			paramsExtra:  map[string]interface{}{secrets.Path: dir},
+			expectedPath: dir,
+			password:     "custom",
+		},
+		{
+			name:         "password from default environment variable",
+			paramsExtra:  map[string]interface{}{secrets.Path: dir},
+			expectedPath: dir,
+			password:     "default",
+		},
+		{
+			name:         "path from config",
+			configExtra:  map[string]interface{}{secrets.Path: filepath.Join(dir, "config")},
+			paramsExtra:  map[string]interface{}{secrets.Path: dir},
+			expectedPath: filepath.Join(dir, "config"),
+			password:     "default",
+		},
+		{
+			name:       "no path",
+			shouldFail: true,
+		},
+		{
+			name:        "missing password file",
+			configExtra: map[string]interface{}{secrets.PasswordFile: filepath.Join(dir, "missing")},
+			paramsExtra: map[string]interface{}{secrets.Path: dir},
+			shouldFail:  true,
+		},
+		{
+			name:        "unset environment variable",
+			configExtra: map[string]interface{}{secrets.PasswordEnv: "UNSET_PASSWORD"},
+			paramsExtra: map[string]interface{}{secrets.Path: dir},
+			shouldFail:  true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			manager, err := SecretsManagerFactory(
+				&secrets.SecretsManagerConfig{Type: secrets.EncryptedLocal, Extra: testCase.configExtra},
+				&secrets.SecretsManagerParams{Logger: hclog.NewNullLogger(), Extra: testCase.paramsExtra},
+			)
+
+			if testCase.shouldFail {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			encrypted := manager.(*EncryptedLocalSecretsManager) //nolint:forcetypeassert
+			assert.Equal(t, testCase.password, encrypted.password)
+			assert.DirExists(t, filepath.Join(testCase.expectedPath, secrets.ConsensusFolderLocal))
+		})
+	}
+}
+
+func TestEncryptedLocalSecretsManager_Empty(t *testing.T) {
+	t.Setenv("EMPTY_PASSWORD", "")
+
+	_, err := SecretsManagerFactory(
+		&secrets.SecretsManagerConfig{Extra: map[string]interface{}{secrets.PasswordEnv: "EMPTY_PASSWORD"}},
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+			Extra:  map[string]interface{}{secrets.Path: t.TempDir()},
+		},
+	)
+	assert.ErrorIs(t, err, errEmptyPassword)
+}
+
+func TestEncryptedLocalSecretsManager_SetGetSecret(t *testing.T) {
+	dir := t.TempDir()
+	secret := []byte("0xdeadbeef")
+
+	t.Setenv(DefaultPasswordEnv, "password")
+
+	manager := newTestSecretsManager(t, dir, nil)
+
+	assert.False(t, manager.HasSecret(secrets.ValidatorKey))
+	require.NoError(t, manager.SetSecret(secrets.ValidatorKey, secret))
+	assert.True(t, manager.HasSecret(secrets.ValidatorKey))
+
+	// the secret is not stored in plaintext
+	stored, err := os.ReadFile(filepath.Join(dir, secrets.ConsensusFolderLocal, secrets.ValidatorKeyLocal))
+	require.NoError(t, err)
+	assert.NotContains(t, string(stored), string(secret))
+
+	value, err := manager.GetSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.Equal(t, secret, value)
+
+	// an existing secret is never overwritten
+	assert.Error(t, manager.SetSecret(secrets.ValidatorKey, []byte("other")))
+
+	t.Setenv(DefaultPasswordEnv, "wrong password")
+
+	_, err = newTestSecretsManager(t, dir, nil).GetSecret(secrets.ValidatorKey)
+	assert.Error(t, err)
+
+	_, err = manager.GetSecret(secrets.NetworkKey)
+	assert.Error(t, err)
+
+	require.NoError(t, manager.RemoveSecret(secrets.ValidatorKey))
+	assert.False(t, manager.HasSecret(secrets.ValidatorKey))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/secrets/awsssm"
+	"github.com/0xPolygon/polygon-edge/secrets/encryptedlocal"
 	"github.com/0xPolygon/polygon-edge/secrets/gcpssm"
 	"github.com/0xPolygon/polygon-edge/secrets/hashicorpvault"
 	"github.com/0xPolygon/polygon-edge/secrets/local"
@@ -70,6 +71,18 @@ func setupGCPSSM(
 	)
 }
 
+// setupEncryptedLocal is a helper method for boilerplate encrypted local secrets manager setup
+func setupEncryptedLocal(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return encryptedlocal.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
 // InitECDSAValidatorKey creates new ECDSA key and set as a validator key
 func InitECDSAValidatorKey(secretsManager secrets.SecretsManager) (types.Address, error) {
 	if secretsManager.HasSecret(secrets.ValidatorKey) {
@@ -209,7 +222,7 @@ func LoadNodeID(secretsManager secrets.SecretsManager) (string, error) {
 	return nodeID.String(), nil
 }
 
-// InitCloudSecretsManager returns the cloud secrets manager from the provided config
+// InitCloudSecretsManager returns the cloud or the encrypted local secrets manager from the provided config
 func InitCloudSecretsManager(secretsConfig *secrets.SecretsManagerConfig) (secrets.SecretsManager, error) {
 	var secretsManager secrets.SecretsManager
 
@@ -235,6 +248,13 @@ func InitCloudSecretsManager(secretsConfig *secrets.SecretsManagerConfig) (secre
 		}
 
 		secretsManager = GCPSSM
+	case secrets.EncryptedLocal:
+		encryptedLocal, err := setupEncryptedLocal(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = encryptedLocal
 	default:
 		return secretsManager, errors.New("unsupported secrets manager")
 	}
@@ -19,6 +19,12 @@ const (
 
 	// Name is the name of the current node
 	Name = "name"
+
+	// PasswordFile is the path to the file with the password of the encrypted keystore
+	PasswordFile = "password-file"
+
+	// PasswordEnv is the name of the environment variable with the password of the encrypted keystore
+	PasswordEnv = "password-env"
 )
 
 // Define constant names for available secrets
@@ -65,6 +71,9 @@ const (
 
 	// GCPSSM pertains to the Google Cloud Computing secret store manager
 	GCPSSM SecretsManagerType = "gcp-ssm"
+
+	// EncryptedLocal pertains to the local FS with the password-protected keystore files
+	EncryptedLocal SecretsManagerType = "encrypted-local"
 )
 
 // SecretsManager defines the base public interface that all
@@ -110,5 +119,5 @@ type SecretsManagerFactory func(
 // SupportedServiceManager checks if the passed in service manager type is supported
 func SupportedServiceManager(service SecretsManagerType) bool {
 	return service == HashicorpVault || service == AWSSSM ||
-		service == Local || service == GCPSSM
+		service == Local || service == GCPSSM || service == EncryptedLocal
 }
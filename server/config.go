@@ -2,10 +2,12 @@ package server
 
 import (
 	"net"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 )
@@ -19,11 +21,20 @@ type Config struct {
 
 	JSONRPC    *JSONRPC
 	GRPCAddr   *net.TCPAddr
+	GRPCTLS    *TLS
 	LibP2PAddr *net.TCPAddr
 
 	PriceLimit         uint64
 	MaxAccountEnqueued uint64
 	MaxSlots           uint64
+	PriceBump          uint64
+
+	// Journal is the path of the local transactions journal (relative to the data dir)
+	Journal         string
+	JournalRotation time.Duration
+
+	// BlockBuildingPolicy overrides the block building policy set in the genesis
+	BlockBuildingPolicy string
 
 	Telemetry *Telemetry
 	Network   *network.Config
@@ -43,7 +54,40 @@ type Config struct {
 
 	Relayer bool
 
+	ExitRelayer bool
+
+	// ExitRelayerRootJSONRPC is the rootchain JSON-RPC endpoint used by the exit relayer,
+	// the bridge endpoint of the chain config is used if empty
+	ExitRelayerRootJSONRPC string
+
+	// ExitRelayerRootKeyFile is the file holding the hex encoded private key of the rootchain account
+	// paying for the exits, required by the exit relayer
+	ExitRelayerRootKeyFile string
+
 	NumBlockConfirmations uint64
+
+	StatePruning *StatePruning
+}
+
+// StatePruning holds the config details for the state trie pruning
+type StatePruning struct {
+	Enabled            bool
+	Retain             uint64
+	CheckpointInterval uint64
+}
+
+// TLS holds the certificate files of a server accepting only TLS connections
+type TLS struct {
+	CertFile string
+	KeyFile  string
+
+	// ClientCAFile enables mutual TLS, the clients must present certificates signed by its CAs
+	ClientCAFile string
+}
+
+// Enabled reports whether the server certificate is set
+func (t *TLS) Enabled() bool {
+	return t != nil && (t.CertFile != "" || t.KeyFile != "")
 }
 
 // Telemetry holds the config details for metric services
@@ -57,4 +101,16 @@ type JSONRPC struct {
 	AccessControlAllowOrigin []string
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	IPCPath                  string
+
+	RateLimit             jsonrpc.RateLimit
+	MethodRateLimits      map[string]jsonrpc.RateLimit
+	AllowedMethods        []string
+	DeniedMethods         []string
+	MaxConcurrentRequests uint64
+	SlowRequestThreshold  time.Duration
+
+	TLS *TLS
+	// JWTSecretPath is the path of the JWT secret, the secret is generated if the file doesn't exist
+	JWTSecretPath string
 }
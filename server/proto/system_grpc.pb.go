@@ -31,6 +31,12 @@ type SystemClient interface {
 	PeersList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersListResponse, error)
 	// PeersInfo returns the info of a peer
 	PeersStatus(ctx context.Context, in *PeersStatusRequest, opts ...grpc.CallOption) (*Peer, error)
+	// PeersBan bans a peer
+	PeersBan(ctx context.Context, in *PeersBanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// PeersUnban lifts the ban of a peer
+	PeersUnban(ctx context.Context, in *PeersUnbanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// PeersListBanned returns the list of banned peers
+	PeersListBanned(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersListBannedResponse, error)
 	// Subscribe subscribes to blockchain events
 	Subscribe(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (System_SubscribeClient, error)
 	// Export returns blockchain data
@@ -83,6 +89,33 @@ func (c *systemClient) PeersStatus(ctx context.Context, in *PeersStatusRequest,
 	return out, nil
 }
 
+func (c *systemClient) PeersBan(ctx context.Context, in *PeersBanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.System/PeersBan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) PeersUnban(ctx context.Context, in *PeersUnbanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.System/PeersUnban", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) PeersListBanned(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersListBannedResponse, error) {
+	out := new(PeersListBannedResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/PeersListBanned", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *systemClient) Subscribe(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (System_SubscribeClient, error) {
 	stream, err := c.cc.NewStream(ctx, &System_ServiceDesc.Streams[0], "/v1.System/Subscribe", opts...)
 	if err != nil {
@@ -168,6 +201,12 @@ type SystemServer interface {
 	PeersList(context.Context, *emptypb.Empty) (*PeersListResponse, error)
 	// PeersInfo returns the info of a peer
 	PeersStatus(context.Context, *PeersStatusRequest) (*Peer, error)
+	// PeersBan bans a peer
+	PeersBan(context.Context, *PeersBanRequest) (*emptypb.Empty, error)
+	// PeersUnban lifts the ban of a peer
+	PeersUnban(context.Context, *PeersUnbanRequest) (*emptypb.Empty, error)
+	// PeersListBanned returns the list of banned peers
+	PeersListBanned(context.Context, *emptypb.Empty) (*PeersListBannedResponse, error)
 	// Subscribe subscribes to blockchain events
 	Subscribe(*emptypb.Empty, System_SubscribeServer) error
 	// Export returns blockchain data
@@ -193,6 +232,15 @@ func (UnimplementedSystemServer) PeersList(context.Context, *emptypb.Empty) (*Pe
 func (UnimplementedSystemServer) PeersStatus(context.Context, *PeersStatusRequest) (*Peer, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PeersStatus not implemented")
 }
+func (UnimplementedSystemServer) PeersBan(context.Context, *PeersBanRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersBan not implemented")
+}
+func (UnimplementedSystemServer) PeersUnban(context.Context, *PeersUnbanRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersUnban not implemented")
+}
+func (UnimplementedSystemServer) PeersListBanned(context.Context, *emptypb.Empty) (*PeersListBannedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersListBanned not implemented")
+}
 func (UnimplementedSystemServer) Subscribe(*emptypb.Empty, System_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
@@ -287,6 +335,60 @@ func _System_PeersStatus_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _System_PeersBan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeersBanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).PeersBan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/PeersBan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).PeersBan(ctx, req.(*PeersBanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_PeersUnban_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeersUnbanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).PeersUnban(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/PeersUnban",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).PeersUnban(ctx, req.(*PeersUnbanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_PeersListBanned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).PeersListBanned(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/PeersListBanned",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).PeersListBanned(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _System_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(emptypb.Empty)
 	if err := stream.RecvMsg(m); err != nil {
@@ -370,6 +472,18 @@ var System_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PeersStatus",
 			Handler:    _System_PeersStatus_Handler,
 		},
+		{
+			MethodName: "PeersBan",
+			Handler:    _System_PeersBan_Handler,
+		},
+		{
+			MethodName: "PeersUnban",
+			Handler:    _System_PeersUnban_Handler,
+		},
+		{
+			MethodName: "PeersListBanned",
+			Handler:    _System_PeersListBanned_Handler,
+		},
 		{
 			MethodName: "BlockByNumber",
 			Handler:    _System_BlockByNumber_Handler,
@@ -10,6 +10,8 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
@@ -22,12 +24,15 @@ import (
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/exitrelayer"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/statesyncrelayer"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
 	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/jwt"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/helper/tlsconfig"
 	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
@@ -38,18 +43,28 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/txpool"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validate"
 	"github.com/hashicorp/go-hclog"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/umbracle/ethgo"
+	ethgow "github.com/umbracle/ethgo/wallet"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
+// snapshotVerificationRange is the number of the headers imported from a state snapshot verified at once
+const snapshotVerificationRange = 2048
+
 var (
-	errBlockTimeMissing = errors.New("block time configuration is missing")
-	errBlockTimeInvalid = errors.New("block time configuration is invalid")
+	errBlockTimeMissing  = errors.New("block time configuration is missing")
+	errBlockTimeInvalid  = errors.New("block time configuration is invalid")
+	errRelayerNotRunning = errors.New("state sync relayer is not running")
+	errReservedGasNotSet = errors.New(
+		"the reserved gas policy requires the reserved gas and the whitelisted addresses set in the genesis",
+	)
 )
 
 // Server is the central manager of the blockchain client
@@ -90,6 +105,16 @@ type Server struct {
 
 	// stateSyncRelayer is handling state syncs execution (Polybft exclusive)
 	stateSyncRelayer *statesyncrelayer.StateSyncRelayer
+
+	// exitRelayer is executing the checkpointed exits on the rootchain (Polybft exclusive)
+	exitRelayer *exitrelayer.ExitRelayer
+
+	// jsonRPCJWTSecret is the JWT secret of the JSON-RPC server (nil if JWT is disabled)
+	jsonRPCJWTSecret []byte
+
+	// statePruner garbage-collects the states of the blocks which are not retained
+	statePruner    *itrie.Pruner
+	statePrunerSub blockchain.Subscription
 }
 
 // newFileLogger returns logger instance that writes all logs to a specified file.
@@ -140,11 +165,16 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, fmt.Errorf("could not setup new logger instance, %w", err)
 	}
 
+	grpcServer, err := newGRPCServer(config.GRPCTLS)
+	if err != nil {
+		return nil, fmt.Errorf("could not setup GRPC server, %w", err)
+	}
+
 	m := &Server{
 		logger:             logger.Named("server"),
 		config:             config,
 		chain:              config.Chain,
-		grpcServer:         grpc.NewServer(grpc.UnaryInterceptor(unaryInterceptor)),
+		grpcServer:         grpcServer,
 		restoreProgression: progress.NewProgressionWrapper(progress.ChainSyncRestore),
 	}
 
@@ -201,7 +231,17 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.stateStorage = stateStorage
 
-	st := itrie.NewState(stateStorage)
+	var pruningStorage *itrie.PruningStorage
+
+	if m.isStatePruningEnabled() {
+		if pruningStorage, err = itrie.NewPruningStorage(stateStorage); err != nil {
+			return nil, err
+		}
+
+		m.stateStorage = pruningStorage
+	}
+
+	st := itrie.NewState(m.stateStorage)
 	m.state = st
 
 	m.executor = state.NewExecutor(config.Chain.Params, st, logger)
@@ -336,6 +376,11 @@ func NewServer(config *Config) (*Server, error) {
 			Blockchain: m.blockchain,
 		}
 
+		policy, err := newBlockBuildingPolicy(m.chain.Params.BlockBuildingPolicy, m.config.BlockBuildingPolicy)
+		if err != nil {
+			return nil, err
+		}
+
 		// start transaction pool
 		m.txpool, err = txpool.NewTxPool(
 			logger,
@@ -344,9 +389,13 @@ func NewServer(config *Config) (*Server, error) {
 			m.grpcServer,
 			m.network,
 			&txpool.Config{
-				MaxSlots:           m.config.MaxSlots,
-				PriceLimit:         m.config.PriceLimit,
-				MaxAccountEnqueued: m.config.MaxAccountEnqueued,
+				MaxSlots:            m.config.MaxSlots,
+				PriceLimit:          m.config.PriceLimit,
+				MaxAccountEnqueued:  m.config.MaxAccountEnqueued,
+				PriceBump:           m.config.PriceBump,
+				Journal:             m.txPoolJournalPath(),
+				JournalRotation:     m.config.JournalRotation,
+				BlockBuildingPolicy: policy,
 			},
 		)
 		if err != nil {
@@ -376,6 +425,11 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// the headers imported from a state snapshot are verified before the node uses them
+	if err := m.verifyImportedSnapshot(); err != nil {
+		return nil, err
+	}
+
 	// setup and start grpc server
 	if err := m.setupGRPC(); err != nil {
 		return nil, err
@@ -385,6 +439,24 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// the relayers authenticate to the jsonrpc server with its JWT secret
+	if err := m.loadJSONRPCJWTSecret(); err != nil {
+		return nil, err
+	}
+
+	// setup relayer, the jsonrpc server exposes the status of the relayed state syncs
+	if config.Relayer {
+		if err := m.setupRelayer(); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.ExitRelayer {
+		if err := m.setupExitRelayer(); err != nil {
+			return nil, err
+		}
+	}
+
 	// setup and start jsonrpc server
 	if err := m.setupJSONRPC(); err != nil {
 		return nil, err
@@ -395,15 +467,30 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// the pruning is started before the blocks are processed, since the first pruning
+	// of the storage removes all the nodes which are not reachable from the retained states
+	if m.isStatePruningEnabled() {
+		if err := m.startStatePruner(st, pruningStorage); err != nil {
+			return nil, err
+		}
+	}
+
 	// start consensus
 	if err := m.consensus.Start(); err != nil {
 		return nil, err
 	}
 
 	// start relayer
-	if config.Relayer {
-		if err := m.setupRelayer(); err != nil {
-			return nil, err
+	if m.stateSyncRelayer != nil {
+		if err := m.stateSyncRelayer.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start relayer: %w", err)
+		}
+	}
+
+	// start exit relayer
+	if m.exitRelayer != nil {
+		if err := m.exitRelayer.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start exit relayer: %w", err)
 		}
 	}
 
@@ -412,6 +499,47 @@ func NewServer(config *Config) (*Server, error) {
 	return m, nil
 }
 
+func (s *Server) isStatePruningEnabled() bool {
+	return s.config.StatePruning != nil && s.config.StatePruning.Enabled
+}
+
+// startStatePruner prunes the state up to the current head and starts garbage collection
+// of the state tries on new blocks
+func (s *Server) startStatePruner(st *itrie.State, storage *itrie.PruningStorage) error {
+	pruner := itrie.NewPruner(s.logger, st, storage, s.blockchain, itrie.PrunerConfig{
+		Retain:             s.config.StatePruning.Retain,
+		CheckpointInterval: s.config.StatePruning.CheckpointInterval,
+	})
+
+	if err := pruner.Prune(s.blockchain.Header()); err != nil {
+		return fmt.Errorf("failed to prune state: %w", err)
+	}
+
+	s.statePruner = pruner
+
+	s.statePrunerSub = s.blockchain.SubscribeEvents()
+
+	go func() {
+		for {
+			ev := s.statePrunerSub.GetEvent()
+			if ev == nil {
+				return
+			}
+
+			if ev.Type == blockchain.EventFork {
+				continue
+			}
+
+			s.statePruner.NotifyHead(ev.Header())
+		}
+	}()
+
+	s.logger.Info("state pruning enabled", "retain", s.config.StatePruning.Retain,
+		"checkpoint interval", s.config.StatePruning.CheckpointInterval)
+
+	return nil
+}
+
 func unaryInterceptor(
 	ctx context.Context,
 	req interface{},
@@ -438,6 +566,55 @@ func (s *Server) restoreChain() error {
 	return nil
 }
 
+// verifyImportedSnapshot verifies the headers imported from a state snapshot by the consensus,
+// the first time the node starts with them
+func (s *Server) verifyImportedSnapshot() error {
+	if s.config.DataDir == "" || !archive.SnapshotVerificationPending(s.config.DataDir) {
+		return nil
+	}
+
+	head := s.blockchain.Header().Number
+	s.logger.Info("Verifying the headers imported from the state snapshot", "head", head)
+
+	_, verifiesHeaders := s.consensus.(blockchain.HeadersVerifier)
+
+	for from := uint64(1); from <= head; from += snapshotVerificationRange {
+		to := from + snapshotVerificationRange - 1
+		if to > head {
+			to = head
+		}
+
+		headers := make([]*types.Header, 0, to-from+1)
+
+		for n := from; n <= to; n++ {
+			header, ok := s.blockchain.GetHeaderByNumber(n)
+			if !ok {
+				return fmt.Errorf("imported header %d not found", n)
+			}
+
+			headers = append(headers, header)
+		}
+
+		if err := s.blockchain.VerifyHeaders(nil, headers); err != nil {
+			return fmt.Errorf("failed to verify the imported headers from %d to %d: %w", from, to, err)
+		}
+
+		// the blockchain verifies only the first header by the consensus not verifying the header chains,
+		// the parents of the imported headers are written already, so that the rest are verified one by one
+		if !verifiesHeaders {
+			for _, header := range headers[1:] {
+				if err := s.consensus.VerifyHeader(header); err != nil {
+					return fmt.Errorf("failed to verify the imported header %d: %w", header.Number, err)
+				}
+			}
+		}
+
+		s.logger.Info("Verified the imported headers", "from", from, "to", to)
+	}
+
+	return archive.CompleteSnapshotVerification(s.config.DataDir)
+}
+
 type txpoolHub struct {
 	state state.State
 	*blockchain.Blockchain
@@ -486,6 +663,58 @@ func (t *txpoolHub) GetBalance(root types.Hash, addr types.Address) (*big.Int, e
 	return account.Balance, nil
 }
 
+// txPoolJournalPath returns the path of the local transactions journal,
+// or an empty string if the journal is disabled
+func (s *Server) txPoolJournalPath() string {
+	if s.config.Journal == "" || filepath.IsAbs(s.config.Journal) {
+		return s.config.Journal
+	}
+
+	if s.config.DataDir == "" {
+		// nothing is persisted when running without a data directory
+		return ""
+	}
+
+	return filepath.Join(s.config.DataDir, s.config.Journal)
+}
+
+// jsonRPCIPCPath returns the path of the JSON-RPC IPC socket
+func (s *Server) jsonRPCIPCPath() string {
+	path := s.config.JSONRPC.IPCPath
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	if s.config.DataDir == "" {
+		return ""
+	}
+
+	return filepath.Join(s.config.DataDir, path)
+}
+
+// newBlockBuildingPolicy creates the block building policy configured in the genesis.
+// The policy name set in the server config takes precedence over the genesis one.
+func newBlockBuildingPolicy(
+	genesisConfig *chain.BlockBuildingPolicyConfig,
+	name string,
+) (txpool.BlockBuildingPolicy, error) {
+	if genesisConfig == nil {
+		genesisConfig = &chain.BlockBuildingPolicyConfig{}
+	}
+
+	if name == "" {
+		name = genesisConfig.Name
+	}
+
+	// without the reserved gas the policy would silently act as the price one
+	if name == txpool.ReservedGasPolicy &&
+		(genesisConfig.ReservedGas == 0 || len(genesisConfig.WhitelistedAddresses) == 0) {
+		return nil, errReservedGasNotSet
+	}
+
+	return txpool.NewBlockBuildingPolicy(name, genesisConfig.ReservedGas, genesisConfig.WhitelistedAddresses)
+}
+
 // setupSecretsManager sets up the secrets manager
 func (s *Server) setupSecretsManager() error {
 	secretsManagerConfig := s.config.SecretsManager
@@ -501,9 +730,9 @@ func (s *Server) setupSecretsManager() error {
 		Logger: s.logger,
 	}
 
-	if secretsManagerType == secrets.Local {
-		// Only the base directory is required for
-		// the local secrets manager
+	if secretsManagerType == secrets.Local || secretsManagerType == secrets.EncryptedLocal {
+		// Only the base directory is required for the local secrets manager,
+		// the encrypted one uses it unless its config specifies another one
 		secretsManagerParams.Extra = map[string]interface{}{
 			secrets.Path: s.config.DataDir,
 		}
@@ -630,26 +859,99 @@ func (s *Server) setupRelayer() error {
 		trackerStartBlockConfig = polyBFTConfig.Bridge.EventTrackerStartBlocks
 	}
 
-	relayer := statesyncrelayer.NewRelayer(
+	endpoint, creds, err := s.localJSONRPCClient()
+	if err != nil {
+		return err
+	}
+
+	relayer, err := statesyncrelayer.NewRelayer(
 		s.config.DataDir,
-		s.config.JSONRPC.JSONRPCAddr.String(),
+		endpoint,
+		creds,
 		ethgo.Address(contracts.StateReceiverContract),
 		trackerStartBlockConfig[contracts.StateReceiverContract],
 		s.logger.Named("relayer"),
 		wallet.NewEcdsaSigner(wallet.NewKey(account)),
 	)
+	if err != nil {
+		return fmt.Errorf("failed to create relayer: %w", err)
+	}
 
-	// start relayer
-	if err := relayer.Start(); err != nil {
-		return fmt.Errorf("failed to start relayer: %w", err)
+	s.stateSyncRelayer = relayer
+
+	return nil
+}
+
+// setupExitRelayer sets up the exit relayer
+func (s *Server) setupExitRelayer() error {
+	polyBFTConfig, err := consensusPolyBFT.GetPolyBFTConfig(s.config.Chain)
+	if err != nil {
+		return fmt.Errorf("failed to extract polybft config: %w", err)
 	}
 
+	if !polyBFTConfig.IsBridgeEnabled() {
+		return errors.New("exit relayer requires the bridge to be enabled")
+	}
+
+	key, err := s.exitRelayerKey()
+	if err != nil {
+		return err
+	}
+
+	rootRPCEndpoint := s.config.ExitRelayerRootJSONRPC
+	if rootRPCEndpoint == "" {
+		rootRPCEndpoint = polyBFTConfig.Bridge.JSONRPCEndpoint
+	}
+
+	endpoint, creds, err := s.localJSONRPCClient()
+	if err != nil {
+		return err
+	}
+
+	relayer, err := exitrelayer.NewExitRelayer(
+		s.config.DataDir,
+		endpoint,
+		creds,
+		rootRPCEndpoint,
+		ethgo.Address(polyBFTConfig.Bridge.ExitHelperAddr),
+		ethgo.Address(polyBFTConfig.Bridge.CheckpointManagerAddr),
+		polyBFTConfig.Bridge.EventTrackerStartBlocks[contracts.L2StateSenderContract],
+		s.logger.Named("exit_relayer"),
+		key,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create exit relayer: %w", err)
+	}
+
+	s.exitRelayer = relayer
+
 	return nil
 }
 
+// exitRelayerKey returns the key of the rootchain account paying for the exits, read from the configured key file.
+// The validator key is never used, so that the exits do not drain the validator account
+func (s *Server) exitRelayerKey() (ethgo.Key, error) {
+	if s.config.ExitRelayerRootKeyFile == "" {
+		return nil, errors.New("the exit relayer requires the rootchain key file")
+	}
+
+	raw, err := os.ReadFile(s.config.ExitRelayerRootKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the exit relayer key file: %w", err)
+	}
+
+	privateKey, err := crypto.BytesToECDSAPrivateKey([]byte(strings.TrimSpace(string(raw))))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the exit relayer key: %w", err)
+	}
+
+	return ethgow.NewKey(privateKey), nil
+}
+
 type jsonRPCHub struct {
 	state              state.State
 	restoreProgression *progress.ProgressionWrapper
+	stateSyncRelayer   *statesyncrelayer.StateSyncRelayer
 
 	*blockchain.Blockchain
 	*txpool.TxPool
@@ -712,6 +1014,62 @@ func (j *jsonRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error
 	return code, nil
 }
 
+// GetProof returns the merkle proof of the account and of its given storage slots
+// in the state with the given root
+func (j *jsonRPCHub) GetProof(
+	root types.Hash,
+	addr types.Address,
+	storageKeys []types.Hash,
+) (*jsonrpc.AccountProof, error) {
+	snap, err := j.state.NewSnapshotAt(root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get snapshot for root '%s': %w", root, err)
+	}
+
+	account, err := snap.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		// the proof shows that the account does not exist
+		account = &state.Account{
+			Balance:  big.NewInt(0),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash.Bytes(),
+		}
+	}
+
+	accountProof, err := j.state.GetProof(root, addr.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &jsonrpc.AccountProof{
+		Nonce:         account.Nonce,
+		Balance:       new(big.Int).Set(account.Balance),
+		CodeHash:      types.BytesToHash(account.CodeHash),
+		StorageRoot:   account.Root,
+		Proof:         accountProof,
+		StorageProofs: make([]*jsonrpc.StorageProof, len(storageKeys)),
+	}
+
+	for i, key := range storageKeys {
+		storageProof, err := j.state.GetProof(account.Root, key.Bytes())
+		if err != nil {
+			return nil, err
+		}
+
+		proof.StorageProofs[i] = &jsonrpc.StorageProof{
+			Key:   key,
+			Value: snap.GetStorage(addr, account.Root, key),
+			Proof: storageProof,
+		}
+	}
+
+	return proof, nil
+}
+
 func (j *jsonRPCHub) ApplyTxn(
 	header *types.Header,
 	txn *types.Transaction,
@@ -858,6 +1216,55 @@ func (j *jsonRPCHub) TraceCall(
 	return tracer.GetResult()
 }
 
+// GetStateSyncStatus returns the execution status of the state sync relayed by the node
+func (j *jsonRPCHub) GetStateSyncStatus(stateSyncID uint64) (*jsonrpc.StateSyncStatus, error) {
+	if j.stateSyncRelayer == nil {
+		return nil, errRelayerNotRunning
+	}
+
+	relay, err := j.stateSyncRelayer.StateSync(stateSyncID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toStateSyncStatus(relay), nil
+}
+
+// GetPendingStateSyncs returns the state syncs awaiting execution by the node relayer
+func (j *jsonRPCHub) GetPendingStateSyncs() ([]*jsonrpc.StateSyncStatus, error) {
+	if j.stateSyncRelayer == nil {
+		return nil, errRelayerNotRunning
+	}
+
+	relays, err := j.stateSyncRelayer.PendingStateSyncs()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*jsonrpc.StateSyncStatus, len(relays))
+	for i, relay := range relays {
+		statuses[i] = toStateSyncStatus(relay)
+	}
+
+	return statuses, nil
+}
+
+func toStateSyncStatus(relay *statesyncrelayer.StateSyncRelay) *jsonrpc.StateSyncStatus {
+	status := &jsonrpc.StateSyncStatus{
+		ID:          relay.ID,
+		BlockNumber: relay.BlockNumber,
+		Status:      string(relay.Status),
+		Attempts:    relay.Attempts,
+		LastError:   relay.LastError,
+	}
+
+	if !relay.NextAttempt.IsZero() {
+		status.NextAttempt = uint64(relay.NextAttempt.Unix())
+	}
+
+	return status
+}
+
 func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 	// restore progression
 	if restoreProg := j.restoreProgression.GetProgression(); restoreProg != nil {
@@ -879,6 +1286,7 @@ func (s *Server) setupJSONRPC() error {
 	hub := &jsonRPCHub{
 		state:              s.state,
 		restoreProgression: s.restoreProgression,
+		stateSyncRelayer:   s.stateSyncRelayer,
 		Blockchain:         s.blockchain,
 		TxPool:             s.txpool,
 		Executor:           s.executor,
@@ -896,6 +1304,23 @@ func (s *Server) setupJSONRPC() error {
 		PriceLimit:               s.config.PriceLimit,
 		BatchLengthLimit:         s.config.JSONRPC.BatchLengthLimit,
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
+		IPCPath:                  s.jsonRPCIPCPath(),
+		RateLimit:                s.config.JSONRPC.RateLimit,
+		MethodRateLimits:         s.config.JSONRPC.MethodRateLimits,
+		AllowedMethods:           s.config.JSONRPC.AllowedMethods,
+		DeniedMethods:            s.config.JSONRPC.DeniedMethods,
+		MaxConcurrentRequests:    s.config.JSONRPC.MaxConcurrentRequests,
+		SlowRequestThreshold:     s.config.JSONRPC.SlowRequestThreshold,
+		JWTSecret:                s.jsonRPCJWTSecret,
+	}
+
+	if tlsFiles := s.config.JSONRPC.TLS; tlsFiles.Enabled() {
+		var err error
+
+		conf.TLSConfig, err = tlsconfig.NewServerConfig(tlsFiles.CertFile, tlsFiles.KeyFile, tlsFiles.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("invalid JSON-RPC TLS config: %w", err)
+		}
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -908,6 +1333,70 @@ func (s *Server) setupJSONRPC() error {
 	return nil
 }
 
+// loadJSONRPCJWTSecret loads the JWT secret of the JSON-RPC server, if JWT is enabled
+func (s *Server) loadJSONRPCJWTSecret() error {
+	if s.config.JSONRPC.JWTSecretPath == "" {
+		return nil
+	}
+
+	secret, created, err := jwt.LoadOrCreateSecret(s.config.JSONRPC.JWTSecretPath)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		s.logger.Info("generated JSON-RPC JWT secret", "path", s.config.JSONRPC.JWTSecretPath)
+	}
+
+	s.jsonRPCJWTSecret = secret
+
+	return nil
+}
+
+// localJSONRPCClient returns the endpoint and the credentials of the in-process JSON-RPC clients.
+// They trust only the JSON-RPC server certificate, and present it in case of mutual TLS
+func (s *Server) localJSONRPCClient() (string, *txrelayer.Credentials, error) {
+	addr := s.config.JSONRPC.JSONRPCAddr
+
+	host := addr.IP.String()
+	if addr.IP == nil || addr.IP.IsUnspecified() {
+		host = "127.0.0.1"
+	}
+
+	creds := &txrelayer.Credentials{JWTSecret: s.jsonRPCJWTSecret}
+	scheme := "http"
+
+	if tlsFiles := s.config.JSONRPC.TLS; tlsFiles.Enabled() {
+		var err error
+
+		if creds.TLSConfig, err = tlsconfig.NewPinnedClientConfig(tlsFiles.CertFile, tlsFiles.KeyFile); err != nil {
+			return "", nil, fmt.Errorf("invalid JSON-RPC TLS config: %w", err)
+		}
+
+		scheme = "https"
+	}
+
+	endpoint := fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(host, strconv.Itoa(addr.Port)))
+
+	return endpoint, creds, nil
+}
+
+// newGRPCServer creates the GRPC server, accepting only TLS connections if the TLS config is set
+func newGRPCServer(tlsConfig *TLS) (*grpc.Server, error) {
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(unaryInterceptor)}
+
+	if tlsConfig.Enabled() {
+		config, err := tlsconfig.NewServerConfig(tlsConfig.CertFile, tlsConfig.KeyFile, tlsConfig.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, grpc.Creds(credentials.NewTLS(config)))
+	}
+
+	return grpc.NewServer(opts...), nil
+}
+
 // setupGRPC sets up the grpc server and listens on tcp
 func (s *Server) setupGRPC() error {
 	proto.RegisterSystemServer(s.grpcServer, &systemService{server: s})
@@ -924,7 +1413,7 @@ func (s *Server) setupGRPC() error {
 		}
 	}()
 
-	s.logger.Info("GRPC server running", "addr", s.config.GRPCAddr.String())
+	s.logger.Info("GRPC server running", "addr", s.config.GRPCAddr.String(), "tls", s.config.GRPCTLS.Enabled())
 
 	return nil
 }
@@ -956,6 +1445,12 @@ func (s *Server) Close() {
 		s.logger.Error("failed to close consensus", "err", err.Error())
 	}
 
+	// Stop the state pruner before closing its storage
+	if s.statePruner != nil {
+		s.statePrunerSub.Close()
+		s.statePruner.Close()
+	}
+
 	// Close the state storage
 	if err := s.stateStorage.Close(); err != nil {
 		s.logger.Error("failed to close storage for trie", "err", err.Error())
@@ -972,9 +1467,21 @@ func (s *Server) Close() {
 		s.stateSyncRelayer.Stop()
 	}
 
+	// Stop exit relayer
+	if s.exitRelayer != nil {
+		s.exitRelayer.Stop()
+	}
+
 	// Close the txpool's main loop
 	s.txpool.Close()
 
+	// Close the JSON-RPC IPC server
+	if s.jsonrpcServer != nil {
+		if err := s.jsonrpcServer.Close(); err != nil {
+			s.logger.Error("failed to close JSON-RPC IPC server", "err", err.Error())
+		}
+	}
+
 	// Close DataDog profiler
 	s.closeDataDogProfiler()
 }
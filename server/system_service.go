@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/network/common"
@@ -161,6 +162,63 @@ func (s *systemService) PeersList(
 	return resp, nil
 }
 
+// PeersBan implements the 'peers ban' operator service
+func (s *systemService) PeersBan(_ context.Context, req *proto.PeersBanRequest) (*empty.Empty, error) {
+	peerID, err := peer.Decode(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := time.Duration(req.Duration) * time.Second
+
+	if err := s.server.network.BanPeer(peerID, duration, req.Reason); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// PeersUnban implements the 'peers unban' operator service
+func (s *systemService) PeersUnban(_ context.Context, req *proto.PeersUnbanRequest) (*empty.Empty, error) {
+	peerID, err := peer.Decode(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.server.network.UnbanPeer(peerID); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// PeersListBanned implements the 'peers list-banned' operator service
+func (s *systemService) PeersListBanned(
+	_ context.Context,
+	_ *empty.Empty,
+) (*proto.PeersListBannedResponse, error) {
+	bans := s.server.network.BannedPeers()
+
+	resp := &proto.PeersListBannedResponse{
+		Peers: make([]*proto.BannedPeer, 0, len(bans)),
+	}
+
+	for _, ban := range bans {
+		bannedPeer := &proto.BannedPeer{
+			Id:     ban.PeerID.String(),
+			Reason: ban.Reason,
+		}
+
+		if !ban.Expiry.IsZero() {
+			bannedPeer.Expiry = ban.Expiry.Unix()
+		}
+
+		resp.Peers = append(resp.Peers, bannedPeer)
+	}
+
+	return resp, nil
+}
+
 // BlockByNumber implements the BlockByNumber operator service
 func (s *systemService) BlockByNumber(
 	ctx context.Context,
@@ -187,8 +245,8 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 	}
 
 	if req.To != 0 {
-		if from >= req.To {
-			return errors.New("to must be greater than from")
+		if from > req.To {
+			return errors.New("to must be greater than or equal to from")
 		}
 
 		to = &req.To
@@ -22,10 +22,16 @@ import (
 
 const (
 	SpuriousDragonMaxCodeSize = 24576
-	TxPoolMaxInitCodeSize     = 2 * SpuriousDragonMaxCodeSize
 
 	TxGas                 uint64 = 21000 // Per transaction not creating a contract
 	TxGasContractCreation uint64 = 53000 // Per transaction that creates a contract
+
+	TxAccessListAddressGas    uint64 = 2400 // Per address specified in EIP-2930 access list
+	TxAccessListStorageKeyGas uint64 = 1900 // Per storage key specified in EIP-2930 access list
+	TxInitCodeWordGas         uint64 = 2    // Per word of the init code of a contract creation (EIP-3860)
+
+	RefundQuotient        uint64 = 2 // Maximum refund quotient, refund can go up to half the gas used
+	RefundQuotientEIP3529 uint64 = 5 // Maximum refund quotient after EIP-3529
 )
 
 // GetHashByNumber returns the hash function of a block number
@@ -86,6 +92,9 @@ func (e *Executor) WriteGenesis(
 		gasPool:     uint64(env.GasLimit),
 		config:      config,
 		precompiles: precompiled.NewPrecompiled(),
+		accessList:  runtime.NewAccessList(),
+
+		transientStorage: runtime.NewTransientStorage(),
 	}
 
 	for addr, account := range alloc {
@@ -212,6 +221,9 @@ func (e *Executor) BeginTxn(
 		evm:         evm.NewEVM(),
 		precompiles: precompiled.NewPrecompiled(),
 		PostHook:    e.PostHook,
+		accessList:  runtime.NewAccessList(),
+
+		transientStorage: runtime.NewTransientStorage(),
 	}
 
 	// enable contract deployment allow list (if any)
@@ -274,6 +286,16 @@ type Transition struct {
 	txnBlockList        *addresslist.AddressList
 	bridgeAllowList     *addresslist.AddressList
 	bridgeBlockList     *addresslist.AddressList
+
+	// accessList tracks the addresses and storage slots accessed in the current transaction
+	accessList *runtime.AccessList
+
+	// journal records the access list additions and the transient storage modifications,
+	// so that they can be undone when a call frame reverts
+	journal journal
+
+	// transientStorage is the storage discarded at the end of the current transaction (EIP-1153)
+	transientStorage *runtime.TransientStorage
 }
 
 func NewTransition(config chain.ForksInTime, snap Snapshot, radix *Txn) *Transition {
@@ -283,6 +305,9 @@ func NewTransition(config chain.ForksInTime, snap Snapshot, radix *Txn) *Transit
 		snap:        snap,
 		evm:         evm.NewEVM(),
 		precompiles: precompiled.NewPrecompiled(),
+		accessList:  runtime.NewAccessList(),
+
+		transientStorage: runtime.NewTransientStorage(),
 	}
 }
 
@@ -331,7 +356,7 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	var err error
 
 	if txn.From == emptyFrom &&
-		(txn.Type == types.LegacyTx || txn.Type == types.DynamicFeeTx) {
+		(txn.Type == types.LegacyTx || txn.Type == types.DynamicFeeTx || txn.Type == types.AccessListTx) {
 		// Decrypt the from address
 		signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
 
@@ -512,6 +537,7 @@ var (
 	ErrBlockLimitReached     = fmt.Errorf("gas limit reached in the pool")
 	ErrIntrinsicGasOverflow  = fmt.Errorf("overflow in intrinsic gas calculation")
 	ErrNotEnoughIntrinsicGas = fmt.Errorf("not enough gas supplied for intrinsic gas costs")
+	ErrTxTypeNotSupported    = fmt.Errorf("transaction type not supported")
 
 	// ErrTipAboveFeeCap is a sanity error to ensure no one is able to specify a
 	// transaction with a tip higher than the total fee cap.
@@ -559,6 +585,10 @@ func NewGasLimitReachedTransitionApplicationError(err error) *GasLimitReachedTra
 func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, error) {
 	var err error
 
+	if t.ctx.Tracer != nil {
+		t.ctx.Tracer.TxStart(msg.Gas, msg.From, msg.To, t.ctx.Coinbase, t)
+	}
+
 	if msg.Type == types.StateTx {
 		err = checkAndProcessStateTx(msg)
 	} else {
@@ -569,17 +599,25 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		return nil, err
 	}
 
+	// prepare the access list of the transaction (EIP-2929, EIP-2930)
+	t.journal.reset()
+	t.accessList = runtime.NewAccessList()
+	t.accessList.PrepareAccessList(msg.From, msg.To, t.precompiles.Addrs(&t.config), msg.AccessList)
+
+	// the coinbase is warm from the start of the transaction (EIP-3651)
+	if t.config.Shanghai {
+		t.accessList.AddAddress(t.ctx.Coinbase)
+	}
+
+	t.transientStorage = runtime.NewTransientStorage()
+
 	// the amount of gas required is available in the block
 	if err = t.subGasPool(msg.Gas); err != nil {
 		return nil, NewGasLimitReachedTransitionApplicationError(err)
 	}
 
-	if t.ctx.Tracer != nil {
-		t.ctx.Tracer.TxStart(msg.Gas)
-	}
-
 	// 4. there is no overflow when calculating intrinsic gas
-	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul)
+	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul, t.config.Shanghai)
 	if err != nil {
 		return nil, NewTransitionApplicationError(err, false)
 	}
@@ -607,11 +645,7 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	}
 
 	refund := t.state.GetRefund()
-	result.UpdateGasUsed(msg.Gas, refund)
-
-	if t.ctx.Tracer != nil {
-		t.ctx.Tracer.TxEnd(result.GasLeft)
-	}
+	result.UpdateGasUsed(msg.Gas, refund, t.refundQuotient())
 
 	// Refund the sender
 	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), gasPrice)
@@ -643,6 +677,12 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	// return gas to the pool
 	t.addGasPool(result.GasLeft)
 
+	result.AccessList = t.accessList
+
+	if t.ctx.Tracer != nil {
+		t.ctx.Tracer.TxEnd(result.GasLeft)
+	}
+
 	return result, nil
 }
 
@@ -766,7 +806,7 @@ func (t *Transition) applyCall(
 		}
 	}
 
-	snapshot := t.state.Snapshot()
+	snapshot := t.snapshot()
 	t.state.TouchAccount(c.Address)
 
 	if callType == runtime.Call {
@@ -785,7 +825,7 @@ func (t *Transition) applyCall(
 
 	result = t.run(c, host)
 	if result.Failed() {
-		t.state.RevertToSnapshot(snapshot)
+		t.revertToSnapshot(snapshot)
 	}
 
 	t.captureCallEnd(c, result)
@@ -793,6 +833,26 @@ func (t *Transition) applyCall(
 	return result
 }
 
+// transitionSnapshot is the position to revert the state and the journal of the transition to
+type transitionSnapshot struct {
+	state   int
+	journal int
+}
+
+// snapshot takes a snapshot of the state and the journal at this point of the execution
+func (t *Transition) snapshot() transitionSnapshot {
+	return transitionSnapshot{
+		state:   t.state.Snapshot(),
+		journal: t.journal.snapshot(),
+	}
+}
+
+// revertToSnapshot undoes the modifications made after the snapshot
+func (t *Transition) revertToSnapshot(s transitionSnapshot) {
+	t.state.RevertToSnapshot(s.state)
+	t.journal.revertToSnapshot(t, s.journal)
+}
+
 func (t *Transition) hasCodeOrNonce(addr types.Address) bool {
 	if t.state.GetNonce(addr) != 0 {
 		return true
@@ -816,6 +876,9 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	// Increment the nonce of the caller
 	t.state.IncrNonce(c.Caller)
 
+	// The created address is warm even if the creation fails (EIP-2929)
+	t.AddAddressToAccessList(c.Address)
+
 	// Check if there is a collision and the address already exists
 	if t.hasCodeOrNonce(c.Address) {
 		return &runtime.ExecutionResult{
@@ -825,7 +888,7 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	}
 
 	// Take snapshot of the current state
-	snapshot := t.state.Snapshot()
+	snapshot := t.snapshot()
 
 	if t.config.EIP158 {
 		// Force the creation of the account
@@ -843,7 +906,7 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 
 	var result *runtime.ExecutionResult
 
-	t.captureCallStart(c, evm.CREATE)
+	t.captureCallStart(c, c.Type)
 
 	defer func() {
 		// pass result to be set later
@@ -855,37 +918,43 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		role := t.deploymentAllowList.GetRole(c.Caller)
 
 		if !role.Enabled() {
-			return &runtime.ExecutionResult{
+			result = &runtime.ExecutionResult{
 				GasLeft: 0,
 				Err:     runtime.ErrNotAuth,
 			}
+
+			return result
 		}
 	} else if t.deploymentBlockList != nil {
 		role := t.deploymentBlockList.GetRole(c.Caller)
 
 		if role == addresslist.EnabledRole {
-			return &runtime.ExecutionResult{
+			result = &runtime.ExecutionResult{
 				GasLeft: 0,
 				Err:     runtime.ErrNotAuth,
 			}
+
+			return result
 		}
 	}
 
 	result = t.run(c, host)
 	if result.Failed() {
-		t.state.RevertToSnapshot(snapshot)
+		t.revertToSnapshot(snapshot)
 
 		return result
 	}
 
 	if t.config.EIP158 && len(result.ReturnValue) > SpuriousDragonMaxCodeSize {
 		// Contract size exceeds 'SpuriousDragon' size limit
-		t.state.RevertToSnapshot(snapshot)
+		t.revertToSnapshot(snapshot)
 
-		return &runtime.ExecutionResult{
+		result = &runtime.ExecutionResult{
 			GasLeft: 0,
 			Err:     runtime.ErrMaxCodeSizeExceeded,
 		}
+
+		return result
 	}
 
 	gasCost := uint64(len(result.ReturnValue)) * 200
@@ -896,7 +965,7 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 
 		// Out of gas creating the contract
 		if t.config.Homestead {
-			t.state.RevertToSnapshot(snapshot)
+			t.revertToSnapshot(snapshot)
 
 			result.GasLeft = 0
 		}
@@ -968,8 +1037,18 @@ func (t *Transition) GetNonce(addr types.Address) uint64 {
 	return t.state.GetNonce(addr)
 }
 
+// refundQuotient returns the maximum refund quotient, the refund can go up to gas used / quotient
+func (t *Transition) refundQuotient() uint64 {
+	if t.config.EIP3529 {
+		return RefundQuotientEIP3529
+	}
+
+	return RefundQuotient
+}
+
 func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address) {
-	if !t.state.HasSuicided(addr) {
+	// the refund for self destructing is removed in EIP-3529
+	if !t.state.HasSuicided(addr) && !t.config.EIP3529 {
 		t.state.AddRefund(24000)
 	}
 
@@ -978,7 +1057,7 @@ func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address)
 }
 
 func (t *Transition) Callx(c *runtime.Contract, h runtime.Host) *runtime.ExecutionResult {
-	if c.Type == runtime.Create {
+	if c.Type == runtime.Create || c.Type == runtime.Create2 {
 		return t.applyCreate(c, h)
 	}
 
@@ -1030,7 +1109,57 @@ func (t *Transition) GetRefund() uint64 {
 	return t.state.GetRefund()
 }
 
-func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (uint64, error) {
+// AddAddressToAccessList marks the given address as accessed in the current transaction
+func (t *Transition) AddAddressToAccessList(addr types.Address) {
+	if t.accessList.ContainsAddress(addr) {
+		return
+	}
+
+	t.journal.append(accessListAddressChange{address: addr})
+	t.accessList.AddAddress(addr)
+}
+
+// AddSlotToAccessList marks the given storage slot as accessed in the current transaction
+func (t *Transition) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	addressPresent, slotPresent := t.accessList.Contains(addr, slot)
+	if slotPresent {
+		return
+	}
+
+	if !addressPresent {
+		t.journal.append(accessListAddressChange{address: addr})
+	}
+
+	t.journal.append(accessListSlotChange{address: addr, slot: slot})
+	t.accessList.AddSlot(addr, slot)
+}
+
+// ContainsAccessListAddress checks if the address has been accessed in the current transaction
+func (t *Transition) ContainsAccessListAddress(addr types.Address) bool {
+	return t.accessList.ContainsAddress(addr)
+}
+
+// ContainsAccessListSlot checks if the storage slot and its address have been accessed in the current transaction
+func (t *Transition) ContainsAccessListSlot(addr types.Address, slot types.Hash) (bool, bool) {
+	return t.accessList.Contains(addr, slot)
+}
+
+// GetTransientState returns the value of the transient storage slot of the contract
+func (t *Transition) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	return t.transientStorage.Get(addr, key)
+}
+
+// SetTransientState sets the value of the transient storage slot of the contract
+func (t *Transition) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {
+	t.journal.append(transientStorageChange{
+		address:  addr,
+		key:      key,
+		previous: t.transientStorage.Get(addr, key),
+	})
+	t.transientStorage.Set(addr, key, value)
+}
+
+func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul, isShanghai bool) (uint64, error) {
 	cost := uint64(0)
 
 	// Contract creation is only paid on the homestead fork
@@ -1068,6 +1197,22 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 		}
 
 		cost += zeros * 4
+
+		// EIP-3860: the init code of a contract creation is paid per word
+		if msg.IsContractCreation() && isShanghai {
+			words := (uint64(len(payload)) + 31) / 32
+			if (math.MaxUint64-cost)/TxInitCodeWordGas < words {
+				return 0, ErrIntrinsicGasOverflow
+			}
+
+			cost += words * TxInitCodeWordGas
+		}
+	}
+
+	// EIP-2930: addresses and storage keys of the access list are paid upfront
+	if len(msg.AccessList) > 0 {
+		cost += uint64(len(msg.AccessList)) * TxAccessListAddressGas
+		cost += uint64(msg.AccessList.StorageKeys()) * TxAccessListStorageKeyGas
 	}
 
 	return cost, nil
@@ -1078,6 +1223,16 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 // 1. the nonce of the message caller is correct
 // 2. caller has enough balance to cover transaction fee(gaslimit * gasprice * val) or fee(gasfeecap * gasprice * val)
 func checkAndProcessTx(msg *types.Transaction, t *Transition) error {
+	// 0. the transaction type is supported by the active forks
+	if msg.Type == types.AccessListTx && !t.config.Berlin {
+		return NewTransitionApplicationError(ErrTxTypeNotSupported, false)
+	}
+
+	// the init code of a contract creation doesn't exceed the limit (EIP-3860)
+	if t.config.Shanghai && msg.IsContractCreation() && len(msg.Input) > runtime.MaxInitCodeSize {
+		return NewTransitionApplicationError(runtime.ErrMaxInitCodeSizeExceeded, false)
+	}
+
 	// 1. the nonce of the message caller is correct
 	if err := t.nonceCheck(msg); err != nil {
 		return NewTransitionApplicationError(err, true)
@@ -1151,9 +1306,15 @@ func (t *Transition) captureCallEnd(c *runtime.Contract, result *runtime.Executi
 		return
 	}
 
+	var gasUsed uint64
+	if c.Gas > result.GasLeft {
+		gasUsed = c.Gas - result.GasLeft
+	}
+
 	t.ctx.Tracer.CallEnd(
 		c.Depth,
 		result.ReturnValue,
+		gasUsed,
 		result.Err,
 	)
 }
@@ -154,3 +154,127 @@ func Test_Transition_checkDynamicFees(t *testing.T) {
 		})
 	}
 }
+
+func TestTransientStorageRevert(t *testing.T) {
+	t.Parallel()
+
+	var (
+		caller   = types.Address{0x1}
+		contract = types.Address{0x2}
+	)
+
+	state := newStateWithPreState(nil)
+	tt := NewTransition(chain.ForksInTime{Byzantium: true, Cancun: true}, state, newTxn(state))
+
+	// TSTORE(1, 10) followed by REVERT(0, 0)
+	tt.state.SetCode(contract, []byte{0x60, 0x0a, 0x60, 0x01, 0x5d, 0x60, 0x00, 0x60, 0x00, 0xfd})
+
+	result := tt.Call2(caller, contract, nil, big.NewInt(0), 100000)
+	require.ErrorIs(t, result.Err, runtime.ErrExecutionReverted)
+
+	assert.Equal(t, types.ZeroHash, tt.GetTransientState(contract, types.BytesToHash([]byte{0x1})))
+
+	// the same code without REVERT keeps the value
+	tt.state.SetCode(contract, []byte{0x60, 0x0a, 0x60, 0x01, 0x5d, 0x00})
+
+	result = tt.Call2(caller, contract, nil, big.NewInt(0), 100000)
+	require.NoError(t, result.Err)
+
+	assert.Equal(t, types.BytesToHash([]byte{0xa}), tt.GetTransientState(contract, types.BytesToHash([]byte{0x1})))
+}
+
+func TestAccessListRevert(t *testing.T) {
+	t.Parallel()
+
+	var (
+		caller   = types.Address{0x1}
+		contract = types.Address{0x2}
+		slot     = types.BytesToHash([]byte{0x1})
+	)
+
+	state := newStateWithPreState(nil)
+	tt := NewTransition(chain.ForksInTime{Byzantium: true, Berlin: true}, state, newTxn(state))
+
+	// the slot added before the call is kept on revert
+	tt.AddSlotToAccessList(caller, slot)
+
+	// SLOAD(1) followed by REVERT(0, 0)
+	tt.state.SetCode(contract, []byte{0x60, 0x01, 0x54, 0x50, 0x60, 0x00, 0x60, 0x00, 0xfd})
+
+	result := tt.Call2(caller, contract, nil, big.NewInt(0), 100000)
+	require.ErrorIs(t, result.Err, runtime.ErrExecutionReverted)
+
+	addressPresent, slotPresent := tt.ContainsAccessListSlot(contract, slot)
+	assert.False(t, addressPresent)
+	assert.False(t, slotPresent)
+
+	_, slotPresent = tt.ContainsAccessListSlot(caller, slot)
+	assert.True(t, slotPresent)
+
+	// the same code without REVERT keeps the slot
+	tt.state.SetCode(contract, []byte{0x60, 0x01, 0x54, 0x50, 0x00})
+
+	result = tt.Call2(caller, contract, nil, big.NewInt(0), 100000)
+	require.NoError(t, result.Err)
+
+	addressPresent, slotPresent = tt.ContainsAccessListSlot(contract, slot)
+	assert.True(t, addressPresent)
+	assert.True(t, slotPresent)
+}
+
+func TestEIP3529Refunds(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		config         chain.ForksInTime
+		selfdestruct   uint64
+		clearing       uint64
+		refundQuotient uint64
+	}{
+		{"before London", chain.ForksInTime{Istanbul: true, Berlin: true}, 24000, 15000, RefundQuotient},
+		// the existing London chains keep the refunds until EIP-3529 is activated
+		{"London", chain.ForksInTime{Istanbul: true, Berlin: true, London: true}, 24000, 15000, RefundQuotient},
+		{"EIP-3529", chain.ForksInTime{Istanbul: true, Berlin: true, London: true, EIP3529: true}, 0, 4800,
+			RefundQuotientEIP3529},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := newStateWithPreState(nil)
+			tt := NewTransition(c.config, state, newTxn(state))
+
+			tt.Selfdestruct(types.Address{0x1}, types.Address{0x2})
+			assert.Equal(t, c.selfdestruct, tt.GetRefund())
+			assert.Equal(t, c.refundQuotient, tt.refundQuotient())
+
+			state = newStateWithPreState(defaultPreState)
+			tt = NewTransition(c.config, state, newTxn(state))
+
+			tt.SetStorage(addr1, hash1, types.ZeroHash, &c.config)
+			assert.Equal(t, c.clearing, tt.GetRefund())
+		})
+	}
+}
+
+func TestTransactionGasCost_InitCode(t *testing.T) {
+	t.Parallel()
+
+	tx := &types.Transaction{
+		Input: make([]byte, 33),
+	}
+
+	beforeShanghai, err := TransactionGasCost(tx, true, true, false)
+	require.NoError(t, err)
+
+	afterShanghai, err := TransactionGasCost(tx, true, true, true)
+	require.NoError(t, err)
+
+	// 33 bytes of init code take 2 words
+	assert.Equal(t, TxGasContractCreation+33*4, beforeShanghai)
+	assert.Equal(t, beforeShanghai+2*TxInitCodeWordGas, afterShanghai)
+}
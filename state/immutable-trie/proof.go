@@ -0,0 +1,184 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	errInvalidProofNode = errors.New("invalid proof node")
+	errMissingProofNode = errors.New("missing proof node")
+)
+
+// Prove returns the merkle proof of the key in the trie with the given root.
+// The proof is the list of the RLP encoded nodes on the path from the root to the key,
+// which also proves the absence of the key if it is not part of the trie
+func Prove(root types.Hash, key []byte, storage Storage) ([][]byte, error) {
+	proof := [][]byte{}
+
+	if root == types.EmptyRootHash {
+		return proof, nil
+	}
+
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	hash, nibbles := root.Bytes(), bytesToHexNibbles(key)
+
+	for hash != nil {
+		data, ok := storage.Get(hash)
+		if !ok || len(data) == 0 {
+			return nil, fmt.Errorf("trie node %s not found", types.BytesToHash(hash))
+		}
+
+		proof = append(proof, append([]byte{}, data...))
+
+		v, err := p.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if hash, nibbles, _, err = walkProofNode(v, nibbles); err != nil {
+			return nil, err
+		}
+	}
+
+	return proof, nil
+}
+
+// VerifyProof verifies the merkle proof of the key against the given root.
+// It returns the value of the key, or nil if the proof shows that the key is not part of the trie
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	if root == types.EmptyRootHash && len(proof) == 0 {
+		return nil, nil
+	}
+
+	nodes := make(map[types.Hash][]byte, len(proof))
+	for _, node := range proof {
+		nodes[types.BytesToHash(crypto.Keccak256(node))] = node
+	}
+
+	p := &fastrlp.Parser{}
+
+	hash, nibbles := root.Bytes(), bytesToHexNibbles(key)
+
+	for {
+		data, ok := nodes[types.BytesToHash(hash)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errMissingProofNode, types.BytesToHash(hash))
+		}
+
+		v, err := p.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+
+		var value []byte
+
+		if hash, nibbles, value, err = walkProofNode(v, nibbles); err != nil {
+			return nil, err
+		}
+
+		if hash == nil {
+			return value, nil
+		}
+	}
+}
+
+// walkProofNode follows the key nibbles through the given node and the nodes embedded in it.
+// It returns either the hash of the next node to resolve together with the remaining nibbles,
+// or the value stored under the key (nil if the key is not part of the trie)
+func walkProofNode(v *fastrlp.Value, nibbles []byte) ([]byte, []byte, []byte, error) {
+	for {
+		if v.Type() != fastrlp.TypeArray {
+			return nil, nil, nil, errInvalidProofNode
+		}
+
+		var child *fastrlp.Value
+
+		switch v.Elems() {
+		case 2:
+			if v.Get(0).Type() != fastrlp.TypeBytes || !isValidCompact(v.Get(0).Raw()) {
+				return nil, nil, nil, errInvalidProofNode
+			}
+
+			key := decodeCompact(v.Get(0).Raw())
+			if hasTerminator(key) {
+				// leaf node
+				if !bytes.Equal(key, nibbles) {
+					return nil, nil, nil, nil
+				}
+
+				return nil, nil, copyValue(v.Get(1)), nil
+			}
+
+			// extension node
+			if len(key) > len(nibbles) || !bytes.Equal(nibbles[:len(key)], key) {
+				return nil, nil, nil, nil
+			}
+
+			child, nibbles = v.Get(1), nibbles[len(key):]
+
+		case 17:
+			if len(nibbles) == 0 {
+				return nil, nil, nil, errInvalidProofNode
+			}
+
+			if nibbles[0] == 16 {
+				return nil, nil, copyValue(v.Get(16)), nil
+			}
+
+			child, nibbles = v.Get(int(nibbles[0])), nibbles[1:]
+
+		default:
+			return nil, nil, nil, errInvalidProofNode
+		}
+
+		if child.Type() == fastrlp.TypeArray {
+			// the child is small enough to be embedded in its parent
+			v = child
+
+			continue
+		}
+
+		switch raw := child.Raw(); len(raw) {
+		case 0:
+			return nil, nil, nil, nil
+		case types.HashLength:
+			return append([]byte{}, raw...), nibbles, nil, nil
+		default:
+			return nil, nil, nil, errInvalidProofNode
+		}
+	}
+}
+
+// isValidCompact checks that the compact encoded key of a short node can be decoded.
+// The first nibble holds the flags (terminator and odd length), and it is followed
+// by a zero padding nibble if the key has an even number of nibbles
+func isValidCompact(compact []byte) bool {
+	if len(compact) == 0 {
+		return false
+	}
+
+	flags := compact[0] >> 4
+	if flags > 3 {
+		return false
+	}
+
+	// even length keys are padded with a zero nibble
+	return flags&1 == 1 || compact[0]&0x0f == 0
+}
+
+func copyValue(v *fastrlp.Value) []byte {
+	if v.Type() != fastrlp.TypeBytes || len(v.Raw()) == 0 {
+		return nil
+	}
+
+	return append([]byte{}, v.Raw()...)
+}
@@ -0,0 +1,163 @@
+package itrie
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func buildProofTrie(t *testing.T, entries map[string][]byte) (types.Hash, Storage) {
+	t.Helper()
+
+	storage := NewMemoryStorage()
+
+	txn := NewTrie().Txn(storage)
+	txn.batch = storage
+
+	for k, v := range entries {
+		txn.Insert([]byte(k), v)
+	}
+
+	root, err := txn.Hash()
+	require.NoError(t, err)
+
+	return types.BytesToHash(root), storage
+}
+
+func TestProof(t *testing.T) {
+	t.Parallel()
+
+	entries := map[string][]byte{}
+
+	for i := 0; i < 200; i++ {
+		key := make([]byte, 32)
+		rand.Read(key)
+
+		entries[string(key)] = append([]byte{0x1}, key[:i%32]...)
+	}
+
+	// short keys and values produce nodes embedded in their parents
+	entries["a"] = []byte{0x1}
+	entries["ab"] = []byte{0x2}
+
+	root, storage := buildProofTrie(t, entries)
+
+	t.Run("proves existing keys", func(t *testing.T) {
+		t.Parallel()
+
+		for k, v := range entries {
+			proof, err := Prove(root, []byte(k), storage)
+			require.NoError(t, err)
+			require.NotEmpty(t, proof)
+
+			value, err := VerifyProof(root, []byte(k), proof)
+			require.NoError(t, err)
+			assert.Equal(t, v, value)
+		}
+	})
+
+	t.Run("proves absent key", func(t *testing.T) {
+		t.Parallel()
+
+		key := []byte("missing key")
+
+		proof, err := Prove(root, key, storage)
+		require.NoError(t, err)
+		require.NotEmpty(t, proof)
+
+		value, err := VerifyProof(root, key, proof)
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("rejects incomplete proof", func(t *testing.T) {
+		t.Parallel()
+
+		for k := range entries {
+			proof, err := Prove(root, []byte(k), storage)
+			require.NoError(t, err)
+
+			_, err = VerifyProof(root, []byte(k), proof[:len(proof)-1])
+			assert.ErrorIs(t, err, errMissingProofNode)
+
+			break
+		}
+	})
+
+	t.Run("rejects proof for another root", func(t *testing.T) {
+		t.Parallel()
+
+		proof, err := Prove(root, []byte("a"), storage)
+		require.NoError(t, err)
+
+		_, err = VerifyProof(types.StringToHash("0x1"), []byte("a"), proof)
+		assert.ErrorIs(t, err, errMissingProofNode)
+	})
+}
+
+func TestProof_EmptyTrie(t *testing.T) {
+	t.Parallel()
+
+	proof, err := Prove(types.EmptyRootHash, []byte("key"), NewMemoryStorage())
+	require.NoError(t, err)
+	assert.Empty(t, proof)
+
+	value, err := VerifyProof(types.EmptyRootHash, []byte("key"), proof)
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestProof_SingleLeaf(t *testing.T) {
+	t.Parallel()
+
+	root, storage := buildProofTrie(t, map[string][]byte{"key": []byte("value")})
+
+	proof, err := Prove(root, []byte("key"), storage)
+	require.NoError(t, err)
+	require.Len(t, proof, 1)
+
+	value, err := VerifyProof(root, []byte("key"), proof)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestProof_MalformedShortNode(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		key  []byte
+	}{
+		{"empty key", []byte{}},
+		{"invalid flags", []byte{0x50}},
+		{"non zero padding", []byte{0x2a}},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			arena := &fastrlp.Arena{}
+
+			node := arena.NewArray()
+			node.Set(arena.NewBytes(c.key))
+			node.Set(arena.NewBytes([]byte("value")))
+
+			raw := node.MarshalTo(nil)
+			root := types.BytesToHash(crypto.Keccak256(raw))
+
+			require.NotPanics(t, func() {
+				_, err := VerifyProof(root, []byte("key"), [][]byte{raw})
+				assert.ErrorIs(t, err, errInvalidProofNode)
+			})
+		})
+	}
+}
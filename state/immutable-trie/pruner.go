@@ -0,0 +1,716 @@
+package itrie
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// pruneDeleteBatchSize is the number of trie nodes removed from the storage at once
+	pruneDeleteBatchSize = 10000
+
+	// pruneRefLength is the length of the encoded reference count of a trie node
+	pruneRefLength = 8
+)
+
+var (
+	// pruneRefPrefix is the prefix of the reference counts of the trie nodes
+	pruneRefPrefix = []byte("prune-ref")
+
+	// pruneProgressKey is the key of the pruning progress, see pruneProgress
+	pruneProgressKey = []byte("prune-progress")
+)
+
+var (
+	errPrunerClosed       = errors.New("pruner is closed")
+	errInvalidTrieNode    = errors.New("invalid trie node")
+	errStorageNotPrunable = errors.New("trie storage does not support pruning")
+)
+
+// PruningStorage is the trie storage which counts the references to its trie nodes,
+// so that the nodes which are not referenced by the retained states anymore are removed
+// without walking the retained states. A node is referenced by every written node having it as a child
+// and by every retained block having it as the state root
+type PruningStorage struct {
+	PrunableStorage
+
+	lock sync.Mutex
+	// counting is set once the reference counts of the storage are initialized
+	counting bool
+}
+
+// NewPruningStorage wraps the storage so it can be garbage-collected by the pruner.
+// The references are counted from the start if the pruning of the storage is initialized already,
+// since the nodes written before the first pruning are counted by it
+func NewPruningStorage(storage Storage) (*PruningStorage, error) {
+	prunable, ok := storage.(PrunableStorage)
+	if !ok {
+		return nil, errStorageNotPrunable
+	}
+
+	p := &PruningStorage{PrunableStorage: prunable}
+	_, p.counting = p.readProgress()
+
+	return p, nil
+}
+
+func (p *PruningStorage) Put(k, v []byte) {
+	batch := p.Batch()
+	batch.Put(k, v)
+	batch.Write()
+}
+
+func (p *PruningStorage) Batch() Batch {
+	return &pruningBatch{storage: p}
+}
+
+// writeNodes writes the trie nodes along with the references to their children.
+// The references of the nodes which are in the storage already were counted when they were written
+func (p *PruningStorage) writeNodes(keys, values [][]byte) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var (
+		batch   = p.PrunableStorage.Batch()
+		refs    = newPruneRefs(p.PrunableStorage)
+		written = make(map[string]struct{}, len(keys))
+	)
+
+	for i, key := range keys {
+		// the existence is checked before the put, since some batches write through
+		if p.counting && p.isNewNode(key, written) {
+			// the nodes are encoded by the trie, so that they are always valid
+			_ = forEachNodeRef(values[i], refs.add)
+		}
+
+		batch.Put(key, values[i])
+	}
+
+	// the counts only grow here, so that none of them is released
+	refs.write(batch)
+	batch.Write()
+}
+
+// isNewNode returns true if the node is neither stored nor written before by the same batch
+func (p *PruningStorage) isNewNode(key []byte, written map[string]struct{}) bool {
+	if _, ok := written[string(key)]; ok {
+		return false
+	}
+
+	written[string(key)] = struct{}{}
+
+	_, ok := p.PrunableStorage.Get(key)
+
+	return !ok
+}
+
+// updateRoots references the added state roots and releases the removed ones along with the progress
+// of the pruning, and removes the nodes which are not referenced anymore. It returns the number of removed nodes
+func (p *PruningStorage) updateRoots(added, removed []types.Hash, progress *pruneProgress) (int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	refs := newPruneRefs(p.PrunableStorage)
+
+	// the roots are added first, so that a root both added and removed, such as the root
+	// of the blocks not changing the state, is not removed
+	for _, root := range added {
+		if root != types.EmptyRootHash {
+			refs.add(root)
+		}
+	}
+
+	var (
+		stack   = append([]types.Hash{}, removed...)
+		deleted = make([][]byte, 0)
+	)
+
+	for len(stack) > 0 {
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if hash == types.EmptyRootHash {
+			continue
+		}
+
+		// the node is not referenced anymore once its last reference is released
+		if !refs.release(hash) {
+			continue
+		}
+
+		data, ok := p.PrunableStorage.Get(hash.Bytes())
+		if !ok {
+			continue
+		}
+
+		deleted = append(deleted, hash.Bytes())
+
+		if err := forEachNodeRef(data, func(child types.Hash) {
+			stack = append(stack, child)
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	batch := p.PrunableStorage.Batch()
+	released := refs.write(batch)
+	batch.Put(pruneProgressKey, progress.encode())
+	batch.Write()
+
+	// the nodes are removed after their counts are written, so that an interrupted removal
+	// leaves the unreferenced nodes behind rather than the counts of the removed nodes
+	if err := p.deleteKeys(append(deleted, released...)); err != nil {
+		return 0, err
+	}
+
+	return len(deleted), nil
+}
+
+// initialize counts the references to the nodes reachable from the given roots and removes the rest of the nodes.
+// It walks the whole retained state and the whole storage, so that it's done once, when the pruning
+// of the storage starts. It returns the numbers of the retained and the removed nodes
+func (p *PruningStorage) initialize(
+	roots []types.Hash,
+	progress *pruneProgress,
+	closeCh <-chan struct{},
+) (int, int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var (
+		counts = map[types.Hash]uint64{}
+		stack  = make([]types.Hash, 0)
+	)
+
+	reference := func(hash types.Hash) {
+		if counts[hash]++; counts[hash] == 1 {
+			stack = append(stack, hash)
+		}
+	}
+
+	for _, root := range roots {
+		if root == types.EmptyRootHash {
+			continue
+		}
+
+		if _, ok := p.PrunableStorage.Get(root.Bytes()); !ok {
+			// the state was already removed, e.g. with a shorter retention
+			continue
+		}
+
+		reference(root)
+	}
+
+	for len(stack) > 0 {
+		if isPrunerClosed(closeCh) {
+			return 0, 0, errPrunerClosed
+		}
+
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		data, ok := p.PrunableStorage.Get(hash.Bytes())
+		if !ok || len(data) == 0 {
+			return 0, 0, fmt.Errorf("trie node %s not found", hash)
+		}
+
+		if err := forEachNodeRef(data, reference); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	keys := make([][]byte, 0, pruneDeleteBatchSize)
+	deleted := 0
+
+	err := p.PrunableStorage.IterateNodes(func(key []byte) error {
+		if isPrunerClosed(closeCh) {
+			return errPrunerClosed
+		}
+
+		if _, ok := counts[types.BytesToHash(key)]; ok {
+			return nil
+		}
+
+		if keys = append(keys, append([]byte{}, key...)); len(keys) == pruneDeleteBatchSize {
+			deleted += len(keys)
+			err := p.PrunableStorage.DeleteNodes(keys)
+			keys = keys[:0]
+
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := p.PrunableStorage.DeleteNodes(keys); err != nil {
+		return 0, 0, err
+	}
+
+	deleted += len(keys)
+
+	batch := p.PrunableStorage.Batch()
+
+	for hash, count := range counts {
+		batch.Put(pruneRefKey(hash), encodePruneRef(count))
+	}
+
+	// the progress is written last, so that an interrupted initialization is started over
+	batch.Put(pruneProgressKey, progress.encode())
+	batch.Write()
+
+	p.counting = true
+
+	return len(counts), deleted, nil
+}
+
+// readProgress returns the progress of the pruning, or false if the reference counts are not initialized yet
+func (p *PruningStorage) readProgress() (*pruneProgress, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	data, ok := p.PrunableStorage.Get(pruneProgressKey)
+	if !ok {
+		return nil, false
+	}
+
+	progress := &pruneProgress{}
+	if err := progress.decode(data); err != nil {
+		return nil, false
+	}
+
+	return progress, true
+}
+
+func (p *PruningStorage) deleteKeys(keys [][]byte) error {
+	for len(keys) > 0 {
+		n := len(keys)
+		if n > pruneDeleteBatchSize {
+			n = pruneDeleteBatchSize
+		}
+
+		if err := p.PrunableStorage.DeleteNodes(keys[:n]); err != nil {
+			return err
+		}
+
+		keys = keys[n:]
+	}
+
+	return nil
+}
+
+type pruningBatch struct {
+	storage *PruningStorage
+	keys    [][]byte
+	values  [][]byte
+}
+
+func (b *pruningBatch) Put(k, v []byte) {
+	// the trie reuses its buffers once the node is written to the batch
+	b.keys = append(b.keys, append([]byte{}, k...))
+	b.values = append(b.values, append([]byte{}, v...))
+}
+
+func (b *pruningBatch) Write() {
+	b.storage.writeNodes(b.keys, b.values)
+}
+
+// pruneRefs are the reference counts of the trie nodes changed by a single write
+type pruneRefs struct {
+	storage Storage
+	counts  map[types.Hash]uint64
+}
+
+func newPruneRefs(storage Storage) *pruneRefs {
+	return &pruneRefs{storage: storage, counts: map[types.Hash]uint64{}}
+}
+
+func (r *pruneRefs) get(hash types.Hash) uint64 {
+	if count, ok := r.counts[hash]; ok {
+		return count
+	}
+
+	data, ok := r.storage.Get(pruneRefKey(hash))
+	if !ok || len(data) != pruneRefLength {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(data)
+}
+
+func (r *pruneRefs) add(hash types.Hash) {
+	r.counts[hash] = r.get(hash) + 1
+}
+
+// release releases a reference to the node and reports whether it was the last one.
+// A node which isn't referenced, such as a node of the state never written to the chain, is kept
+func (r *pruneRefs) release(hash types.Hash) bool {
+	count := r.get(hash)
+	if count == 0 {
+		return false
+	}
+
+	r.counts[hash] = count - 1
+
+	return count == 1
+}
+
+// write writes the changed counts to the batch, and returns the keys of the released counts
+// which are to be removed from the storage
+func (r *pruneRefs) write(batch Batch) [][]byte {
+	released := make([][]byte, 0)
+
+	for hash, count := range r.counts {
+		if count == 0 {
+			released = append(released, pruneRefKey(hash))
+
+			continue
+		}
+
+		batch.Put(pruneRefKey(hash), encodePruneRef(count))
+	}
+
+	return released
+}
+
+func pruneRefKey(hash types.Hash) []byte {
+	return append(append(make([]byte, 0, len(pruneRefPrefix)+types.HashLength), pruneRefPrefix...), hash.Bytes()...)
+}
+
+func encodePruneRef(count uint64) []byte {
+	data := make([]byte, pruneRefLength)
+	binary.BigEndian.PutUint64(data, count)
+
+	return data
+}
+
+// pruneProgress is the range of the blocks whose state roots are referenced by the storage.
+// The roots of the blocks from low up to next are referenced, along with the roots of the checkpoints before low
+type pruneProgress struct {
+	next uint64
+	low  uint64
+}
+
+func (p *pruneProgress) encode() []byte {
+	data := make([]byte, 2*pruneRefLength)
+	binary.BigEndian.PutUint64(data, p.next)
+	binary.BigEndian.PutUint64(data[pruneRefLength:], p.low)
+
+	return data
+}
+
+func (p *pruneProgress) decode(data []byte) error {
+	if len(data) != 2*pruneRefLength {
+		return fmt.Errorf("invalid pruning progress length %d", len(data))
+	}
+
+	p.next = binary.BigEndian.Uint64(data)
+	p.low = binary.BigEndian.Uint64(data[pruneRefLength:])
+
+	return nil
+}
+
+type pruneHeaderGetter interface {
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+}
+
+// PrunerConfig is the configuration of the state pruner
+type PrunerConfig struct {
+	// Retain is the number of the latest block states which are kept
+	Retain uint64
+
+	// CheckpointInterval is the interval of blocks whose states are kept forever,
+	// zero disables checkpoints
+	CheckpointInterval uint64
+}
+
+// Pruner garbage-collects the trie nodes which are not reachable
+// from the states of the retained blocks
+type Pruner struct {
+	logger  hclog.Logger
+	state   *State
+	storage *PruningStorage
+	headers pruneHeaderGetter
+	config  PrunerConfig
+
+	running atomic.Bool
+	// lock guards closed, so that no pruning starts once the pruner is closed
+	lock    sync.Mutex
+	closed  bool
+	wg      sync.WaitGroup
+	closeCh chan struct{}
+}
+
+// NewPruner creates the pruner of the given state
+func NewPruner(
+	logger hclog.Logger,
+	state *State,
+	storage *PruningStorage,
+	headers pruneHeaderGetter,
+	config PrunerConfig,
+) *Pruner {
+	return &Pruner{
+		logger:  logger.Named("pruner"),
+		state:   state,
+		storage: storage,
+		headers: headers,
+		config:  config,
+		closeCh: make(chan struct{}),
+	}
+}
+
+// NotifyHead starts the pruning up to the new head in the background,
+// unless the previous one is still running or the pruner is closed
+func (p *Pruner) NotifyHead(head *types.Header) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed || !p.running.CompareAndSwap(false, true) {
+		return
+	}
+
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+		defer p.running.Store(false)
+
+		if err := p.Prune(head); err != nil && !errors.Is(err, errPrunerClosed) {
+			p.logger.Error("failed to prune state", "block", head.Number, "err", err)
+		}
+	}()
+}
+
+// Close stops the running pruning, if any
+func (p *Pruner) Close() {
+	p.lock.Lock()
+
+	if p.closed {
+		p.lock.Unlock()
+
+		return
+	}
+
+	p.closed = true
+	close(p.closeCh)
+	p.lock.Unlock()
+
+	p.wg.Wait()
+}
+
+// Prune references the states of the blocks written since the previous pruning up to the given head,
+// and releases the states of the blocks which are not retained anymore, except for the checkpoint blocks.
+// The nodes which are not referenced anymore are removed, so that the work is bound by the number of
+// the new and the removed nodes. The first pruning of the storage walks the retained states
+// and the whole storage once, to count the references to the nodes and remove the unreachable ones
+func (p *Pruner) Prune(head *types.Header) error {
+	start := time.Now()
+
+	progress, ok := p.storage.readProgress()
+	if !ok {
+		return p.initialize(head)
+	}
+
+	added := make([]types.Hash, 0)
+
+	for ; progress.next <= head.Number; progress.next++ {
+		root, err := p.stateRoot(progress.next)
+		if err != nil {
+			return err
+		}
+
+		added = append(added, root)
+	}
+
+	removed := make([]types.Hash, 0)
+
+	for ; progress.low+p.config.Retain <= head.Number && progress.low < progress.next; progress.low++ {
+		if p.isCheckpoint(progress.low) {
+			continue
+		}
+
+		root, err := p.stateRoot(progress.low)
+		if err != nil {
+			return err
+		}
+
+		removed = append(removed, root)
+	}
+
+	deleted, err := p.storage.updateRoots(added, removed, progress)
+	if err != nil {
+		return err
+	}
+
+	if deleted > 0 {
+		// the cached tries may reference the removed nodes
+		p.state.cache.Purge()
+	}
+
+	p.logger.Debug("state pruned", "block", head.Number, "released states", len(removed),
+		"deleted nodes", deleted, "duration", time.Since(start))
+
+	return nil
+}
+
+// initialize starts the pruning of the storage at the given head
+func (p *Pruner) initialize(head *types.Header) error {
+	start := time.Now()
+
+	progress := &pruneProgress{next: head.Number + 1}
+	if head.Number >= p.config.Retain {
+		progress.low = head.Number - p.config.Retain + 1
+	}
+
+	roots := make([]types.Hash, 0)
+
+	for n := uint64(0); n <= head.Number; n++ {
+		if n < progress.low && !p.isCheckpoint(n) {
+			continue
+		}
+
+		root, err := p.stateRoot(n)
+		if err != nil {
+			p.logger.Debug("header of retained block not found", "block", n)
+
+			continue
+		}
+
+		roots = append(roots, root)
+	}
+
+	p.logger.Info("initializing state pruning, the whole state storage is walked once", "block", head.Number)
+
+	retained, deleted, err := p.storage.initialize(roots, progress, p.closeCh)
+	if err != nil {
+		return err
+	}
+
+	p.state.cache.Purge()
+
+	p.logger.Info("state pruning initialized", "block", head.Number, "retained nodes", retained,
+		"deleted nodes", deleted, "duration", time.Since(start))
+
+	return nil
+}
+
+func (p *Pruner) stateRoot(number uint64) (types.Hash, error) {
+	header, ok := p.headers.GetHeaderByNumber(number)
+	if !ok {
+		return types.ZeroHash, fmt.Errorf("header of block %d not found", number)
+	}
+
+	return header.StateRoot, nil
+}
+
+func (p *Pruner) isCheckpoint(number uint64) bool {
+	return p.config.CheckpointInterval > 0 && number%p.config.CheckpointInterval == 0
+}
+
+// forEachNodeRef calls the handler with the hash of every node referenced by the trie node.
+// Account leaves of the state trie also reference the storage tries of the accounts
+func forEachNodeRef(data []byte, handler func(types.Hash)) error {
+	parser := parserPool.Get()
+	defer parserPool.Put(parser)
+
+	v, err := parser.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	return forEachValueRef(v, handler)
+}
+
+func forEachValueRef(v *fastrlp.Value, handler func(types.Hash)) error {
+	if v.Type() != fastrlp.TypeArray {
+		return errInvalidTrieNode
+	}
+
+	switch v.Elems() {
+	case 2:
+		if hasTerminator(decodeCompact(v.Get(0).Raw())) {
+			return forEachLeafRef(v.Get(1), handler)
+		}
+
+		return forEachChildRef(v.Get(1), handler)
+
+	case 17:
+		for i := 0; i < 16; i++ {
+			if err := forEachChildRef(v.Get(i), handler); err != nil {
+				return err
+			}
+		}
+
+		if len(v.Get(16).Raw()) == 0 {
+			return nil
+		}
+
+		return forEachLeafRef(v.Get(16), handler)
+
+	default:
+		return errInvalidTrieNode
+	}
+}
+
+func forEachChildRef(v *fastrlp.Value, handler func(types.Hash)) error {
+	if v.Type() == fastrlp.TypeArray {
+		// the child is embedded in its parent
+		return forEachValueRef(v, handler)
+	}
+
+	switch raw := v.Raw(); len(raw) {
+	case 0:
+		return nil
+	case types.HashLength:
+		handler(types.BytesToHash(raw))
+
+		return nil
+	default:
+		return errInvalidTrieNode
+	}
+}
+
+// forEachLeafRef calls the handler with the storage root of the account leaf.
+// The values of the storage tries are encoded as RLP strings, while the accounts are encoded as RLP lists,
+// so that the leaves of both tries are told apart without knowing the trie of the node
+func forEachLeafRef(v *fastrlp.Value, handler func(types.Hash)) error {
+	parser := parserPool.Get()
+	defer parserPool.Put(parser)
+
+	leaf, err := parser.Parse(v.Raw())
+	if err != nil || leaf.Type() != fastrlp.TypeArray {
+		return nil
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(v.Raw()); err != nil {
+		return err
+	}
+
+	if account.Root != types.EmptyRootHash {
+		handler(account.Root)
+	}
+
+	return nil
+}
+
+func isPrunerClosed(closeCh <-chan struct{}) bool {
+	select {
+	case <-closeCh:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,315 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockPruneHeaders map[uint64]*types.Header
+
+func (m mockPruneHeaders) GetHeaderByNumber(n uint64) (*types.Header, bool) {
+	header, ok := m[n]
+
+	return header, ok
+}
+
+var (
+	pruneTestContract = types.StringToAddress("0x1000")
+	pruneTestSlot     = types.StringToHash("0x1")
+)
+
+// commitPruneTestBlock commits the state of the block, which changes a balance
+// and a storage slot of a contract and adds a new account
+func commitPruneTestBlock(t *testing.T, snap state.Snapshot, number uint64) (state.Snapshot, *types.Header) {
+	t.Helper()
+
+	contract, err := snap.GetAccount(pruneTestContract)
+	require.NoError(t, err)
+
+	contractRoot := types.EmptyRootHash
+	if contract != nil {
+		contractRoot = contract.Root
+	}
+
+	snap, root := snap.Commit([]*state.Object{
+		{
+			Address:  pruneTestContract,
+			Balance:  big.NewInt(int64(number)),
+			CodeHash: types.EmptyCodeHash,
+			Root:     contractRoot,
+			Storage: []*state.StorageObject{
+				{Key: pruneTestSlot.Bytes(), Val: types.BytesToHash([]byte{byte(number + 1)}).Bytes()},
+			},
+		},
+		{
+			Address:  types.BytesToAddress([]byte{byte(number + 1)}),
+			Balance:  big.NewInt(1),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		},
+	})
+
+	return snap, &types.Header{Number: number, StateRoot: types.BytesToHash(root)}
+}
+
+// buildPrunedChain commits the states of the given number of blocks
+func buildPrunedChain(t *testing.T, st *State, blocks uint64) mockPruneHeaders {
+	t.Helper()
+
+	headers := mockPruneHeaders{}
+	snap := st.NewSnapshot()
+
+	for i := uint64(0); i < blocks; i++ {
+		snap, headers[i] = commitPruneTestBlock(t, snap, i)
+	}
+
+	return headers
+}
+
+func newTestPruner(t *testing.T, config PrunerConfig) (*Pruner, *State, *PruningStorage) {
+	t.Helper()
+
+	storage, err := NewPruningStorage(NewMemoryStorage())
+	require.NoError(t, err)
+
+	st := NewState(storage)
+
+	return NewPruner(hclog.NewNullLogger(), st, storage, mockPruneHeaders{}, config), st, storage
+}
+
+// assertPrunedStates checks that only the states of the retained blocks are kept
+func assertPrunedStates(t *testing.T, st *State, headers mockPruneHeaders, retained []uint64) {
+	t.Helper()
+
+	isRetained := map[uint64]bool{}
+	for _, n := range retained {
+		isRetained[n] = true
+	}
+
+	for n, header := range headers {
+		snap, err := st.NewSnapshotAt(header.StateRoot)
+		if !isRetained[n] {
+			assert.Error(t, err, "block %d", n)
+
+			continue
+		}
+
+		require.NoError(t, err, "block %d", n)
+
+		contract, err := snap.GetAccount(pruneTestContract)
+		require.NoError(t, err)
+		require.NotNil(t, contract)
+		assert.Equal(t, big.NewInt(int64(n)), contract.Balance)
+
+		value := snap.GetStorage(pruneTestContract, contract.Root, pruneTestSlot)
+		assert.Equal(t, types.BytesToHash([]byte{byte(n + 1)}), value)
+
+		account, err := snap.GetAccount(types.BytesToAddress([]byte{byte(n + 1)}))
+		require.NoError(t, err)
+		assert.NotNil(t, account)
+	}
+}
+
+// storedNodes returns the keys of the trie nodes in the storage
+func storedNodes(t *testing.T, storage *PruningStorage) map[types.Hash]struct{} {
+	t.Helper()
+
+	nodes := map[types.Hash]struct{}{}
+
+	require.NoError(t, storage.IterateNodes(func(key []byte) error {
+		nodes[types.BytesToHash(key)] = struct{}{}
+
+		return nil
+	}))
+
+	return nodes
+}
+
+func TestPruner_Prune(t *testing.T) {
+	t.Parallel()
+
+	pruner, st, _ := newTestPruner(t, PrunerConfig{Retain: 3, CheckpointInterval: 4})
+
+	// the states written before the pruning started are removed by the first pruning
+	headers := buildPrunedChain(t, st, 10)
+	pruner.headers = headers
+
+	require.NoError(t, pruner.Prune(headers[9]))
+
+	assertPrunedStates(t, st, headers, []uint64{0, 4, 7, 8, 9})
+}
+
+func TestPruner_PruneIncremental(t *testing.T) {
+	t.Parallel()
+
+	var (
+		config                        = PrunerConfig{Retain: 3, CheckpointInterval: 4}
+		pruner, st, storage           = newTestPruner(t, config)
+		initPruner, initSt, initStore = newTestPruner(t, config)
+		headers                       = mockPruneHeaders{}
+		snap                          = st.NewSnapshot()
+	)
+
+	pruner.headers = headers
+
+	// the pruning starts with the genesis and follows every new block
+	for i := uint64(0); i < 10; i++ {
+		snap, headers[i] = commitPruneTestBlock(t, snap, i)
+
+		require.NoError(t, pruner.Prune(headers[i]))
+	}
+
+	assertPrunedStates(t, st, headers, []uint64{0, 4, 7, 8, 9})
+
+	// the nodes left behind match the nodes reachable from the retained states
+	initHeaders := buildPrunedChain(t, initSt, 10)
+	initPruner.headers = initHeaders
+
+	require.NoError(t, initPruner.Prune(initHeaders[9]))
+
+	assert.Equal(t, storedNodes(t, initStore), storedNodes(t, storage))
+
+	// the pruning continues from the progress stored along with the counts
+	restarted, err := NewPruningStorage(storage.PrunableStorage)
+	require.NoError(t, err)
+	assert.True(t, restarted.counting)
+
+	st = NewState(restarted)
+	pruner = NewPruner(hclog.NewNullLogger(), st, restarted, headers, config)
+
+	snap, err = st.NewSnapshotAt(headers[9].StateRoot)
+	require.NoError(t, err)
+
+	for i := uint64(10); i < 12; i++ {
+		snap, headers[i] = commitPruneTestBlock(t, snap, i)
+	}
+
+	require.NoError(t, pruner.Prune(headers[11]))
+
+	assertPrunedStates(t, st, headers, []uint64{0, 4, 8, 9, 10, 11})
+}
+
+func TestPruner_KeepsUnchangedStates(t *testing.T) {
+	t.Parallel()
+
+	pruner, st, _ := newTestPruner(t, PrunerConfig{Retain: 1})
+
+	headers := buildPrunedChain(t, st, 2)
+	pruner.headers = headers
+
+	require.NoError(t, pruner.Prune(headers[1]))
+
+	// the blocks not changing the state share the state root
+	headers[2] = &types.Header{Number: 2, StateRoot: headers[1].StateRoot}
+	headers[3] = &types.Header{Number: 3, StateRoot: headers[1].StateRoot}
+
+	require.NoError(t, pruner.Prune(headers[2]))
+	require.NoError(t, pruner.Prune(headers[3]))
+
+	_, err := st.NewSnapshotAt(headers[3].StateRoot)
+	assert.NoError(t, err)
+}
+
+func TestPruner_KeepsStatesNotWrittenYet(t *testing.T) {
+	t.Parallel()
+
+	pruner, st, _ := newTestPruner(t, PrunerConfig{Retain: 1})
+
+	headers := buildPrunedChain(t, st, 4)
+	pruner.headers = headers
+
+	require.NoError(t, pruner.Prune(headers[3]))
+
+	snap, err := st.NewSnapshotAt(headers[3].StateRoot)
+	require.NoError(t, err)
+
+	// the state of the block which is not yet written to the chain
+	_, pending := commitPruneTestBlock(t, snap, 4)
+
+	require.NoError(t, pruner.Prune(headers[3]))
+
+	_, err = st.NewSnapshotAt(pending.StateRoot)
+	assert.NoError(t, err)
+
+	// the state is retained once its block is written
+	headers[4] = pending
+
+	require.NoError(t, pruner.Prune(headers[4]))
+
+	assertPrunedStates(t, st, headers, []uint64{4})
+}
+
+func TestPruner_NotifyHead(t *testing.T) {
+	t.Parallel()
+
+	pruner, st, _ := newTestPruner(t, PrunerConfig{Retain: 2})
+
+	headers := buildPrunedChain(t, st, 6)
+	pruner.headers = headers
+
+	pruner.NotifyHead(headers[5])
+	pruner.wg.Wait()
+
+	_, err := st.NewSnapshotAt(headers[4].StateRoot)
+	assert.NoError(t, err)
+
+	_, err = st.NewSnapshotAt(headers[3].StateRoot)
+	assert.Error(t, err)
+}
+
+func TestPruner_NotifyHeadAfterClose(t *testing.T) {
+	t.Parallel()
+
+	pruner, st, storage := newTestPruner(t, PrunerConfig{Retain: 1})
+
+	headers := buildPrunedChain(t, st, 3)
+	pruner.headers = headers
+
+	pruner.Close()
+	pruner.NotifyHead(headers[2])
+	pruner.wg.Wait()
+
+	// the pruning doesn't start once the pruner is closed
+	_, ok := storage.readProgress()
+	assert.False(t, ok)
+
+	_, err := st.NewSnapshotAt(headers[0].StateRoot)
+	assert.NoError(t, err)
+
+	// closing again is a no-op
+	pruner.Close()
+}
+
+func TestNewPruningStorage_NotPrunable(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewPruningStorage(&struct{ Storage }{NewMemoryStorage()})
+	assert.ErrorIs(t, err, errStorageNotPrunable)
+}
+
+func TestMemStorage_IterateNodesSkipsNonNodeKeys(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+
+	nodeKey := types.StringToHash("0x1").Bytes()
+	storage.Put(nodeKey, []byte{0x1})
+	storage.Put(append([]byte("code"), nodeKey...), []byte{0x2})
+
+	keys := make([][]byte, 0)
+
+	require.NoError(t, storage.(*memStorage).IterateNodes(func(key []byte) error {
+		keys = append(keys, key)
+
+		return nil
+	}))
+
+	assert.Equal(t, [][]byte{nodeKey}, keys)
+}
@@ -87,6 +87,12 @@ func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
 	return t, nil
 }
 
+// GetProof returns the merkle proof of the key in the trie with the given root.
+// The key is hashed the same way keys are hashed when inserted into the state tries
+func (s *State) GetProof(root types.Hash, key []byte) ([][]byte, error) {
+	return Prove(root, hashit(key), s.storage)
+}
+
 func (s *State) AddState(root types.Hash, t *Trie) {
 	s.cache.Add(root, t)
 }
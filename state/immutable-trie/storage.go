@@ -34,6 +34,17 @@ type Storage interface {
 	Close() error
 }
 
+// PrunableStorage is the trie storage whose nodes can be garbage-collected
+type PrunableStorage interface {
+	Storage
+
+	// IterateNodes calls the handler with the key of every trie node in the storage
+	IterateNodes(handler func(key []byte) error) error
+
+	// DeleteNodes removes the trie nodes with the given keys from the storage
+	DeleteNodes(keys [][]byte) error
+}
+
 // KVStorage is a k/v storage on memory using leveldb
 type KVStorage struct {
 	db *leveldb.DB
@@ -82,6 +93,34 @@ func (kv *KVStorage) Get(k []byte) ([]byte, bool) {
 	return data, true
 }
 
+func (kv *KVStorage) IterateNodes(handler func(key []byte) error) error {
+	iter := kv.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		// code entries are stored under a prefix, only the node keys are hashes
+		if len(iter.Key()) != types.HashLength {
+			continue
+		}
+
+		if err := handler(iter.Key()); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+func (kv *KVStorage) DeleteNodes(keys [][]byte) error {
+	batch := &leveldb.Batch{}
+
+	for _, key := range keys {
+		batch.Delete(key)
+	}
+
+	return kv.db.Write(batch, nil)
+}
+
 func (kv *KVStorage) Close() error {
 	return kv.db.Close()
 }
@@ -152,6 +191,47 @@ func (m *memStorage) Batch() Batch {
 	return &memBatch{db: &m.db, l: new(sync.Mutex)}
 }
 
+func (m *memStorage) IterateNodes(handler func(key []byte) error) error {
+	m.l.Lock()
+	keys := make([][]byte, 0, len(m.db))
+
+	for k := range m.db {
+		key, err := hex.DecodeHex(k)
+		if err != nil {
+			m.l.Unlock()
+
+			return err
+		}
+
+		// code entries are stored under a prefix, only the node keys are hashes
+		if len(key) != types.HashLength {
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+	m.l.Unlock()
+
+	for _, key := range keys {
+		if err := handler(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *memStorage) DeleteNodes(keys [][]byte) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	for _, key := range keys {
+		delete(m.db, hex.EncodeToHex(key))
+	}
+
+	return nil
+}
+
 func (m *memStorage) Close() error {
 	return nil
 }
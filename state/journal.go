@@ -0,0 +1,72 @@
+package state
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// journalEntry is a modification of the transaction scoped state
+// which can be undone when the call frame making it is reverted
+type journalEntry interface {
+	revert(t *Transition)
+}
+
+// journal records the modifications of the transaction scoped state
+// (which is not part of the state trie), in the order they were made
+type journal struct {
+	entries []journalEntry
+}
+
+// append records the modification
+func (j *journal) append(entry journalEntry) {
+	j.entries = append(j.entries, entry)
+}
+
+// snapshot returns the current position of the journal
+func (j *journal) snapshot() int {
+	return len(j.entries)
+}
+
+// revertToSnapshot undoes the modifications made after the given position, in the reverse order
+func (j *journal) revertToSnapshot(t *Transition, snapshot int) {
+	for i := len(j.entries) - 1; i >= snapshot; i-- {
+		j.entries[i].revert(t)
+		j.entries[i] = nil
+	}
+
+	j.entries = j.entries[:snapshot]
+}
+
+// reset drops all the recorded modifications
+func (j *journal) reset() {
+	j.entries = j.entries[:0]
+}
+
+// accessListAddressChange is the addition of the address to the access list
+type accessListAddressChange struct {
+	address types.Address
+}
+
+func (c accessListAddressChange) revert(t *Transition) {
+	t.accessList.DeleteAddress(c.address)
+}
+
+// accessListSlotChange is the addition of the storage slot to the access list
+type accessListSlotChange struct {
+	address types.Address
+	slot    types.Hash
+}
+
+func (c accessListSlotChange) revert(t *Transition) {
+	t.accessList.DeleteSlot(c.address, c.slot)
+}
+
+// transientStorageChange is the modification of the transient storage slot
+type transientStorageChange struct {
+	address  types.Address
+	key      types.Hash
+	previous types.Hash
+}
+
+func (c transientStorageChange) revert(t *Transition) {
+	t.transientStorage.Set(c.address, c.key, c.previous)
+}
@@ -0,0 +1,134 @@
+package runtime
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// AccessList is the set of addresses and storage slots accessed during the transaction execution,
+// as defined in EIP-2929 and EIP-2930
+type AccessList map[types.Address]map[types.Hash]struct{}
+
+// NewAccessList creates a new empty access list
+func NewAccessList() *AccessList {
+	al := make(AccessList)
+
+	return &al
+}
+
+// ContainsAddress returns true if the address is in the access list
+func (al *AccessList) ContainsAddress(address types.Address) bool {
+	_, ok := (*al)[address]
+
+	return ok
+}
+
+// Contains checks if a slot is in the access list, along with the address of its contract
+func (al *AccessList) Contains(address types.Address, slot types.Hash) (bool, bool) {
+	slots, addressPresent := (*al)[address]
+	if !addressPresent {
+		return false, false
+	}
+
+	_, slotPresent := slots[slot]
+
+	return addressPresent, slotPresent
+}
+
+// AddAddress adds the given addresses to the access list
+func (al *AccessList) AddAddress(addresses ...types.Address) {
+	for _, address := range addresses {
+		if _, ok := (*al)[address]; ok {
+			continue
+		}
+
+		(*al)[address] = make(map[types.Hash]struct{})
+	}
+}
+
+// AddSlot adds the given slots of the contract to the access list,
+// along with the contract address itself
+func (al *AccessList) AddSlot(address types.Address, slots ...types.Hash) {
+	slotMap, ok := (*al)[address]
+	if !ok {
+		slotMap = make(map[types.Hash]struct{})
+		(*al)[address] = slotMap
+	}
+
+	for _, slot := range slots {
+		slotMap[slot] = struct{}{}
+	}
+}
+
+// DeleteAddress removes the address, along with its slots, from the access list
+func (al *AccessList) DeleteAddress(address types.Address) {
+	delete(*al, address)
+}
+
+// DeleteSlot removes the slot of the contract from the access list
+func (al *AccessList) DeleteSlot(address types.Address, slot types.Hash) {
+	if slots, ok := (*al)[address]; ok {
+		delete(slots, slot)
+	}
+}
+
+// PrepareAccessList prepopulates the access list with the sender, the receiver,
+// the precompiles and the access list of the transaction, as defined in EIP-2929 and EIP-2930
+func (al *AccessList) PrepareAccessList(
+	from types.Address,
+	to *types.Address,
+	precompiles []types.Address,
+	txAccessList types.TxAccessList,
+) {
+	al.AddAddress(from)
+
+	if to != nil {
+		al.AddAddress(*to)
+	}
+
+	al.AddAddress(precompiles...)
+
+	for _, accessTuple := range txAccessList {
+		al.AddSlot(accessTuple.Address, accessTuple.StorageKeys...)
+	}
+}
+
+// ToTxAccessList converts the access list to the format of the transaction access list,
+// skipping the given addresses unless any of their slots were accessed
+func (al *AccessList) ToTxAccessList(excluded ...types.Address) types.TxAccessList {
+	excludedSet := make(map[types.Address]struct{}, len(excluded))
+	for _, address := range excluded {
+		excludedSet[address] = struct{}{}
+	}
+
+	txAccessList := make(types.TxAccessList, 0, len(*al))
+
+	for address, slots := range *al {
+		if _, ok := excludedSet[address]; ok && len(slots) == 0 {
+			continue
+		}
+
+		keys := make([]types.Hash, 0, len(slots))
+		for slot := range slots {
+			keys = append(keys, slot)
+		}
+
+		sort.Slice(keys, func(i, j int) bool {
+			return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) < 0
+		})
+
+		txAccessList = append(txAccessList, types.AccessTuple{
+			Address:     address,
+			StorageKeys: keys,
+		})
+	}
+
+	// keep the order deterministic
+	sort.Slice(txAccessList, func(i, j int) bool {
+		return bytes.Compare(txAccessList[i].Address.Bytes(), txAccessList[j].Address.Bytes()) < 0
+	})
+
+	return txAccessList
+}
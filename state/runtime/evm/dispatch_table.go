@@ -38,6 +38,7 @@ func init() {
 	register(SMOD, handler{opSMod, 2, 5})
 	register(EXP, handler{opExp, 2, 10})
 
+	register(PUSH0, handler{opPush0, 0, 2})
 	registerRange(PUSH1, PUSH32, opPush, 3)
 	registerRange(DUP1, DUP16, opDup, 3)
 	registerRange(SWAP1, SWAP16, opSwap, 3)
@@ -81,11 +82,16 @@ func init() {
 	register(MLOAD, handler{opMload, 1, 3})
 	register(MSTORE, handler{opMStore, 2, 3})
 	register(MSTORE8, handler{opMStore8, 2, 3})
+	register(MCOPY, handler{opMCopy, 3, 3})
 
 	// store
 	register(SLOAD, handler{opSload, 1, 0})
 	register(SSTORE, handler{opSStore, 2, 0})
 
+	// transient storage
+	register(TLOAD, handler{opTload, 1, 100})
+	register(TSTORE, handler{opTstore, 2, 100})
+
 	register(SHA3, handler{opSha3, 2, 30})
 
 	register(POP, handler{opPop, 1, 2})
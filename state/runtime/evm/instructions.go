@@ -22,6 +22,16 @@ var (
 	wordSize = big.NewInt(32)
 )
 
+const (
+	// eip-2929
+	coldAccountAccessCost uint64 = 2600
+	coldSloadCost         uint64 = 2100
+	warmStorageReadCost   uint64 = 100
+
+	// eip-3860
+	initCodeWordGas uint64 = 2
+)
+
 func opAdd(c *state) {
 	a := c.pop()
 	b := c.top()
@@ -459,13 +469,44 @@ func opMStore8(c *state) {
 	c.memory[offset.Uint64()] = byte(val.Uint64() & 0xff)
 }
 
+func opMCopy(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	dstOffset := c.pop()
+	srcOffset := c.pop()
+	length := c.pop()
+
+	// the memory is expanded to cover both source and destination
+	if !c.allocateMemory(srcOffset, length) || !c.allocateMemory(dstOffset, length) {
+		return
+	}
+
+	size := length.Uint64()
+	if !c.consumeGas(((size + 31) / 32) * copyGas) {
+		return
+	}
+
+	if size != 0 {
+		src, dst := srcOffset.Uint64(), dstOffset.Uint64()
+		copy(c.memory[dst:dst+size], c.memory[src:src+size])
+	}
+}
+
 // --- storage ---
 
 func opSload(c *state) {
 	loc := c.top()
+	slot := bigToHash(loc)
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.slotAccessGas(c.msg.Address, slot)
+	} else if c.config.Istanbul {
 		// eip-1884
 		gas = 800
 	} else if c.config.EIP150 {
@@ -478,7 +519,9 @@ func opSload(c *state) {
 		return
 	}
 
-	val := c.host.GetStorage(c.msg.Address, bigToHash(loc))
+	c.host.AddSlotToAccessList(c.msg.Address, slot)
+
+	val := c.host.GetStorage(c.msg.Address, slot)
 	loc.SetBytes(val.Bytes())
 }
 
@@ -500,12 +543,25 @@ func opSStore(c *state) {
 
 	legacyGasMetering := !c.config.Istanbul && (c.config.Petersburg || !c.config.Constantinople)
 
-	status := c.host.SetStorage(c.msg.Address, key, val, c.config)
 	cost := uint64(0)
 
+	// eip-2929
+	if c.config.Berlin {
+		if _, slotPresent := c.host.ContainsAccessListSlot(c.msg.Address, key); !slotPresent {
+			cost = coldSloadCost
+		}
+	}
+
+	c.host.AddSlotToAccessList(c.msg.Address, key)
+
+	status := c.host.SetStorage(c.msg.Address, key, val, c.config)
+
 	switch status {
 	case runtime.StorageUnchanged:
-		if c.config.Istanbul {
+		if c.config.Berlin {
+			// eip-2929
+			cost += warmStorageReadCost
+		} else if c.config.Istanbul {
 			// eip-2200
 			cost = 800
 		} else if legacyGasMetering {
@@ -515,10 +571,18 @@ func opSStore(c *state) {
 		}
 
 	case runtime.StorageModified:
-		cost = 5000
+		if c.config.Berlin {
+			// eip-2929
+			cost += 5000 - coldSloadCost
+		} else {
+			cost = 5000
+		}
 
 	case runtime.StorageModifiedAgain:
-		if c.config.Istanbul {
+		if c.config.Berlin {
+			// eip-2929
+			cost += warmStorageReadCost
+		} else if c.config.Istanbul {
 			// eip-2200
 			cost = 800
 		} else if legacyGasMetering {
@@ -528,10 +592,15 @@ func opSStore(c *state) {
 		}
 
 	case runtime.StorageAdded:
-		cost = 20000
+		cost += 20000
 
 	case runtime.StorageDeleted:
-		cost = 5000
+		if c.config.Berlin {
+			// eip-2929
+			cost += 5000 - coldSloadCost
+		} else {
+			cost = 5000
+		}
 	}
 
 	if !c.consumeGas(cost) {
@@ -539,6 +608,58 @@ func opSStore(c *state) {
 	}
 }
 
+func opTload(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	loc := c.top()
+
+	val := c.host.GetTransientState(c.msg.Address, bigToHash(loc))
+	loc.SetBytes(val.Bytes())
+}
+
+func opTstore(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	if c.inStaticCall() {
+		c.exit(errWriteProtection)
+
+		return
+	}
+
+	key := c.popHash()
+	val := c.popHash()
+
+	c.host.SetTransientState(c.msg.Address, key, val)
+}
+
+// addressAccessGas returns the cost of accessing the account,
+// depending on whether it has already been accessed in the transaction (eip-2929)
+func (c *state) addressAccessGas(addr types.Address) uint64 {
+	if c.host.ContainsAccessListAddress(addr) {
+		return warmStorageReadCost
+	}
+
+	return coldAccountAccessCost
+}
+
+// slotAccessGas returns the cost of accessing the storage slot,
+// depending on whether it has already been accessed in the transaction (eip-2929)
+func (c *state) slotAccessGas(addr types.Address, slot types.Hash) uint64 {
+	if _, slotPresent := c.host.ContainsAccessListSlot(addr, slot); slotPresent {
+		return warmStorageReadCost
+	}
+
+	return coldSloadCost
+}
+
 const sha3WordGas uint64 = 6
 
 func opSha3(c *state) {
@@ -575,7 +696,10 @@ func opBalance(c *state) {
 	addr, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessGas(addr)
+	} else if c.config.Istanbul {
 		// eip-1884
 		gas = 700
 	} else if c.config.EIP150 {
@@ -588,6 +712,8 @@ func opBalance(c *state) {
 		return
 	}
 
+	c.host.AddAddressToAccessList(addr)
+
 	c.push1().Set(c.host.GetBalance(addr))
 }
 
@@ -658,7 +784,10 @@ func opExtCodeSize(c *state) {
 	addr, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessGas(addr)
+	} else if c.config.EIP150 {
 		gas = 700
 	} else {
 		gas = 20
@@ -668,6 +797,8 @@ func opExtCodeSize(c *state) {
 		return
 	}
 
+	c.host.AddAddressToAccessList(addr)
+
 	c.push1().SetUint64(uint64(c.host.GetCodeSize(addr)))
 }
 
@@ -693,7 +824,10 @@ func opExtCodeHash(c *state) {
 	address, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessGas(address)
+	} else if c.config.Istanbul {
 		gas = 700
 	} else {
 		gas = 400
@@ -703,6 +837,8 @@ func opExtCodeHash(c *state) {
 		return
 	}
 
+	c.host.AddAddressToAccessList(address)
+
 	v := c.push1()
 	if c.host.Empty(address) {
 		v.Set(zero)
@@ -767,7 +903,10 @@ func opExtCodeCopy(c *state) {
 	}
 
 	var gas uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessGas(address)
+	} else if c.config.EIP150 {
 		gas = 700
 	} else {
 		gas = 20
@@ -777,6 +916,8 @@ func opExtCodeCopy(c *state) {
 		return
 	}
 
+	c.host.AddAddressToAccessList(address)
+
 	code := c.host.GetCode(address)
 	if size != 0 {
 		c.setBytes(c.memory[memOffset.Uint64():], code, size, codeOffset)
@@ -943,10 +1084,17 @@ func opSelfDestruct(c *state) {
 		}
 	}
 
+	// eip-2929
+	if c.config.Berlin && !c.host.ContainsAccessListAddress(address) {
+		gas += coldAccountAccessCost
+	}
+
 	if !c.consumeGas(gas) {
 		return
 	}
 
+	c.host.AddAddressToAccessList(address)
+
 	c.host.Selfdestruct(c.msg.Address, address)
 	c.Halt()
 }
@@ -975,6 +1123,16 @@ func opJumpi(c *state) {
 func opJumpDest(c *state) {
 }
 
+func opPush0(c *state) {
+	if !c.config.Shanghai {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	c.push1().Set(zero)
+}
+
 func opPush(n int) instruction {
 	return func(c *state) {
 		ins := c.code
@@ -1093,7 +1251,11 @@ func opCreate(op OpCode) instruction {
 			return
 		}
 
-		contract.Type = runtime.Create
+		if op == CREATE2 {
+			contract.Type = runtime.Create2
+		} else {
+			contract.Type = runtime.Create
+		}
 
 		// Correct call
 		result := c.host.Callx(contract, c.host)
@@ -1226,7 +1388,10 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 	}
 
 	var gasCost uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gasCost = c.addressAccessGas(addr)
+	} else if c.config.EIP150 {
 		gasCost = 700
 	} else {
 		gasCost = 40
@@ -1278,6 +1443,8 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 		return nil, 0, 0, nil
 	}
 
+	c.host.AddAddressToAccessList(addr)
+
 	if transfersValue {
 		gas += 2300
 	}
@@ -1327,6 +1494,13 @@ func (c *state) buildCreateContract(op OpCode) (*runtime.Contract, error) {
 		salt = c.pop()
 	}
 
+	// eip-3860
+	if c.config.Shanghai && (!length.IsUint64() || length.Uint64() > runtime.MaxInitCodeSize) {
+		c.exit(runtime.ErrMaxInitCodeSizeExceeded)
+
+		return nil, nil
+	}
+
 	// check if the value can be transferred
 	hasTransfer := value != nil && value.Sign() != 0
 
@@ -1364,6 +1538,14 @@ func (c *state) buildCreateContract(op OpCode) (*runtime.Contract, error) {
 		}
 	}
 
+	if c.config.Shanghai {
+		// Consume init code gas cost (eip-3860)
+		size := length.Uint64()
+		if !c.consumeGas(((size + 31) / 32) * initCodeWordGas) {
+			return nil, nil
+		}
+	}
+
 	// Calculate and consume gas for the call
 	gas := c.gas
 
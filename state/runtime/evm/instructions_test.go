@@ -120,6 +120,11 @@ func (m *mockHostForInstructions) GetCode(addr types.Address) []byte {
 	return m.code
 }
 
+func (m *mockHostForInstructions) ContainsAccessListAddress(addr types.Address) bool {
+	// all accounts are warm
+	return true
+}
+
 var (
 	addr1 = types.StringToAddress("1")
 )
@@ -728,3 +733,297 @@ func Test_opCall(t *testing.T) {
 		})
 	}
 }
+
+func TestPush0(t *testing.T) {
+	t.Parallel()
+
+	t.Run("shanghai fork enabled", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{Shanghai: true}
+
+		opPush0(s)
+
+		assert.NoError(t, s.err)
+		assert.Equal(t, 1, s.sp)
+		assert.Equal(t, zero, s.pop())
+	})
+
+	t.Run("shanghai fork disabled", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{}
+
+		opPush0(s)
+
+		assert.ErrorIs(t, s.err, errOpCodeNotFound)
+		assert.Equal(t, 0, s.sp)
+	})
+}
+
+func TestMCopy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("overlapping ranges", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{Cancun: true}
+		s.gas = 1000
+		s.memory = append(s.memory, make([]byte, 32)...)
+		copy(s.memory, []byte{0x1, 0x2, 0x3, 0x4})
+
+		s.push(big.NewInt(4)) // length
+		s.push(big.NewInt(0)) // source offset
+		s.push(big.NewInt(2)) // destination offset
+
+		opMCopy(s)
+
+		assert.NoError(t, s.err)
+		assert.Equal(t, []byte{0x1, 0x2, 0x1, 0x2, 0x3, 0x4}, s.memory[:6])
+	})
+
+	t.Run("memory expansion", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{Cancun: true}
+		s.gas = 1000
+
+		s.push(big.NewInt(32)) // length
+		s.push(big.NewInt(64)) // source offset
+		s.push(big.NewInt(0))  // destination offset
+
+		opMCopy(s)
+
+		assert.NoError(t, s.err)
+		assert.Len(t, s.memory, 96)
+	})
+
+	t.Run("cancun fork disabled", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{}
+
+		opMCopy(s)
+
+		assert.ErrorIs(t, s.err, errOpCodeNotFound)
+	})
+}
+
+type mockHostForTransientStorage struct {
+	mockHost
+	transientStorage *runtime.TransientStorage
+}
+
+func (m *mockHostForTransientStorage) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	return m.transientStorage.Get(addr, key)
+}
+
+func (m *mockHostForTransientStorage) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {
+	m.transientStorage.Set(addr, key, value)
+}
+
+func TestTransientStorage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key   = big.NewInt(1)
+		value = big.NewInt(10)
+	)
+
+	t.Run("store and load", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{Cancun: true}
+		s.msg = &runtime.Contract{Address: addr1}
+		s.host = &mockHostForTransientStorage{transientStorage: runtime.NewTransientStorage()}
+
+		s.push(value)
+		s.push(key)
+		opTstore(s)
+
+		assert.NoError(t, s.err)
+		assert.Equal(t, 0, s.sp)
+
+		s.push(key)
+		opTload(s)
+
+		assert.NoError(t, s.err)
+		assert.Equal(t, value, s.pop())
+	})
+
+	t.Run("store in static call", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{Cancun: true}
+		s.msg = &runtime.Contract{Address: addr1, Static: true}
+		s.host = &mockHostForTransientStorage{transientStorage: runtime.NewTransientStorage()}
+
+		s.push(value)
+		s.push(key)
+		opTstore(s)
+
+		assert.ErrorIs(t, s.err, errWriteProtection)
+	})
+
+	t.Run("cancun fork disabled", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{}
+
+		s.push(key)
+		opTload(s)
+
+		assert.ErrorIs(t, s.err, errOpCodeNotFound)
+	})
+}
+
+type mockHostForAccessList struct {
+	mockHost
+	accessList *runtime.AccessList
+}
+
+func (m *mockHostForAccessList) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func (m *mockHostForAccessList) GetBalance(addr types.Address) *big.Int {
+	return big.NewInt(0)
+}
+
+func (m *mockHostForAccessList) AddAddressToAccessList(addr types.Address) {
+	m.accessList.AddAddress(addr)
+}
+
+func (m *mockHostForAccessList) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	m.accessList.AddSlot(addr, slot)
+}
+
+func (m *mockHostForAccessList) ContainsAccessListAddress(addr types.Address) bool {
+	return m.accessList.ContainsAddress(addr)
+}
+
+func (m *mockHostForAccessList) ContainsAccessListSlot(addr types.Address, slot types.Hash) (bool, bool) {
+	return m.accessList.Contains(addr, slot)
+}
+
+func TestAccessListGas(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SLOAD", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{Berlin: true, Istanbul: true, EIP150: true}
+		s.msg = &runtime.Contract{Address: addr1}
+		s.host = &mockHostForAccessList{accessList: runtime.NewAccessList()}
+		s.gas = 10000
+
+		// cold slot
+		s.push(big.NewInt(1))
+		opSload(s)
+		s.pop()
+
+		assert.Equal(t, 10000-coldSloadCost, s.gas)
+
+		// warm slot
+		s.push(big.NewInt(1))
+		opSload(s)
+		s.pop()
+
+		assert.Equal(t, 10000-coldSloadCost-warmStorageReadCost, s.gas)
+	})
+
+	t.Run("BALANCE", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{Berlin: true, Istanbul: true, EIP150: true}
+		s.msg = &runtime.Contract{Address: addr1}
+		s.host = &mockHostForAccessList{accessList: runtime.NewAccessList()}
+		s.gas = 10000
+
+		// cold account
+		s.push(big.NewInt(2))
+		opBalance(s)
+		s.pop()
+
+		assert.Equal(t, 10000-coldAccountAccessCost, s.gas)
+
+		// warm account
+		s.push(big.NewInt(2))
+		opBalance(s)
+		s.pop()
+
+		assert.Equal(t, 10000-coldAccountAccessCost-warmStorageReadCost, s.gas)
+	})
+
+	t.Run("SLOAD before berlin", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{Istanbul: true, EIP150: true}
+		s.msg = &runtime.Contract{Address: addr1}
+		s.host = &mockHostForAccessList{accessList: runtime.NewAccessList()}
+		s.gas = 10000
+
+		s.push(big.NewInt(1))
+		opSload(s)
+		s.pop()
+
+		s.push(big.NewInt(1))
+		opSload(s)
+		s.pop()
+
+		assert.Equal(t, uint64(10000-2*800), s.gas)
+	})
+}
+
+func TestCreateInitCodeLimit(t *testing.T) {
+	t.Parallel()
+
+	s, closeFn := getState()
+	defer closeFn()
+
+	s.config = &chain.ForksInTime{Shanghai: true, Constantinople: true}
+	s.msg = &runtime.Contract{Address: addr1}
+	s.host = &mockHostForInstructions{}
+	s.gas = 1000000
+
+	s.push(big.NewInt(runtime.MaxInitCodeSize + 1)) // length
+	s.push(big.NewInt(0))                           // offset
+	s.push(big.NewInt(0))                           // value
+
+	opCreate(CREATE)(s)
+
+	assert.ErrorIs(t, s.err, runtime.ErrMaxInitCodeSizeExceeded)
+}
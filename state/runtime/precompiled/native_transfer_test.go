@@ -193,3 +193,21 @@ func (d dummyHost) GetTracer() runtime.VMTracer {
 func (d dummyHost) GetRefund() uint64 {
 	return 0
 }
+
+func (d dummyHost) AddAddressToAccessList(addr types.Address) {}
+
+func (d dummyHost) AddSlotToAccessList(addr types.Address, slot types.Hash) {}
+
+func (d dummyHost) ContainsAccessListAddress(addr types.Address) bool {
+	return false
+}
+
+func (d dummyHost) ContainsAccessListSlot(addr types.Address, slot types.Hash) (bool, bool) {
+	return false, false
+}
+
+func (d dummyHost) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func (d dummyHost) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {}
@@ -102,8 +102,26 @@ func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.
 		return false
 	}
 
+	return p.isActive(c.CodeAddress, config)
+}
+
+// Addrs returns the addresses of the precompiles enabled in the given forks
+func (p *Precompiled) Addrs(config *chain.ForksInTime) []types.Address {
+	addrs := make([]types.Address, 0, len(p.contracts))
+
+	for addr := range p.contracts {
+		if p.isActive(addr, config) {
+			addrs = append(addrs, addr)
+		}
+	}
+
+	return addrs
+}
+
+// isActive checks whether the precompile at the given address is enabled in the given forks
+func (p *Precompiled) isActive(addr types.Address, config *chain.ForksInTime) bool {
 	// byzantium precompiles
-	switch c.CodeAddress {
+	switch addr {
 	case five:
 		fallthrough
 	case six:
@@ -115,7 +133,7 @@ func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.
 	}
 
 	// istanbul precompiles
-	switch c.CodeAddress {
+	switch addr {
 	case nine:
 		return config.Istanbul
 	}
@@ -77,6 +77,12 @@ type Host interface {
 	Transfer(from types.Address, to types.Address, amount *big.Int) error
 	GetTracer() VMTracer
 	GetRefund() uint64
+	AddAddressToAccessList(addr types.Address)
+	AddSlotToAccessList(addr types.Address, slot types.Hash)
+	ContainsAccessListAddress(addr types.Address) bool
+	ContainsAccessListSlot(addr types.Address, slot types.Hash) (bool, bool)
+	GetTransientState(addr types.Address, key types.Hash) types.Hash
+	SetTransientState(addr types.Address, key types.Hash, value types.Hash)
 }
 
 type VMTracer interface {
@@ -110,17 +116,18 @@ type ExecutionResult struct {
 	GasUsed     uint64        // Total gas used as result of execution
 	Err         error         // Any error encountered during the execution, listed below
 	Address     types.Address // Contract address
+	AccessList  *AccessList   // Addresses and storage slots accessed during the transaction execution
 }
 
 func (r *ExecutionResult) Succeeded() bool { return r.Err == nil }
 func (r *ExecutionResult) Failed() bool    { return r.Err != nil }
 func (r *ExecutionResult) Reverted() bool  { return errors.Is(r.Err, ErrExecutionReverted) }
 
-func (r *ExecutionResult) UpdateGasUsed(gasLimit uint64, refund uint64) {
+func (r *ExecutionResult) UpdateGasUsed(gasLimit uint64, refund uint64, refundQuotient uint64) {
 	r.GasUsed = gasLimit - r.GasLeft
 
-	// Refund can go up to half the gas used
-	if maxRefund := r.GasUsed / 2; refund > maxRefund {
+	// Refund can go up to the given fraction of the gas used (half of it before EIP-3529)
+	if maxRefund := r.GasUsed / refundQuotient; refund > maxRefund {
 		refund = maxRefund
 	}
 
@@ -135,6 +142,7 @@ var (
 	ErrNotEnoughFunds           = errors.New("not enough funds")
 	ErrInsufficientBalance      = errors.New("insufficient balance for transfer")
 	ErrMaxCodeSizeExceeded      = errors.New("evm: max code size exceeded")
+	ErrMaxInitCodeSizeExceeded  = errors.New("evm: max initcode size exceeded")
 	ErrContractAddressCollision = errors.New("contract address collision")
 	ErrDepth                    = errors.New("max call depth exceeded")
 	ErrExecutionReverted        = errors.New("execution was reverted")
@@ -146,6 +154,9 @@ var (
 
 type CallType int
 
+// MaxInitCodeSize is the maximum size of the contract creation code (EIP-3860)
+const MaxInitCodeSize = 2 * 24576
+
 const (
 	Call CallType = iota
 	CallCode
@@ -210,6 +221,7 @@ func NewContractCreation(
 	code []byte,
 ) *Contract {
 	c := NewContract(depth, origin, from, to, value, gas, code)
+	c.Type = Create
 
 	return c
 }
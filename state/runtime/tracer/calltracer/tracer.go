@@ -0,0 +1,253 @@
+package calltracer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo/abi"
+)
+
+type Config struct {
+	OnlyTopCall bool // trace only the top-level call frame
+}
+
+// Call is a single frame of the call tree
+type Call struct {
+	Type         string  `json:"type"`
+	From         string  `json:"from"`
+	To           string  `json:"to,omitempty"`
+	Value        string  `json:"value,omitempty"`
+	Gas          string  `json:"gas"`
+	GasUsed      string  `json:"gasUsed"`
+	Input        string  `json:"input"`
+	Output       string  `json:"output,omitempty"`
+	Error        string  `json:"error,omitempty"`
+	RevertReason string  `json:"revertReason,omitempty"`
+	Calls        []*Call `json:"calls,omitempty"`
+
+	startGas uint64
+	gasUsed  uint64
+}
+
+// CallTracer builds a nested tree of the calls made during the transaction execution,
+// compatible with the output of the callTracer in geth
+type CallTracer struct {
+	Config Config
+
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	gasLimit  uint64
+	root      *Call
+	callStack []*Call
+}
+
+func NewCallTracer(config Config) *CallTracer {
+	return &CallTracer{
+		Config:     config,
+		cancelLock: sync.RWMutex{},
+	}
+}
+
+func (t *CallTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *CallTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *CallTracer) Clear() {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = nil
+	t.interrupt = false
+	t.gasLimit = 0
+	t.root = nil
+	t.callStack = t.callStack[:0]
+}
+
+func (t *CallTracer) TxStart(
+	gasLimit uint64,
+	from types.Address,
+	to *types.Address,
+	coinbase types.Address,
+	host tracer.RuntimeHost,
+) {
+	t.gasLimit = gasLimit
+}
+
+func (t *CallTracer) TxEnd(gasLeft uint64) {
+	if t.root == nil {
+		return
+	}
+
+	// top-level call reports the gas of the whole transaction, including intrinsic gas
+	t.root.startGas = t.gasLimit
+	t.root.gasUsed = t.gasLimit - gasLeft
+}
+
+func (t *CallTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	if t.Config.OnlyTopCall && depth > 1 {
+		return
+	}
+
+	call := &Call{
+		Type:     callTypeName(runtime.CallType(callType)),
+		From:     from.String(),
+		To:       to.String(),
+		Input:    hex.EncodeToHex(input),
+		startGas: gas,
+	}
+
+	// value is not applicable to delegate and static calls
+	if value != nil {
+		call.Value = hex.EncodeBig(value)
+	}
+
+	if len(t.callStack) == 0 {
+		t.root = call
+	} else {
+		parent := t.callStack[len(t.callStack)-1]
+		parent.Calls = append(parent.Calls, call)
+	}
+
+	t.callStack = append(t.callStack, call)
+}
+
+func (t *CallTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasUsed uint64,
+	err error,
+) {
+	if t.Config.OnlyTopCall && depth > 1 {
+		return
+	}
+
+	if len(t.callStack) == 0 {
+		return
+	}
+
+	call := t.callStack[len(t.callStack)-1]
+	t.callStack = t.callStack[:len(t.callStack)-1]
+
+	call.gasUsed = gasUsed
+
+	if err == nil {
+		call.Output = hex.EncodeToHex(output)
+
+		return
+	}
+
+	call.Error = err.Error()
+
+	if call.Type == "CREATE" || call.Type == "CREATE2" {
+		call.To = ""
+	}
+
+	if !errors.Is(err, runtime.ErrExecutionReverted) || len(output) == 0 {
+		return
+	}
+
+	call.Output = hex.EncodeToHex(output)
+
+	if reason, unpackErr := abi.UnpackRevertError(output); unpackErr == nil {
+		call.RevertReason = reason
+	}
+}
+
+func (t *CallTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+	}
+}
+
+func (t *CallTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+func (t *CallTracer) GetResult() (interface{}, error) {
+	t.cancelLock.RLock()
+	reason := t.reason
+	t.cancelLock.RUnlock()
+
+	if reason != nil {
+		return nil, reason
+	}
+
+	if t.root == nil {
+		return nil, errors.New("no call frames were captured")
+	}
+
+	finalizeGas(t.root)
+
+	return t.root, nil
+}
+
+// finalizeGas formats the gas fields of the given call and all of its sub-calls
+func finalizeGas(call *Call) {
+	call.Gas = hex.EncodeUint64(call.startGas)
+	call.GasUsed = hex.EncodeUint64(call.gasUsed)
+
+	for _, c := range call.Calls {
+		finalizeGas(c)
+	}
+}
+
+func callTypeName(callType runtime.CallType) string {
+	switch callType {
+	case runtime.Call:
+		return "CALL"
+	case runtime.CallCode:
+		return "CALLCODE"
+	case runtime.DelegateCall:
+		return "DELEGATECALL"
+	case runtime.StaticCall:
+		return "STATICCALL"
+	case runtime.Create:
+		return "CREATE"
+	case runtime.Create2:
+		return "CREATE2"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,172 @@
+package calltracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo/abi"
+)
+
+var (
+	testFrom  = types.StringToAddress("1")
+	testTo    = types.StringToAddress("2")
+	testInner = types.StringToAddress("3")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+func TestCallTracerNestedCalls(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{})
+
+	tracer.TxStart(100000, testFrom, &testTo, types.ZeroAddress, nil)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 79000, big.NewInt(10), []byte{0x1})
+	tracer.CallStart(2, testTo, testInner, int(runtime.StaticCall), 5000, nil, []byte{0x2})
+	tracer.CallEnd(2, []byte{0x3}, 1200, nil)
+	tracer.CallStart(2, testTo, testInner, int(runtime.Create2), 6000, big.NewInt(0), []byte{0x4})
+	tracer.CallEnd(2, nil, 6000, runtime.ErrOutOfGas)
+	tracer.CallEnd(1, []byte{0x5}, 50000, nil)
+	tracer.TxEnd(29000)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		&Call{
+			Type:     "CALL",
+			From:     testFrom.String(),
+			To:       testTo.String(),
+			Value:    "0xa",
+			Gas:      "0x186a0",
+			GasUsed:  "0x11558",
+			Input:    "0x01",
+			Output:   "0x05",
+			startGas: 100000,
+			gasUsed:  71000,
+			Calls: []*Call{
+				{
+					Type:     "STATICCALL",
+					From:     testTo.String(),
+					To:       testInner.String(),
+					Gas:      "0x1388",
+					GasUsed:  "0x4b0",
+					Input:    "0x02",
+					Output:   "0x03",
+					startGas: 5000,
+					gasUsed:  1200,
+				},
+				{
+					Type:     "CREATE2",
+					From:     testTo.String(),
+					Value:    "0x0",
+					Gas:      "0x1770",
+					GasUsed:  "0x1770",
+					Input:    "0x04",
+					Error:    runtime.ErrOutOfGas.Error(),
+					startGas: 6000,
+					gasUsed:  6000,
+				},
+			},
+		},
+		res,
+	)
+}
+
+func TestCallTracerOnlyTopCall(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{OnlyTopCall: true})
+
+	tracer.TxStart(100000, testFrom, &testTo, types.ZeroAddress, nil)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 79000, big.NewInt(0), nil)
+	tracer.CallStart(2, testTo, testInner, int(runtime.Call), 5000, big.NewInt(0), nil)
+	tracer.CallEnd(2, nil, 1200, nil)
+	tracer.CallEnd(1, nil, 50000, nil)
+	tracer.TxEnd(29000)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	call, ok := res.(*Call)
+	require.True(t, ok)
+
+	assert.Empty(t, call.Calls)
+	assert.Equal(t, "0x11558", call.GasUsed)
+}
+
+func TestCallTracerRevertReason(t *testing.T) {
+	t.Parallel()
+
+	revertOutput, err := abi.Encode([]interface{}{"insufficient funds"}, abi.MustNewType("tuple(string)"))
+	require.NoError(t, err)
+
+	// prepend Error(string) selector
+	revertOutput = append([]byte{0x08, 0xc3, 0x79, 0xa0}, revertOutput...)
+
+	tracer := NewCallTracer(Config{})
+
+	tracer.TxStart(30000, testFrom, &testTo, types.ZeroAddress, nil)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 9000, big.NewInt(0), nil)
+	tracer.CallEnd(1, revertOutput, 100, runtime.ErrExecutionReverted)
+	tracer.TxEnd(29000)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	call, ok := res.(*Call)
+	require.True(t, ok)
+
+	assert.Equal(t, runtime.ErrExecutionReverted.Error(), call.Error)
+	assert.Equal(t, "insufficient funds", call.RevertReason)
+	assert.NotEmpty(t, call.Output)
+}
+
+func TestCallTracerCancel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tracer = NewCallTracer(Config{})
+		state  = &mockState{}
+		err    = errors.New("timeout")
+	)
+
+	tracer.Cancel(err)
+	tracer.CaptureState(nil, nil, 0, testTo, 0, nil, state)
+
+	assert.True(t, state.halted)
+
+	res, resErr := tracer.GetResult()
+	assert.Nil(t, res)
+	assert.Equal(t, err, resErr)
+}
+
+func TestCallTracerClear(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{})
+
+	tracer.TxStart(30000, testFrom, &testTo, types.ZeroAddress, nil)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 9000, big.NewInt(0), nil)
+	tracer.Cancel(errors.New("timeout"))
+
+	tracer.Clear()
+
+	assert.Nil(t, tracer.root)
+	assert.Empty(t, tracer.callStack)
+	assert.Zero(t, tracer.gasLimit)
+	assert.NoError(t, tracer.reason)
+	assert.False(t, tracer.interrupt)
+}
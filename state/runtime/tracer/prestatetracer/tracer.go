@@ -0,0 +1,365 @@
+package prestatetracer
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type Config struct {
+	DiffMode bool // emit the state before and after the transaction
+}
+
+// Account is the state of a single account touched by the transaction
+type Account struct {
+	Balance *big.Int
+	Nonce   uint64
+	Code    []byte
+	Storage map[types.Hash]types.Hash
+}
+
+// AccountRes is the JSON representation of the Account
+type AccountRes struct {
+	Balance string                    `json:"balance,omitempty"`
+	Nonce   uint64                    `json:"nonce,omitempty"`
+	Code    string                    `json:"code,omitempty"`
+	Storage map[types.Hash]types.Hash `json:"storage,omitempty"`
+}
+
+// DiffResult is the result of the tracer in diff mode
+type DiffResult struct {
+	Pre  map[types.Address]*AccountRes `json:"pre"`
+	Post map[types.Address]*AccountRes `json:"post"`
+}
+
+// PrestateTracer records every account and storage slot touched by the transaction
+// together with their values before (and optionally after) the execution
+type PrestateTracer struct {
+	Config Config
+
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	host    tracer.RuntimeHost
+	pre     map[types.Address]*Account
+	post    map[types.Address]*Account
+	created map[types.Address]struct{}
+}
+
+func NewPrestateTracer(config Config) *PrestateTracer {
+	return &PrestateTracer{
+		Config:     config,
+		cancelLock: sync.RWMutex{},
+		pre:        make(map[types.Address]*Account),
+		post:       make(map[types.Address]*Account),
+		created:    make(map[types.Address]struct{}),
+	}
+}
+
+func (t *PrestateTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *PrestateTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *PrestateTracer) Clear() {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = nil
+	t.interrupt = false
+	t.host = nil
+	t.pre = make(map[types.Address]*Account)
+	t.post = make(map[types.Address]*Account)
+	t.created = make(map[types.Address]struct{})
+}
+
+func (t *PrestateTracer) TxStart(
+	gasLimit uint64,
+	from types.Address,
+	to *types.Address,
+	coinbase types.Address,
+	host tracer.RuntimeHost,
+) {
+	t.host = host
+
+	t.lookupAccount(from)
+	t.lookupAccount(coinbase)
+
+	if to != nil {
+		t.lookupAccount(*to)
+
+		return
+	}
+
+	created := crypto.CreateAddress(from, host.GetNonce(from))
+
+	t.lookupAccount(created)
+	t.created[created] = struct{}{}
+}
+
+func (t *PrestateTracer) TxEnd(gasLeft uint64) {
+	if !t.Config.DiffMode || t.host == nil {
+		return
+	}
+
+	t.processDiffState()
+}
+
+func (t *PrestateTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+}
+
+func (t *PrestateTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasUsed uint64,
+	err error,
+) {
+}
+
+func (t *PrestateTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	switch opCode {
+	case evm.SLOAD, evm.SSTORE:
+		if sp < 1 {
+			return
+		}
+
+		t.lookupStorage(contractAddress, types.BytesToHash(stack[sp-1].Bytes()))
+
+	case evm.EXTCODECOPY, evm.EXTCODEHASH, evm.EXTCODESIZE, evm.BALANCE, evm.SELFDESTRUCT:
+		if sp < 1 {
+			return
+		}
+
+		t.lookupAccount(types.BytesToAddress(stack[sp-1].Bytes()))
+
+	case evm.CALL, evm.CALLCODE, evm.DELEGATECALL, evm.STATICCALL:
+		if sp < 2 {
+			return
+		}
+
+		t.lookupAccount(types.BytesToAddress(stack[sp-2].Bytes()))
+
+	case evm.CREATE:
+		created := crypto.CreateAddress(contractAddress, host.GetNonce(contractAddress))
+
+		t.lookupAccount(created)
+		t.created[created] = struct{}{}
+
+	case evm.CREATE2:
+		if sp < 4 {
+			return
+		}
+
+		var (
+			offset = stack[sp-2]
+			size   = stack[sp-3]
+			salt   = types.BytesToHash(stack[sp-4].Bytes())
+		)
+
+		// memory has not been expanded yet if the init code is out of its bounds
+		if !offset.IsUint64() || !size.IsUint64() ||
+			offset.Uint64()+size.Uint64() > uint64(len(memory)) {
+			return
+		}
+
+		initCode := memory[offset.Uint64() : offset.Uint64()+size.Uint64()]
+		created := crypto.CreateAddress2(contractAddress, salt, initCode)
+
+		t.lookupAccount(created)
+		t.created[created] = struct{}{}
+	}
+}
+
+func (t *PrestateTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+// lookupAccount records the current state of the account if it hasn't been recorded yet
+func (t *PrestateTracer) lookupAccount(addr types.Address) {
+	if _, ok := t.pre[addr]; ok {
+		return
+	}
+
+	t.pre[addr] = &Account{
+		Balance: new(big.Int).Set(t.host.GetBalance(addr)),
+		Nonce:   t.host.GetNonce(addr),
+		Code:    t.host.GetCode(addr),
+		Storage: make(map[types.Hash]types.Hash),
+	}
+}
+
+// lookupStorage records the current value of the storage slot if it hasn't been recorded yet
+func (t *PrestateTracer) lookupStorage(addr types.Address, slot types.Hash) {
+	t.lookupAccount(addr)
+
+	if _, ok := t.pre[addr].Storage[slot]; ok {
+		return
+	}
+
+	t.pre[addr].Storage[slot] = t.host.GetStorage(addr, slot)
+}
+
+// processDiffState compares the recorded state with the state after the transaction,
+// leaving only the modified accounts and storage slots in pre and post states
+func (t *PrestateTracer) processDiffState() {
+	for addr, preAccount := range t.pre {
+		var (
+			modified    = false
+			postAccount = &Account{Storage: make(map[types.Hash]types.Hash)}
+			newBalance  = t.host.GetBalance(addr)
+			newNonce    = t.host.GetNonce(addr)
+			newCode     = t.host.GetCode(addr)
+		)
+
+		if newBalance.Cmp(preAccount.Balance) != 0 {
+			modified = true
+			postAccount.Balance = new(big.Int).Set(newBalance)
+		}
+
+		if newNonce != preAccount.Nonce {
+			modified = true
+			postAccount.Nonce = newNonce
+		}
+
+		if !bytes.Equal(newCode, preAccount.Code) {
+			modified = true
+			postAccount.Code = newCode
+		}
+
+		for slot, value := range preAccount.Storage {
+			newValue := t.host.GetStorage(addr, slot)
+
+			if value == newValue {
+				// omit unchanged slots
+				delete(preAccount.Storage, slot)
+
+				continue
+			}
+
+			modified = true
+
+			if value == types.ZeroHash {
+				// omit empty slots from the pre state
+				delete(preAccount.Storage, slot)
+			}
+
+			if newValue != types.ZeroHash {
+				postAccount.Storage[slot] = newValue
+			}
+		}
+
+		if modified {
+			t.post[addr] = postAccount
+		} else {
+			// unmodified accounts are not part of the diff
+			delete(t.pre, addr)
+		}
+	}
+
+	// created contracts didn't exist before the transaction
+	for addr := range t.created {
+		if preAccount, ok := t.pre[addr]; ok && preAccount.empty() {
+			delete(t.pre, addr)
+		}
+	}
+}
+
+func (a *Account) empty() bool {
+	return a.Nonce == 0 && len(a.Code) == 0 && len(a.Storage) == 0 && a.Balance.Sign() == 0
+}
+
+func (t *PrestateTracer) GetResult() (interface{}, error) {
+	t.cancelLock.RLock()
+	reason := t.reason
+	t.cancelLock.RUnlock()
+
+	if reason != nil {
+		return nil, reason
+	}
+
+	if t.Config.DiffMode {
+		return &DiffResult{
+			Pre:  formatAccounts(t.pre),
+			Post: formatAccounts(t.post),
+		}, nil
+	}
+
+	return formatAccounts(t.pre), nil
+}
+
+func formatAccounts(accounts map[types.Address]*Account) map[types.Address]*AccountRes {
+	res := make(map[types.Address]*AccountRes, len(accounts))
+
+	for addr, account := range accounts {
+		accountRes := &AccountRes{
+			Nonce: account.Nonce,
+		}
+
+		if account.Balance != nil {
+			accountRes.Balance = hex.EncodeBig(account.Balance)
+		}
+
+		if len(account.Code) > 0 {
+			accountRes.Code = hex.EncodeToHex(account.Code)
+		}
+
+		if len(account.Storage) > 0 {
+			accountRes.Storage = make(map[types.Hash]types.Hash, len(account.Storage))
+
+			for slot, value := range account.Storage {
+				accountRes.Storage[slot] = value
+			}
+		}
+
+		res[addr] = accountRes
+	}
+
+	return res
+}
@@ -0,0 +1,302 @@
+package prestatetracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testFrom     = types.StringToAddress("1")
+	testTo       = types.StringToAddress("2")
+	testCoinbase = types.StringToAddress("3")
+	testOther    = types.StringToAddress("4")
+
+	testSlot1 = types.StringToHash("1")
+	testSlot2 = types.StringToHash("2")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+type mockAccount struct {
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[types.Hash]types.Hash
+}
+
+type mockHost struct {
+	accounts map[types.Address]*mockAccount
+}
+
+func newMockHost() *mockHost {
+	return &mockHost{
+		accounts: map[types.Address]*mockAccount{
+			testFrom: {
+				balance: big.NewInt(1000),
+				nonce:   5,
+			},
+			testTo: {
+				balance: big.NewInt(10),
+				code:    []byte{0x1, 0x2},
+				storage: map[types.Hash]types.Hash{
+					testSlot1: types.StringToHash("10"),
+				},
+			},
+			testOther: {
+				balance: big.NewInt(7),
+			},
+		},
+	}
+}
+
+func (m *mockHost) account(addr types.Address) *mockAccount {
+	acc, ok := m.accounts[addr]
+	if !ok {
+		acc = &mockAccount{balance: big.NewInt(0)}
+		m.accounts[addr] = acc
+	}
+
+	if acc.storage == nil {
+		acc.storage = make(map[types.Hash]types.Hash)
+	}
+
+	return acc
+}
+
+func (m *mockHost) GetRefund() uint64 {
+	return 0
+}
+
+func (m *mockHost) GetStorage(addr types.Address, slot types.Hash) types.Hash {
+	return m.account(addr).storage[slot]
+}
+
+func (m *mockHost) GetBalance(addr types.Address) *big.Int {
+	return m.account(addr).balance
+}
+
+func (m *mockHost) GetNonce(addr types.Address) uint64 {
+	return m.account(addr).nonce
+}
+
+func (m *mockHost) GetCode(addr types.Address) []byte {
+	return m.account(addr).code
+}
+
+func TestPrestateTracerPrestate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		host   = newMockHost()
+		tracer = NewPrestateTracer(Config{})
+	)
+
+	tracer.TxStart(21000, testFrom, &testTo, testCoinbase, host)
+
+	// SLOAD of slot 1 and CALL to the other account
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(testSlot1.Bytes())}, evm.SLOAD, testTo, 1, host, &mockState{})
+	tracer.CaptureState(
+		nil,
+		[]*big.Int{big.NewInt(0), new(big.Int).SetBytes(testOther.Bytes()), big.NewInt(100)},
+		evm.CALL,
+		testTo,
+		3,
+		host,
+		&mockState{},
+	)
+
+	// modify state during the transaction
+	host.account(testFrom).balance = big.NewInt(500)
+	host.account(testTo).storage[testSlot1] = types.StringToHash("20")
+
+	// slot has already been recorded
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(testSlot1.Bytes())}, evm.SLOAD, testTo, 1, host, &mockState{})
+
+	tracer.TxEnd(0)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		map[types.Address]*AccountRes{
+			testFrom: {
+				Balance: "0x3e8",
+				Nonce:   5,
+			},
+			testTo: {
+				Balance: "0xa",
+				Code:    "0x0102",
+				Storage: map[types.Hash]types.Hash{
+					testSlot1: types.StringToHash("10"),
+				},
+			},
+			testCoinbase: {
+				Balance: "0x0",
+			},
+			testOther: {
+				Balance: "0x7",
+			},
+		},
+		res,
+	)
+}
+
+func TestPrestateTracerDiffMode(t *testing.T) {
+	t.Parallel()
+
+	var (
+		host   = newMockHost()
+		tracer = NewPrestateTracer(Config{DiffMode: true})
+	)
+
+	tracer.TxStart(21000, testFrom, &testTo, testCoinbase, host)
+
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(testSlot1.Bytes())}, evm.SLOAD, testTo, 1, host, &mockState{})
+	tracer.CaptureState(
+		nil,
+		[]*big.Int{big.NewInt(1), new(big.Int).SetBytes(testSlot2.Bytes())},
+		evm.SSTORE,
+		testTo,
+		2,
+		host,
+		&mockState{},
+	)
+
+	// apply the changes of the transaction
+	host.account(testFrom).balance = big.NewInt(500)
+	host.account(testFrom).nonce = 6
+	host.account(testCoinbase).balance = big.NewInt(500)
+	host.account(testTo).storage[testSlot2] = types.StringToHash("1")
+
+	tracer.TxEnd(0)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		&DiffResult{
+			Pre: map[types.Address]*AccountRes{
+				testFrom: {
+					Balance: "0x3e8",
+					Nonce:   5,
+				},
+				testCoinbase: {
+					Balance: "0x0",
+				},
+				testTo: {
+					Balance: "0xa",
+					Code:    "0x0102",
+				},
+			},
+			Post: map[types.Address]*AccountRes{
+				testFrom: {
+					Balance: "0x1f4",
+					Nonce:   6,
+				},
+				testCoinbase: {
+					Balance: "0x1f4",
+				},
+				testTo: {
+					Storage: map[types.Hash]types.Hash{
+						testSlot2: types.StringToHash("1"),
+					},
+				},
+			},
+		},
+		res,
+	)
+}
+
+func TestPrestateTracerContractCreation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		host    = newMockHost()
+		tracer  = NewPrestateTracer(Config{DiffMode: true})
+		created = crypto.CreateAddress(testFrom, 5)
+	)
+
+	tracer.TxStart(100000, testFrom, nil, testCoinbase, host)
+
+	host.account(testFrom).nonce = 6
+	host.account(created).nonce = 1
+	host.account(created).code = []byte{0x3}
+
+	tracer.TxEnd(0)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	diff, ok := res.(*DiffResult)
+	require.True(t, ok)
+
+	assert.NotContains(t, diff.Pre, created)
+	assert.Equal(
+		t,
+		&AccountRes{
+			Nonce: 1,
+			Code:  "0x03",
+		},
+		diff.Post[created],
+	)
+}
+
+func TestPrestateTracerCancel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tracer = NewPrestateTracer(Config{})
+		state  = &mockState{}
+		err    = errors.New("timeout")
+	)
+
+	tracer.Cancel(err)
+	tracer.CaptureState(nil, nil, evm.SLOAD, testTo, 0, newMockHost(), state)
+
+	assert.True(t, state.halted)
+
+	res, resErr := tracer.GetResult()
+	assert.Nil(t, res)
+	assert.Equal(t, err, resErr)
+}
+
+func TestPrestateTracerCancel_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewPrestateTracer(Config{})
+	done := make(chan struct{})
+
+	// the tracer is cancelled by the timeout while the result is read
+	go func() {
+		defer close(done)
+
+		tracer.Cancel(errors.New("timeout"))
+	}()
+
+	_, _ = tracer.GetResult()
+
+	<-done
+
+	_, err := tracer.GetResult()
+	assert.EqualError(t, err, "timeout")
+
+	tracer.Clear()
+
+	_, err = tracer.GetResult()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,43 @@
+package runtime
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// TransientStorage is the storage which is discarded at the end of the transaction,
+// as defined in EIP-1153
+type TransientStorage map[types.Address]map[types.Hash]types.Hash
+
+// NewTransientStorage creates a new empty transient storage
+func NewTransientStorage() *TransientStorage {
+	ts := make(TransientStorage)
+
+	return &ts
+}
+
+// Get returns the value of the given slot of the contract
+func (ts *TransientStorage) Get(address types.Address, key types.Hash) types.Hash {
+	slots, ok := (*ts)[address]
+	if !ok {
+		return types.ZeroHash
+	}
+
+	return slots[key]
+}
+
+// Set sets the value of the given slot of the contract
+func (ts *TransientStorage) Set(address types.Address, key types.Hash, value types.Hash) {
+	slots, ok := (*ts)[address]
+	if !ok {
+		slots = make(map[types.Hash]types.Hash)
+		(*ts)[address] = slots
+	}
+
+	if value == types.ZeroHash {
+		delete(slots, key)
+
+		return
+	}
+
+	slots[key] = value
+}
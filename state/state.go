@@ -15,6 +15,7 @@ type State interface {
 	NewSnapshotAt(types.Hash) (Snapshot, error)
 	NewSnapshot() Snapshot
 	GetCode(hash types.Hash) ([]byte, bool)
+	GetProof(root types.Hash, key []byte) ([][]byte, error)
 }
 
 type Snapshot interface {
@@ -231,11 +231,17 @@ func (txn *Txn) SetStorage(
 
 	legacyGasMetering := !config.Istanbul && (config.Petersburg || !config.Constantinople)
 
+	// refund for clearing a slot, reduced in EIP-3529
+	clearingRefund := uint64(15000)
+	if config.EIP3529 {
+		clearingRefund = 4800
+	}
+
 	if legacyGasMetering {
 		if oldValue == types.ZeroHash {
 			return runtime.StorageAdded
 		} else if value == types.ZeroHash {
-			txn.AddRefund(15000)
+			txn.AddRefund(clearingRefund)
 
 			return runtime.StorageDeleted
 		}
@@ -249,7 +255,7 @@ func (txn *Txn) SetStorage(
 		}
 
 		if value == types.ZeroHash { // delete slot (2.1.2b)
-			txn.AddRefund(15000)
+			txn.AddRefund(clearingRefund)
 
 			return runtime.StorageDeleted
 		}
@@ -259,22 +265,28 @@ func (txn *Txn) SetStorage(
 
 	if original != types.ZeroHash { // Storage slot was populated before this transaction started
 		if current == types.ZeroHash { // recreate slot (2.2.1.1)
-			txn.SubRefund(15000)
+			txn.SubRefund(clearingRefund)
 		} else if value == types.ZeroHash { // delete slot (2.2.1.2)
-			txn.AddRefund(15000)
+			txn.AddRefund(clearingRefund)
 		}
 	}
 
 	if original == value {
 		if original == types.ZeroHash { // reset to original nonexistent slot (2.2.2.1)
 			// Storage was used as memory (allocation and deallocation occurred within the same contract)
-			if config.Istanbul {
+			if config.Berlin {
+				// eip-2929
+				txn.AddRefund(19900)
+			} else if config.Istanbul {
 				txn.AddRefund(19200)
 			} else {
 				txn.AddRefund(19800)
 			}
 		} else { // reset to original existing slot (2.2.2.2)
-			if config.Istanbul {
+			if config.Berlin {
+				// eip-2929
+				txn.AddRefund(2800)
+			} else if config.Istanbul {
 				txn.AddRefund(4200)
 			} else {
 				txn.AddRefund(4800)
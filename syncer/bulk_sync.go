@@ -0,0 +1,619 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// defaultBlockRangeSize is the number of blocks requested from a peer at once
+	defaultBlockRangeSize = 64
+
+	// defaultMaxSyncPeers is the maximum number of peers the blocks are downloaded from in parallel
+	defaultMaxSyncPeers = 8
+
+	// maxRangesAhead is the number of ranges beyond the next block to write that may be downloaded,
+	// it bounds the memory taken by the blocks awaiting to be written
+	maxRangesAhead = 2 * defaultMaxSyncPeers
+
+	// defaultHeaderRangeSize is the number of headers requested from a peer at once
+	defaultHeaderRangeSize = 256
+
+	// defaultHeaderBatchSize is the number of headers verified before their bodies are downloaded,
+	// it bounds the memory taken by the headers awaiting their bodies
+	defaultHeaderBatchSize = 2048
+)
+
+var (
+	errNoSyncPeers      = errors.New("no peers left to download the blocks from")
+	errUnexpectedBlock  = errors.New("peer sent an unexpected block")
+	errIncompleteRange  = errors.New("peer closed the stream before sending the whole range")
+	errBulkSyncFinished = errors.New("bulk sync finished")
+	errInvalidBlock     = errors.New("unable to verify block")
+	errInvalidHeaders   = errors.New("unable to verify headers")
+	errInvalidBody      = errors.New("block body doesn't match the header")
+)
+
+// blockRange is an inclusive range of block heights
+type blockRange struct {
+	from uint64
+	to   uint64
+}
+
+// splitBlockRange splits the heights from the given range into ranges of at most size blocks
+func splitBlockRange(from, to, size uint64) []blockRange {
+	ranges := make([]blockRange, 0, (to-from)/size+1)
+
+	for start := from; start <= to; start += size {
+		end := start + size - 1
+		if end > to {
+			end = to
+		}
+
+		ranges = append(ranges, blockRange{from: start, to: end})
+	}
+
+	return ranges
+}
+
+// rangeResult is the outcome of downloading a block range from a peer.
+// The blocks may cover only the beginning of the range if the download failed halfway
+type rangeResult struct {
+	blockRange
+	peer   *NoForkPeer
+	blocks []*types.Block
+	err    error
+}
+
+// rangeDownloader downloads the blocks of the range from the peer.
+// Returns the blocks downloaded so far along with the error if the download fails halfway
+type rangeDownloader func(peerID peer.ID, rng blockRange, doneCh <-chan struct{}) ([]*types.Block, error)
+
+// rangeScheduler keeps track of the block ranges awaiting download
+// and of the peers able to download them
+type rangeScheduler struct {
+	pending []blockRange  // ranges awaiting download, sorted by height
+	idle    []*NoForkPeer // peers not downloading at the moment, best first
+	ahead   uint64        // the number of blocks beyond the next block to write that may be downloaded
+}
+
+// newRangeScheduler returns a scheduler of the given block range, split into ranges of rangeSize blocks
+func newRangeScheduler(from, to uint64, rangeSize uint64, peers []*NoForkPeer) *rangeScheduler {
+	idle := make([]*NoForkPeer, len(peers))
+	copy(idle, peers)
+
+	return &rangeScheduler{
+		pending: splitBlockRange(from, to, rangeSize),
+		idle:    idle,
+		ahead:   maxRangesAhead * rangeSize,
+	}
+}
+
+// next returns the lowest pending range that one of the idle peers can serve, along with the peer,
+// and marks the peer busy. Returns false if no range can be assigned at the moment
+func (r *rangeScheduler) next(nextToWrite uint64) (blockRange, *NoForkPeer, bool) {
+	for i, rng := range r.pending {
+		if rng.from >= nextToWrite+r.ahead {
+			break
+		}
+
+		for j, p := range r.idle {
+			if p.Number < rng.to {
+				continue
+			}
+
+			r.pending = append(r.pending[:i], r.pending[i+1:]...)
+			r.idle = append(r.idle[:j], r.idle[j+1:]...)
+
+			return rng, p, true
+		}
+	}
+
+	return blockRange{}, nil, false
+}
+
+// retry puts back the range that failed to download or to verify
+func (r *rangeScheduler) retry(rng blockRange) {
+	idx := sort.Search(len(r.pending), func(i int) bool {
+		return r.pending[i].from > rng.from
+	})
+
+	r.pending = append(r.pending, blockRange{})
+	copy(r.pending[idx+1:], r.pending[idx:])
+	r.pending[idx] = rng
+}
+
+// release marks the peer idle again
+func (r *rangeScheduler) release(p *NoForkPeer) {
+	idx := sort.Search(len(r.idle), func(i int) bool {
+		return p.IsBetter(r.idle[i])
+	})
+
+	r.idle = append(r.idle, nil)
+	copy(r.idle[idx+1:], r.idle[idx:])
+	r.idle[idx] = p
+}
+
+// drop removes the idle peer from the scheduler
+func (r *rangeScheduler) drop(peerID peer.ID) {
+	for i, p := range r.idle {
+		if p.ID == peerID {
+			r.idle = append(r.idle[:i], r.idle[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// bulkSync downloads the blocks up to the latest block of the best peer and writes them in order.
+// The headers are downloaded and verified first, batch by batch, and the bodies of the verified headers
+// are downloaded afterwards. Falls back to downloading whole blocks if the headers can't be downloaded,
+// e.g. the peers don't serve them. Returns true if the callback requested to terminate the sync
+func (s *syncer) bulkSync(peers []*NoForkPeer, newBlockCallback func(*types.FullBlock) bool) (bool, error) {
+	target := peers[0].Number
+
+	for {
+		from := s.blockchain.Header().Number + 1
+		if from > target {
+			return false, nil
+		}
+
+		to := target
+		if to-from >= s.headerBatchSize {
+			to = from + s.headerBatchSize - 1
+		}
+
+		headers, err := s.syncHeaders(peers, from, to)
+		if err != nil {
+			s.logger.Warn("failed to sync headers, downloading whole blocks", "from", from, "to", to, "err", err)
+
+			return s.syncRanges(peers, target, s.downloadRange, newBlockCallback)
+		}
+
+		shouldTerminate, err := s.syncRanges(peers, to, s.bodiesDownloader(headers), newBlockCallback)
+		if shouldTerminate || err != nil {
+			return shouldTerminate, err
+		}
+	}
+}
+
+// syncRanges downloads the blocks following the local latest block up to the target,
+// fetching disjoint block ranges from the given peers in parallel,
+// and writes them in order. The ranges failing to download or to verify are retried with other peers.
+// Returns true if the callback requested to terminate the sync
+func (s *syncer) syncRanges(
+	peers []*NoForkPeer,
+	target uint64,
+	download rangeDownloader,
+	newBlockCallback func(*types.FullBlock) bool,
+) (bool, error) {
+	var (
+		nextToWrite = s.blockchain.Header().Number + 1
+		scheduler   = newRangeScheduler(nextToWrite, target, s.blockRangeSize, peers)
+
+		// completed downloads by their beginning height
+		downloaded = make(map[uint64]*rangeResult)
+
+		// every peer downloads a single range at a time, so the downloads never block on sending the result
+		resultCh = make(chan *rangeResult, len(peers))
+		doneCh   = make(chan struct{})
+		inFlight = 0
+	)
+
+	// abort the downloads still in progress
+	defer close(doneCh)
+
+	for nextToWrite <= target {
+		for {
+			rng, p, ok := scheduler.next(nextToWrite)
+			if !ok {
+				break
+			}
+
+			inFlight++
+
+			go func() {
+				blocks, err := download(p.ID, rng, doneCh)
+
+				resultCh <- &rangeResult{blockRange: rng, peer: p, blocks: blocks, err: err}
+			}()
+		}
+
+		if inFlight == 0 {
+			return false, errNoSyncPeers
+		}
+
+		res := <-resultCh
+		inFlight--
+
+		if len(res.blocks) > 0 {
+			downloaded[res.from] = res
+		}
+
+		if errors.Is(res.err, errInvalidBody) {
+			s.network.ReportPeer(res.peer.ID, network.PenaltyInvalidBlock, "invalid block body")
+		}
+
+		if res.err != nil {
+			s.logger.Warn(
+				"failed to download blocks from peer, retrying with other peers",
+				"peer", res.peer.ID,
+				"from", res.from,
+				"to", res.to,
+				"err", res.err,
+			)
+
+			// the peer is not used again in this round
+			scheduler.retry(blockRange{from: res.from + uint64(len(res.blocks)), to: res.to})
+		} else {
+			scheduler.release(res.peer)
+		}
+
+		// write the downloaded blocks following the local latest block
+		for {
+			res, ok := downloaded[nextToWrite]
+			if !ok {
+				break
+			}
+
+			delete(downloaded, nextToWrite)
+
+			written, shouldTerminate, err := s.writeBlocks(res.blocks, newBlockCallback)
+			nextToWrite += uint64(written)
+
+			if shouldTerminate {
+				return true, nil
+			}
+
+			if errors.Is(err, errInvalidBlock) {
+				s.logger.Warn("peer sent an invalid block, retrying with other peers", "peer", res.peer.ID, "err", err)
+				s.network.ReportPeer(res.peer.ID, network.PenaltyInvalidBlock, "invalid block")
+
+				scheduler.drop(res.peer.ID)
+				scheduler.retry(blockRange{from: nextToWrite, to: res.from + uint64(len(res.blocks)) - 1})
+
+				break
+			}
+
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// writeBlocks verifies and writes the consecutive blocks to the chain.
+// Returns the number of written blocks, and whether the callback requested to terminate the sync
+func (s *syncer) writeBlocks(
+	blocks []*types.Block,
+	newBlockCallback func(*types.FullBlock) bool,
+) (int, bool, error) {
+	for i, block := range blocks {
+		fullBlock, err := s.blockchain.VerifyFinalizedBlock(block)
+		if err != nil {
+			return i, false, fmt.Errorf("%w, %v", errInvalidBlock, err)
+		}
+
+		if err := s.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
+			return i, false, fmt.Errorf("failed to write block while bulk syncing: %w", err)
+		}
+
+		if newBlockCallback(fullBlock) {
+			return i + 1, true, nil
+		}
+	}
+
+	return len(blocks), false, nil
+}
+
+// downloadRange downloads the block range from the peer.
+// Returns the blocks downloaded so far along with the error if the download fails halfway
+func (s *syncer) downloadRange(peerID peer.ID, rng blockRange, doneCh <-chan struct{}) ([]*types.Block, error) {
+	blockCh, err := s.syncPeerClient.GetBlocks(peerID, rng.from, rng.to, s.blockTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+			s.logger.Error("failed to close stream", "peer", peerID, "err", err)
+		}
+	}()
+
+	blocks := make([]*types.Block, 0, rng.to-rng.from+1)
+
+	for {
+		select {
+		case block, ok := <-blockCh:
+			if !ok {
+				if len(blocks) < int(rng.to-rng.from+1) {
+					return blocks, errIncompleteRange
+				}
+
+				return blocks, nil
+			}
+
+			// safe check
+			if block.Number() == 0 {
+				continue
+			}
+
+			if block.Number() != rng.from+uint64(len(blocks)) || block.Number() > rng.to {
+				return blocks, errUnexpectedBlock
+			}
+
+			blocks = append(blocks, block)
+		case <-time.After(s.blockTimeout):
+			return blocks, errTimeout
+		case <-doneCh:
+			return blocks, errBulkSyncFinished
+		}
+	}
+}
+
+// syncHeaders downloads the headers of the given range, fetching disjoint header ranges
+// from the given peers in parallel, and verifies them as a chain following the local latest block.
+// The ranges failing to download or to verify are retried with other peers
+func (s *syncer) syncHeaders(peers []*NoForkPeer, from, to uint64) ([]*types.Header, error) {
+	var (
+		scheduler = newRangeScheduler(from, to, s.headerRangeSize, peers)
+
+		// the downloaded header ranges by their beginning height
+		downloaded = make(map[uint64]*headersResult)
+
+		resultCh = make(chan *headersResult, len(peers))
+		inFlight = 0
+	)
+
+	// all the ranges of the batch may be downloaded at once
+	scheduler.ahead = to - from + 1
+
+	for {
+		for {
+			rng, p, ok := scheduler.next(from)
+			if !ok {
+				break
+			}
+
+			inFlight++
+
+			go func() {
+				headers, err := s.downloadHeaders(p.ID, rng)
+
+				resultCh <- &headersResult{blockRange: rng, peer: p, headers: headers, err: err}
+			}()
+		}
+
+		if inFlight == 0 {
+			if len(scheduler.pending) > 0 {
+				return nil, errNoSyncPeers
+			}
+
+			headers, culprit, err := s.verifyHeaders(downloaded, from, to)
+			if err == nil {
+				return headers, nil
+			}
+
+			s.logger.Warn("peer sent invalid headers, retrying with other peers", "peer", culprit.peer.ID, "err", err)
+			s.network.ReportPeer(culprit.peer.ID, network.PenaltyInvalidBlock, "invalid headers")
+
+			delete(downloaded, culprit.from)
+			scheduler.drop(culprit.peer.ID)
+			scheduler.retry(culprit.blockRange)
+
+			continue
+		}
+
+		res := <-resultCh
+		inFlight--
+
+		if res.err != nil {
+			s.logger.Warn(
+				"failed to download headers from peer, retrying with other peers",
+				"peer", res.peer.ID,
+				"from", res.from,
+				"to", res.to,
+				"err", res.err,
+			)
+
+			// the peer is not used again for this batch
+			scheduler.retry(res.blockRange)
+
+			continue
+		}
+
+		downloaded[res.from] = res
+
+		scheduler.release(res.peer)
+	}
+}
+
+// headersResult is the outcome of downloading a header range from a peer
+type headersResult struct {
+	blockRange
+	peer    *NoForkPeer
+	headers []*types.Header
+	err     error
+}
+
+// verifyHeaders verifies the downloaded header ranges in order, as a chain following the local latest block.
+// Every range is verified once, following the ranges verified before it.
+// If the chain is invalid, the range that failed is returned along with the error
+func (s *syncer) verifyHeaders(
+	downloaded map[uint64]*headersResult,
+	from, to uint64,
+) ([]*types.Header, *headersResult, error) {
+	headers := make([]*types.Header, 0, to-from+1)
+
+	for next := from; next <= to; {
+		res := downloaded[next]
+
+		if err := s.blockchain.VerifyHeaders(headers, res.headers); err != nil {
+			return nil, res, fmt.Errorf("%w, %v", errInvalidHeaders, err)
+		}
+
+		headers = append(headers, res.headers...)
+		next = res.to + 1
+	}
+
+	return headers, nil, nil
+}
+
+// downloadHeaders downloads the header range from the peer
+func (s *syncer) downloadHeaders(peerID peer.ID, rng blockRange) ([]*types.Header, error) {
+	defer func() {
+		if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+			s.logger.Error("failed to close stream", "peer", peerID, "err", err)
+		}
+	}()
+
+	headers, err := s.syncPeerClient.GetHeaders(peerID, rng.from, rng.to, s.blockTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(headers) != int(rng.to-rng.from+1) {
+		return nil, errIncompleteRange
+	}
+
+	for i, header := range headers {
+		if header.Number != rng.from+uint64(i) {
+			return nil, errUnexpectedBlock
+		}
+	}
+
+	return headers, nil
+}
+
+// bodiesDownloader returns a rangeDownloader downloading the bodies and the receipts of the given verified headers,
+// and assembling the blocks out of them. The receipts are checked against the headers before the blocks are
+// executed, so that a peer serving the bodies of another chain is caught before executing its blocks
+func (s *syncer) bodiesDownloader(headers []*types.Header) rangeDownloader {
+	first := headers[0].Number
+
+	return func(peerID peer.ID, rng blockRange, doneCh <-chan struct{}) ([]*types.Block, error) {
+		// closing the stream aborts the requests if the sync finishes before the bodies are received
+		defer func() {
+			if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+				s.logger.Error("failed to close stream", "peer", peerID, "err", err)
+			}
+		}()
+
+		timeout := s.blockTimeout * time.Duration(rng.to-rng.from+1)
+
+		var (
+			bodies   []*types.Body
+			receipts [][]*types.Receipt
+			err      error
+			// buffered, so that the requests don't block once the sync finished
+			resCh = make(chan struct{}, 1)
+		)
+
+		go func() {
+			defer func() {
+				resCh <- struct{}{}
+			}()
+
+			bodies, err = s.syncPeerClient.GetBodies(peerID, rng.from, rng.to, timeout)
+			if err != nil || len(bodies) == 0 {
+				return
+			}
+
+			receipts, err = s.syncPeerClient.GetReceipts(peerID, rng.from, rng.from+uint64(len(bodies))-1, timeout)
+		}()
+
+		select {
+		case <-resCh:
+		case <-doneCh:
+			return nil, errBulkSyncFinished
+		}
+
+		if len(bodies) > int(rng.to-rng.from+1) || len(receipts) > len(bodies) {
+			return nil, errUnexpectedBlock
+		}
+
+		if err == nil && len(receipts) < len(bodies) {
+			err = errIncompleteRange
+		}
+
+		blocks := make([]*types.Block, 0, len(bodies))
+
+		for i, body := range bodies {
+			header := headers[rng.from-first+uint64(i)]
+
+			if err := verifyBody(header, body); err != nil {
+				return blocks, err
+			}
+
+			if i >= len(receipts) {
+				break
+			}
+
+			if err := verifyReceipts(header, body, receipts[i]); err != nil {
+				return blocks, err
+			}
+
+			blocks = append(blocks, &types.Block{
+				Header:       header,
+				Transactions: body.Transactions,
+				Uncles:       body.Uncles,
+			})
+		}
+
+		if err != nil {
+			return blocks, err
+		}
+
+		if len(blocks) < int(rng.to-rng.from+1) {
+			return blocks, errIncompleteRange
+		}
+
+		return blocks, nil
+	}
+}
+
+// verifyBody checks that the block body matches the transactions and uncles roots of its header
+func verifyBody(header *types.Header, body *types.Body) error {
+	if hash := buildroot.CalculateTransactionsRoot(body.Transactions); hash != header.TxRoot {
+		return fmt.Errorf("%w, block %d has invalid transactions root", errInvalidBody, header.Number)
+	}
+
+	if hash := buildroot.CalculateUncleRoot(body.Uncles); hash != header.Sha3Uncles {
+		return fmt.Errorf("%w, block %d has invalid uncles root", errInvalidBody, header.Number)
+	}
+
+	return nil
+}
+
+// verifyReceipts checks that the block receipts match the transactions, the gas used and the receipts root
+// of its header
+func verifyReceipts(header *types.Header, body *types.Body, receipts []*types.Receipt) error {
+	if len(receipts) != len(body.Transactions) {
+		return fmt.Errorf("%w, block %d has %d receipts for %d transactions",
+			errInvalidBody, header.Number, len(receipts), len(body.Transactions))
+	}
+
+	gasUsed := uint64(0)
+	if len(receipts) > 0 {
+		gasUsed = receipts[len(receipts)-1].CumulativeGasUsed
+	}
+
+	if gasUsed != header.GasUsed {
+		return fmt.Errorf("%w, block %d has invalid gas used", errInvalidBody, header.Number)
+	}
+
+	if hash := buildroot.CalculateReceiptsRoot(receipts); hash != header.ReceiptsRoot {
+		return fmt.Errorf("%w, block %d has invalid receipts root", errInvalidBody, header.Number)
+	}
+
+	return nil
+}
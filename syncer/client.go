@@ -289,10 +289,12 @@ func (m *syncPeerClient) CloseStream(peerID peer.ID) error {
 	return m.network.CloseProtocolStream(syncerProto, peerID)
 }
 
-// GetBlocks returns a stream of blocks from given height to peer's latest
+// GetBlocks returns a stream of blocks in the given range,
+// the range ends at peer's latest if to is zero
 func (m *syncPeerClient) GetBlocks(
 	peerID peer.ID,
 	from uint64,
+	to uint64,
 	timeoutPerBlock time.Duration,
 ) (<-chan *types.Block, error) {
 	clt, err := m.newSyncPeerClient(peerID)
@@ -304,6 +306,7 @@ func (m *syncPeerClient) GetBlocks(
 
 	stream, err := clt.GetBlocks(ctx, &proto.GetBlocksRequest{
 		From: from,
+		To:   to,
 	})
 	if err != nil {
 		cancel()
@@ -344,6 +347,141 @@ func (m *syncPeerClient) GetBlocks(
 	return blockCh, nil
 }
 
+// GetHeaders returns the headers in the given range, the range ends at peer's latest if to is zero.
+// The headers received so far are returned along with the error if the request fails halfway
+func (m *syncPeerClient) GetHeaders(
+	peerID peer.ID,
+	from uint64,
+	to uint64,
+	timeout time.Duration,
+) ([]*types.Header, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync peer client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := clt.GetHeaders(ctx, &proto.GetHeadersRequest{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GetHeaders stream: %w", err)
+	}
+
+	headers := make([]*types.Header, 0)
+
+	for {
+		protoHeader, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return headers, nil
+		}
+
+		if err != nil {
+			return headers, err
+		}
+
+		header := &types.Header{}
+		if err := header.UnmarshalRLP(protoHeader.Header); err != nil {
+			return headers, err
+		}
+
+		headers = append(headers, header)
+	}
+}
+
+// GetBodies returns the block bodies in the given range, the range ends at peer's latest if to is zero.
+// The bodies received so far are returned along with the error if the request fails halfway
+func (m *syncPeerClient) GetBodies(
+	peerID peer.ID,
+	from uint64,
+	to uint64,
+	timeout time.Duration,
+) ([]*types.Body, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync peer client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := clt.GetBodies(ctx, &proto.GetBodiesRequest{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GetBodies stream: %w", err)
+	}
+
+	bodies := make([]*types.Body, 0)
+
+	for {
+		protoBody, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return bodies, nil
+		}
+
+		if err != nil {
+			return bodies, err
+		}
+
+		body, err := fromProtoBody(protoBody)
+		if err != nil {
+			return bodies, err
+		}
+
+		bodies = append(bodies, body)
+	}
+}
+
+// GetReceipts returns the block receipts in the given range, the range ends at peer's latest if to is zero.
+// The receipts received so far are returned along with the error if the request fails halfway
+func (m *syncPeerClient) GetReceipts(
+	peerID peer.ID,
+	from uint64,
+	to uint64,
+	timeout time.Duration,
+) ([][]*types.Receipt, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync peer client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := clt.GetReceipts(ctx, &proto.GetReceiptsRequest{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GetReceipts stream: %w", err)
+	}
+
+	receipts := make([][]*types.Receipt, 0)
+
+	for {
+		protoReceipts, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return receipts, nil
+		}
+
+		if err != nil {
+			return receipts, err
+		}
+
+		blockReceipts := types.Receipts{}
+		if err := blockReceipts.UnmarshalRLP(protoReceipts.Receipts); err != nil {
+			return receipts, err
+		}
+
+		receipts = append(receipts, blockReceipts)
+	}
+}
+
 // newSyncPeerClient creates gRPC client
 func (m *syncPeerClient) newSyncPeerClient(peerID peer.ID) (proto.SyncPeerClient, error) {
 	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
@@ -366,6 +504,34 @@ func fromProto(protoBlock *proto.Block) (*types.Block, error) {
 	return block, nil
 }
 
+// fromProtoBody gets block body from gRPC response data
+func fromProtoBody(protoBody *proto.Body) (*types.Body, error) {
+	body := &types.Body{
+		Transactions: make([]*types.Transaction, len(protoBody.Transactions)),
+		Uncles:       make([]*types.Header, len(protoBody.Uncles)),
+	}
+
+	for i, raw := range protoBody.Transactions {
+		tx := &types.Transaction{}
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			return nil, err
+		}
+
+		body.Transactions[i] = tx
+	}
+
+	for i, raw := range protoBody.Uncles {
+		uncle := &types.Header{}
+		if err := uncle.UnmarshalRLP(raw); err != nil {
+			return nil, err
+		}
+
+		body.Uncles[i] = uncle
+	}
+
+	return body, nil
+}
+
 func blockStreamToChannel(stream proto.SyncPeer_GetBlocksClient) (<-chan *types.Block, <-chan error) {
 	blockCh := make(chan *types.Block)
 	errorCh := make(chan error, 1)
@@ -508,7 +508,7 @@ func Test_syncPeerClient_GetBlocks(t *testing.T) {
 
 	assert.NoError(t, err)
 
-	blockStream, err := client.GetBlocks(peerSrv.AddrInfo().ID, syncFrom, 5*time.Second)
+	blockStream, err := client.GetBlocks(peerSrv.AddrInfo().ID, syncFrom, 0, 5*time.Second)
 	assert.NoError(t, err)
 
 	blocks := make([]*types.Block, 0, peerLatest)
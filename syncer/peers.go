@@ -2,6 +2,7 @@ package syncer
 
 import (
 	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -67,3 +68,28 @@ func (m *PeerMap) BestPeer(skipMap map[peer.ID]bool) *NoForkPeer {
 
 	return bestPeer
 }
+
+// BestPeers returns up to max peers whose latest block is above the given height, best first
+func (m *PeerMap) BestPeers(height uint64, max int) []*NoForkPeer {
+	peers := make([]*NoForkPeer, 0)
+
+	m.Range(func(key, value interface{}) bool {
+		peer, _ := value.(*NoForkPeer)
+
+		if peer.Number > height {
+			peers = append(peers, peer)
+		}
+
+		return true
+	})
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].IsBetter(peers[j])
+	})
+
+	if len(peers) > max {
+		peers = peers[:max]
+	}
+
+	return peers
+}
@@ -160,3 +160,56 @@ func TestBestPeer(t *testing.T) {
 		})
 	}
 }
+
+func TestBestPeers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		height uint64
+		max    int
+		peers  []*NoForkPeer
+		result []*NoForkPeer
+	}{
+		{
+			name:   "should return peers ordered from the best",
+			height: 0,
+			max:    3,
+			peers:  peers,
+			result: []*NoForkPeer{peers[2], peers[1], peers[0]},
+		},
+		{
+			name:   "should return empty slice in case of empty map",
+			height: 0,
+			max:    3,
+			peers:  nil,
+			result: []*NoForkPeer{},
+		},
+		{
+			name:   "should skip the peers not above the height",
+			height: 10,
+			max:    3,
+			peers:  peers,
+			result: []*NoForkPeer{peers[2], peers[1]},
+		},
+		{
+			name:   "should return at most max peers",
+			height: 0,
+			max:    1,
+			peers:  peers,
+			result: []*NoForkPeer{peers[2]},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			peerMap := NewPeerMap(test.peers)
+
+			assert.Equal(t, test.result, peerMap.BestPeers(test.height, test.max))
+		})
+	}
+}
@@ -22,6 +22,12 @@ type SyncPeerClient interface {
 	GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (SyncPeer_GetBlocksClient, error)
 	// Returns server's status
 	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SyncPeerStatus, error)
+	// Returns stream of headers in the specified range
+	GetHeaders(ctx context.Context, in *GetHeadersRequest, opts ...grpc.CallOption) (SyncPeer_GetHeadersClient, error)
+	// Returns stream of block bodies in the specified range
+	GetBodies(ctx context.Context, in *GetBodiesRequest, opts ...grpc.CallOption) (SyncPeer_GetBodiesClient, error)
+	// Returns stream of block receipts in the specified range
+	GetReceipts(ctx context.Context, in *GetReceiptsRequest, opts ...grpc.CallOption) (SyncPeer_GetReceiptsClient, error)
 }
 
 type syncPeerClient struct {
@@ -73,6 +79,102 @@ func (c *syncPeerClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *syncPeerClient) GetHeaders(ctx context.Context, in *GetHeadersRequest, opts ...grpc.CallOption) (SyncPeer_GetHeadersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SyncPeer_serviceDesc.Streams[1], "/v1.SyncPeer/GetHeaders", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &syncPeerGetHeadersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SyncPeer_GetHeadersClient interface {
+	Recv() (*Header, error)
+	grpc.ClientStream
+}
+
+type syncPeerGetHeadersClient struct {
+	grpc.ClientStream
+}
+
+func (x *syncPeerGetHeadersClient) Recv() (*Header, error) {
+	m := new(Header)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *syncPeerClient) GetBodies(ctx context.Context, in *GetBodiesRequest, opts ...grpc.CallOption) (SyncPeer_GetBodiesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SyncPeer_serviceDesc.Streams[2], "/v1.SyncPeer/GetBodies", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &syncPeerGetBodiesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SyncPeer_GetBodiesClient interface {
+	Recv() (*Body, error)
+	grpc.ClientStream
+}
+
+type syncPeerGetBodiesClient struct {
+	grpc.ClientStream
+}
+
+func (x *syncPeerGetBodiesClient) Recv() (*Body, error) {
+	m := new(Body)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *syncPeerClient) GetReceipts(ctx context.Context, in *GetReceiptsRequest, opts ...grpc.CallOption) (SyncPeer_GetReceiptsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SyncPeer_serviceDesc.Streams[3], "/v1.SyncPeer/GetReceipts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &syncPeerGetReceiptsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SyncPeer_GetReceiptsClient interface {
+	Recv() (*Receipts, error)
+	grpc.ClientStream
+}
+
+type syncPeerGetReceiptsClient struct {
+	grpc.ClientStream
+}
+
+func (x *syncPeerGetReceiptsClient) Recv() (*Receipts, error) {
+	m := new(Receipts)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // SyncPeerServer is the server API for SyncPeer service.
 // All implementations must embed UnimplementedSyncPeerServer
 // for forward compatibility
@@ -81,6 +183,12 @@ type SyncPeerServer interface {
 	GetBlocks(*GetBlocksRequest, SyncPeer_GetBlocksServer) error
 	// Returns server's status
 	GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error)
+	// Returns stream of headers in the specified range
+	GetHeaders(*GetHeadersRequest, SyncPeer_GetHeadersServer) error
+	// Returns stream of block bodies in the specified range
+	GetBodies(*GetBodiesRequest, SyncPeer_GetBodiesServer) error
+	// Returns stream of block receipts in the specified range
+	GetReceipts(*GetReceiptsRequest, SyncPeer_GetReceiptsServer) error
 	mustEmbedUnimplementedSyncPeerServer()
 }
 
@@ -94,6 +202,15 @@ func (UnimplementedSyncPeerServer) GetBlocks(*GetBlocksRequest, SyncPeer_GetBloc
 func (UnimplementedSyncPeerServer) GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
+func (UnimplementedSyncPeerServer) GetHeaders(*GetHeadersRequest, SyncPeer_GetHeadersServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetHeaders not implemented")
+}
+func (UnimplementedSyncPeerServer) GetBodies(*GetBodiesRequest, SyncPeer_GetBodiesServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetBodies not implemented")
+}
+func (UnimplementedSyncPeerServer) GetReceipts(*GetReceiptsRequest, SyncPeer_GetReceiptsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetReceipts not implemented")
+}
 func (UnimplementedSyncPeerServer) mustEmbedUnimplementedSyncPeerServer() {}
 
 // UnsafeSyncPeerServer may be embedded to opt out of forward compatibility for this service.
@@ -146,6 +263,69 @@ func _SyncPeer_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SyncPeer_GetHeaders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetHeadersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SyncPeerServer).GetHeaders(m, &syncPeerGetHeadersServer{stream})
+}
+
+type SyncPeer_GetHeadersServer interface {
+	Send(*Header) error
+	grpc.ServerStream
+}
+
+type syncPeerGetHeadersServer struct {
+	grpc.ServerStream
+}
+
+func (x *syncPeerGetHeadersServer) Send(m *Header) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SyncPeer_GetBodies_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetBodiesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SyncPeerServer).GetBodies(m, &syncPeerGetBodiesServer{stream})
+}
+
+type SyncPeer_GetBodiesServer interface {
+	Send(*Body) error
+	grpc.ServerStream
+}
+
+type syncPeerGetBodiesServer struct {
+	grpc.ServerStream
+}
+
+func (x *syncPeerGetBodiesServer) Send(m *Body) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SyncPeer_GetReceipts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetReceiptsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SyncPeerServer).GetReceipts(m, &syncPeerGetReceiptsServer{stream})
+}
+
+type SyncPeer_GetReceiptsServer interface {
+	Send(*Receipts) error
+	grpc.ServerStream
+}
+
+type syncPeerGetReceiptsServer struct {
+	grpc.ServerStream
+}
+
+func (x *syncPeerGetReceiptsServer) Send(m *Receipts) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _SyncPeer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.SyncPeer",
 	HandlerType: (*SyncPeerServer)(nil),
@@ -161,6 +341,21 @@ var _SyncPeer_serviceDesc = grpc.ServiceDesc{
 			Handler:       _SyncPeer_GetBlocks_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetHeaders",
+			Handler:       _SyncPeer_GetHeaders_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetBodies",
+			Handler:       _SyncPeer_GetBodies_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetReceipts",
+			Handler:       _SyncPeer_GetReceipts_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "syncer/proto/syncer.proto",
 }
@@ -51,13 +51,12 @@ func (s *syncPeerService) setupGRPCServer() {
 	s.network.RegisterProtocol(syncerProto, s.stream)
 }
 
-// GetBlocks is a gRPC endpoint to return blocks from the specific height via stream
+// GetBlocks is a gRPC endpoint to return blocks in the specific range via stream
 func (s *syncPeerService) GetBlocks(
 	req *proto.GetBlocksRequest,
 	stream proto.SyncPeer_GetBlocksServer,
 ) error {
-	// from to latest
-	for i := req.From; i <= s.blockchain.Header().Number; i++ {
+	for i := req.From; i <= s.rangeEnd(req.To); i++ {
 		block, ok := s.blockchain.GetBlockByNumber(i, true)
 		if !ok {
 			return ErrBlockNotFound
@@ -74,6 +73,82 @@ func (s *syncPeerService) GetBlocks(
 	return nil
 }
 
+// GetHeaders is a gRPC endpoint to return headers in the specific range via stream
+func (s *syncPeerService) GetHeaders(
+	req *proto.GetHeadersRequest,
+	stream proto.SyncPeer_GetHeadersServer,
+) error {
+	for i := req.From; i <= s.rangeEnd(req.To); i++ {
+		header, ok := s.blockchain.GetHeaderByNumber(i)
+		if !ok {
+			return ErrBlockNotFound
+		}
+
+		// if client closes stream, context.Canceled is given
+		if err := stream.Send(&proto.Header{Header: header.MarshalRLP()}); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+// GetBodies is a gRPC endpoint to return block bodies in the specific range via stream
+func (s *syncPeerService) GetBodies(
+	req *proto.GetBodiesRequest,
+	stream proto.SyncPeer_GetBodiesServer,
+) error {
+	for i := req.From; i <= s.rangeEnd(req.To); i++ {
+		block, ok := s.blockchain.GetBlockByNumber(i, true)
+		if !ok {
+			return ErrBlockNotFound
+		}
+
+		// if client closes stream, context.Canceled is given
+		if err := stream.Send(toProtoBody(block.Body())); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+// GetReceipts is a gRPC endpoint to return block receipts in the specific range via stream
+func (s *syncPeerService) GetReceipts(
+	req *proto.GetReceiptsRequest,
+	stream proto.SyncPeer_GetReceiptsServer,
+) error {
+	for i := req.From; i <= s.rangeEnd(req.To); i++ {
+		header, ok := s.blockchain.GetHeaderByNumber(i)
+		if !ok {
+			return ErrBlockNotFound
+		}
+
+		receipts, err := s.blockchain.GetReceiptsByHash(header.Hash)
+		if err != nil {
+			return err
+		}
+
+		// if client closes stream, context.Canceled is given
+		if err := stream.Send(&proto.Receipts{Receipts: types.Receipts(receipts).MarshalRLPTo(nil)}); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+// rangeEnd returns the last height of the requested range,
+// the latest block height if the requested one is zero or beyond the latest block
+func (s *syncPeerService) rangeEnd(to uint64) uint64 {
+	latest := s.blockchain.Header().Number
+	if to != 0 && to < latest {
+		return to
+	}
+
+	return latest
+}
+
 // GetStatus is a gRPC endpoint to return the latest block number as a node status
 func (s *syncPeerService) GetStatus(
 	ctx context.Context,
@@ -95,3 +170,21 @@ func toProtoBlock(block *types.Block) *proto.Block {
 		Block: block.MarshalRLP(),
 	}
 }
+
+// toProtoBody converts types.Body -> proto.Body
+func toProtoBody(body *types.Body) *proto.Body {
+	protoBody := &proto.Body{
+		Transactions: make([][]byte, len(body.Transactions)),
+		Uncles:       make([][]byte, len(body.Uncles)),
+	}
+
+	for i, tx := range body.Transactions {
+		protoBody.Transactions[i] = tx.MarshalRLP()
+	}
+
+	for i, uncle := range body.Uncles {
+		protoBody.Uncles[i] = uncle.MarshalRLP()
+	}
+
+	return protoBody
+}
@@ -60,6 +60,7 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 	tests := []struct {
 		name           string
 		from           uint64
+		to             uint64
 		latest         uint64
 		blocks         []*types.Block
 		receivedBlocks []*types.Block
@@ -73,6 +74,24 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 			receivedBlocks: blocks[4:], // from 5
 			err:            io.EOF,
 		},
+		{
+			name:           "should send the blocks to the requested height",
+			from:           5,
+			to:             7,
+			latest:         10,
+			blocks:         blocks,
+			receivedBlocks: blocks[4:7], // from 5 to 7
+			err:            io.EOF,
+		},
+		{
+			name:           "should send the blocks to the latest if the requested height is higher",
+			from:           5,
+			to:             20,
+			latest:         10,
+			blocks:         blocks,
+			receivedBlocks: blocks[4:], // from 5
+			err:            io.EOF,
+		},
 		{
 			name:           "should return ErrBlockNotFound",
 			from:           5,
@@ -112,6 +131,7 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 
 			stream, err := client.GetBlocks(context.Background(), &proto.GetBlocksRequest{
 				From: test.from,
+				To:   test.to,
 			})
 
 			assert.NoError(t, err)
@@ -136,6 +156,155 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 	}
 }
 
+func Test_syncPeerService_GetHeaders(t *testing.T) {
+	t.Parallel()
+
+	blocks := createMockBlocks(10)
+
+	tests := []struct {
+		name            string
+		from            uint64
+		to              uint64
+		blocks          []*types.Block
+		receivedHeaders []*types.Block
+		err             error
+	}{
+		{
+			name:            "should send the headers to the latest",
+			from:            5,
+			blocks:          blocks,
+			receivedHeaders: blocks[4:], // from 5
+			err:             io.EOF,
+		},
+		{
+			name:            "should send the headers to the requested height",
+			from:            5,
+			to:              7,
+			blocks:          blocks,
+			receivedHeaders: blocks[4:7], // from 5 to 7
+			err:             io.EOF,
+		},
+		{
+			name:            "should return ErrBlockNotFound",
+			from:            5,
+			blocks:          blocks[:8],
+			receivedHeaders: blocks[4:8], // from 5
+			err:             ErrBlockNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			headerMap := make(map[uint64]*types.Header)
+
+			for _, b := range test.blocks {
+				headerMap[b.Number()] = b.Header
+			}
+
+			service := &syncPeerService{
+				blockchain: &mockBlockchain{
+					headerHandler: newSimpleHeaderHandler(10),
+					getHeaderByNumberHandler: func(u uint64) (*types.Header, bool) {
+						header, ok := headerMap[u]
+
+						return header, ok
+					},
+				},
+			}
+
+			client := newMockGrpcClient(t, service)
+
+			stream, err := client.GetHeaders(context.Background(), &proto.GetHeadersRequest{
+				From: test.from,
+				To:   test.to,
+			})
+
+			assert.NoError(t, err)
+
+			count := 0
+
+			for {
+				protoHeader, err := stream.Recv()
+				if err != nil {
+					assert.Contains(t, err.Error(), test.err.Error())
+
+					break
+				}
+
+				assert.Equal(t, test.receivedHeaders[count].Header.MarshalRLP(), protoHeader.Header)
+
+				count++
+			}
+
+			assert.Equal(t, len(test.receivedHeaders), count)
+		})
+	}
+}
+
+func Test_syncPeerService_GetBodiesAndReceipts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		blocks   = createMockBlocksWithBodies(5)
+		receipts = make(map[types.Hash][]*types.Receipt, len(blocks))
+	)
+
+	for _, b := range blocks {
+		receipts[b.Hash()] = []*types.Receipt{
+			{CumulativeGasUsed: b.Number() * 21000, Logs: []*types.Log{}},
+		}
+	}
+
+	service := &syncPeerService{
+		blockchain: &mockBlockchain{
+			headerHandler: newSimpleHeaderHandler(5),
+			getHeaderByNumberHandler: func(u uint64) (*types.Header, bool) {
+				return blocks[u-1].Header, true
+			},
+			getBlockByNumberHandler: func(u uint64, _ bool) (*types.Block, bool) {
+				return blocks[u-1], true
+			},
+			getReceiptsByHashHandler: func(hash types.Hash) ([]*types.Receipt, error) {
+				return receipts[hash], nil
+			},
+		},
+	}
+
+	client := newMockGrpcClient(t, service)
+
+	bodyStream, err := client.GetBodies(context.Background(), &proto.GetBodiesRequest{From: 2, To: 4})
+	assert.NoError(t, err)
+
+	receiptsStream, err := client.GetReceipts(context.Background(), &proto.GetReceiptsRequest{From: 2, To: 4})
+	assert.NoError(t, err)
+
+	for _, b := range blocks[1:4] {
+		protoBody, err := bodyStream.Recv()
+		assert.NoError(t, err)
+
+		body, err := fromProtoBody(protoBody)
+		assert.NoError(t, err)
+		assert.NoError(t, verifyBody(b.Header, body))
+
+		protoReceipts, err := receiptsStream.Recv()
+		assert.NoError(t, err)
+
+		blockReceipts := types.Receipts{}
+		assert.NoError(t, blockReceipts.UnmarshalRLP(protoReceipts.Receipts))
+		assert.Len(t, blockReceipts, 1)
+		assert.Equal(t, b.Number()*21000, blockReceipts[0].CumulativeGasUsed)
+	}
+
+	_, err = bodyStream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = receiptsStream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
 func TestGetStatus(t *testing.T) {
 	t.Parallel()
 
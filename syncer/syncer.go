@@ -2,7 +2,6 @@ package syncer
 
 import (
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/helper/progress"
@@ -26,6 +25,7 @@ var (
 type syncer struct {
 	logger          hclog.Logger
 	blockchain      Blockchain
+	network         Network
 	syncProgression Progression
 
 	peerMap         *PeerMap
@@ -35,6 +35,18 @@ type syncer struct {
 	// Timeout for syncing a block
 	blockTimeout time.Duration
 
+	// Number of blocks requested from a peer at once
+	blockRangeSize uint64
+
+	// Maximum number of peers the blocks are downloaded from in parallel
+	maxSyncPeers int
+
+	// Number of headers requested from a peer at once
+	headerRangeSize uint64
+
+	// Number of headers verified before their bodies are downloaded
+	headerBatchSize uint64
+
 	// Channel to notify Sync that a new status arrived
 	newStatusCh chan struct{}
 }
@@ -48,10 +60,15 @@ func NewSyncer(
 	return &syncer{
 		logger:          logger.Named(syncerName),
 		blockchain:      blockchain,
+		network:         network,
 		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
 		syncPeerService: NewSyncPeerService(network, blockchain),
 		syncPeerClient:  NewSyncPeerClient(logger, network, blockchain),
 		blockTimeout:    blockTimeout,
+		blockRangeSize:  defaultBlockRangeSize,
+		maxSyncPeers:    defaultMaxSyncPeers,
+		headerRangeSize: defaultHeaderRangeSize,
+		headerBatchSize: defaultHeaderBatchSize,
 		newStatusCh:     make(chan struct{}),
 		peerMap:         new(PeerMap),
 	}
@@ -158,10 +175,9 @@ func (s *syncer) HasSyncPeer() bool {
 	return bestPeer != nil && bestPeer.Number > header.Number
 }
 
-// Sync syncs block with the best peer until callback returns true
+// Sync syncs blocks with the best peers until callback returns true
 func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 	localLatest := s.blockchain.Header().Number
-	skipList := make(map[peer.ID]bool)
 
 	for {
 		// Wait for a new event to arrive
@@ -172,31 +188,16 @@ func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 			localLatest = header.Number
 		}
 
-		// pick one best peer
-		bestPeer := s.peerMap.BestPeer(skipList)
-		if bestPeer == nil {
-			// Empty skipList map if there are no best peers
-			skipList = make(map[peer.ID]bool)
-
+		// pick the best peers having new blocks
+		peers := s.peerMap.BestPeers(localLatest, s.maxSyncPeers)
+		if len(peers) == 0 {
 			continue
 		}
 
-		// if the bestPeer does not have a new block continue
-		if bestPeer.Number <= localLatest {
-			continue
-		}
-
-		// fetch block from the peer
-		lastNumber, shouldTerminate, err := s.bulkSyncWithPeer(bestPeer.ID, callback)
+		// fetch blocks from the peers
+		shouldTerminate, err := s.bulkSync(peers, callback)
 		if err != nil {
-			s.logger.Warn("failed to complete bulk sync with peer, try to next one", "peer ID", "error", bestPeer.ID, err)
-		}
-
-		if lastNumber < bestPeer.Number {
-			skipList[bestPeer.ID] = true
-
-			// continue to next peer
-			continue
+			s.logger.Warn("failed to complete bulk sync, waiting for new peer statuses", "error", err)
 		}
 
 		if shouldTerminate {
@@ -206,52 +207,3 @@ func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 
 	return nil
 }
-
-// bulkSyncWithPeer syncs block with a given peer
-func (s *syncer) bulkSyncWithPeer(peerID peer.ID, newBlockCallback func(*types.FullBlock) bool) (uint64, bool, error) {
-	localLatest := s.blockchain.Header().Number
-	shouldTerminate := false
-
-	blockCh, err := s.syncPeerClient.GetBlocks(peerID, localLatest+1, s.blockTimeout)
-	if err != nil {
-		return 0, false, err
-	}
-
-	defer func() {
-		err := s.syncPeerClient.CloseStream(peerID)
-		if err != nil {
-			s.logger.Error("Failed to close stream: ", err)
-		}
-	}()
-
-	var lastReceivedNumber uint64
-
-	for {
-		select {
-		case block, ok := <-blockCh:
-			if !ok {
-				return lastReceivedNumber, shouldTerminate, nil
-			}
-
-			// safe check
-			if block.Number() == 0 {
-				continue
-			}
-
-			fullBlock, err := s.blockchain.VerifyFinalizedBlock(block)
-			if err != nil {
-				return lastReceivedNumber, false, fmt.Errorf("unable to verify block, %w", err)
-			}
-
-			if err := s.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
-				return lastReceivedNumber, false, fmt.Errorf("failed to write block while bulk syncing: %w", err)
-			}
-
-			shouldTerminate = newBlockCallback(fullBlock)
-
-			lastReceivedNumber = block.Number()
-		case <-time.After(s.blockTimeout):
-			return lastReceivedNumber, shouldTerminate, errTimeout
-		}
-	}
-}
@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
@@ -42,6 +46,9 @@ type mockBlockchain struct {
 	verifyFinalizedBlockHandler func(*types.Block) (*types.FullBlock, error)
 	writeBlockHandler           func(*types.Block) error
 	writeFullBlockHandler       func(*types.FullBlock) error
+	getHeaderByNumberHandler    func(uint64) (*types.Header, bool)
+	getReceiptsByHashHandler    func(types.Hash) ([]*types.Receipt, error)
+	verifyHeadersHandler        func([]*types.Header, []*types.Header) error
 }
 
 func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
@@ -56,6 +63,22 @@ func (m *mockBlockchain) GetBlockByNumber(number uint64, full bool) (*types.Bloc
 	return m.getBlockByNumberHandler(number, full)
 }
 
+func (m *mockBlockchain) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	return m.getHeaderByNumberHandler(number)
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return m.getReceiptsByHashHandler(hash)
+}
+
+func (m *mockBlockchain) VerifyHeaders(parents, headers []*types.Header) error {
+	if m.verifyHeadersHandler == nil {
+		return nil
+	}
+
+	return m.verifyHeadersHandler(parents, headers)
+}
+
 func (m *mockBlockchain) VerifyFinalizedBlock(b *types.Block) (*types.FullBlock, error) {
 	return m.verifyFinalizedBlockHandler(b)
 }
@@ -76,6 +99,33 @@ func newSimpleHeaderHandler(num uint64) func() *types.Header {
 	}
 }
 
+type mockNetwork struct {
+	Network
+
+	penalties map[peer.ID]network.PeerPenalty
+	lock      sync.Mutex
+}
+
+func newMockNetwork() *mockNetwork {
+	return &mockNetwork{
+		penalties: make(map[peer.ID]network.PeerPenalty),
+	}
+}
+
+func (m *mockNetwork) ReportPeer(peerID peer.ID, penalty network.PeerPenalty, _ string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.penalties[peerID] += penalty
+}
+
+func (m *mockNetwork) penalty(peerID peer.ID) network.PeerPenalty {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.penalties[peerID]
+}
+
 type mockSyncPeerService struct{}
 
 func (m *mockSyncPeerService) Start() {}
@@ -89,7 +139,10 @@ func (m *mockProgression) StopProgression() {}
 type mockSyncPeerClient struct {
 	getPeerStatusHandler                  func(peer.ID) (*NoForkPeer, error)
 	getConnectedPeerStatusesHandler       func() []*NoForkPeer
-	getBlocksHandler                      func(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	getBlocksHandler                      func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Block, error)
+	getHeadersHandler                     func(peer.ID, uint64, uint64, time.Duration) ([]*types.Header, error)
+	getBodiesHandler                      func(peer.ID, uint64, uint64, time.Duration) ([]*types.Body, error)
+	getReceiptsHandler                    func(peer.ID, uint64, uint64, time.Duration) ([][]*types.Receipt, error)
 	getPeerStatusUpdateChHandler          func() <-chan *NoForkPeer
 	getPeerConnectionUpdateEventChHandler func() <-chan *event.PeerEvent
 }
@@ -114,10 +167,45 @@ func (m *mockSyncPeerClient) GetConnectedPeerStatuses() []*NoForkPeer {
 
 func (m *mockSyncPeerClient) GetBlocks(
 	id peer.ID,
-	start uint64,
+	from uint64,
+	to uint64,
 	timeoutPerBlock time.Duration,
 ) (<-chan *types.Block, error) {
-	return m.getBlocksHandler(id, start, timeoutPerBlock)
+	return m.getBlocksHandler(id, from, to, timeoutPerBlock)
+}
+
+// errHeadersNotServed is returned by the mock peers not serving headers, like the peers of older versions
+var errHeadersNotServed = errors.New("unknown method GetHeaders")
+
+func (m *mockSyncPeerClient) GetHeaders(
+	id peer.ID,
+	from uint64,
+	to uint64,
+	timeout time.Duration,
+) ([]*types.Header, error) {
+	if m.getHeadersHandler == nil {
+		return nil, errHeadersNotServed
+	}
+
+	return m.getHeadersHandler(id, from, to, timeout)
+}
+
+func (m *mockSyncPeerClient) GetBodies(
+	id peer.ID,
+	from uint64,
+	to uint64,
+	timeout time.Duration,
+) ([]*types.Body, error) {
+	return m.getBodiesHandler(id, from, to, timeout)
+}
+
+func (m *mockSyncPeerClient) GetReceipts(
+	id peer.ID,
+	from uint64,
+	to uint64,
+	timeout time.Duration,
+) ([][]*types.Receipt, error) {
+	return m.getReceiptsHandler(id, from, to, timeout)
 }
 
 func (m *mockSyncPeerClient) GetPeerStatusUpdateCh() <-chan *NoForkPeer {
@@ -167,10 +255,15 @@ func NewTestSyncer(
 	return &syncer{
 		logger:          hclog.NewNullLogger(),
 		blockchain:      blockchain,
+		network:         network,
 		syncProgression: mockProgression,
 		syncPeerService: &mockSyncPeerService{},
 		syncPeerClient:  mockSyncPeerClient,
 		blockTimeout:    blockTimeout,
+		blockRangeSize:  defaultBlockRangeSize,
+		maxSyncPeers:    defaultMaxSyncPeers,
+		headerRangeSize: defaultHeaderRangeSize,
+		headerBatchSize: defaultHeaderBatchSize,
 		newStatusCh:     make(chan struct{}),
 		peerMap:         new(PeerMap),
 	}
@@ -491,6 +584,48 @@ func createMockBlocks(num int) []*types.Block {
 	return blocks
 }
 
+// createMockReceipts returns the receipts of the block created by createMockBlocksWithBodies
+func createMockReceipts(number uint64) []*types.Receipt {
+	receipt := &types.Receipt{CumulativeGasUsed: number * 21000}
+	receipt.SetStatus(types.ReceiptSuccess)
+
+	return []*types.Receipt{receipt}
+}
+
+// createMockBlocksWithBodies returns chained blocks having a transaction each,
+// with the transactions, uncles and receipts roots matching their bodies
+func createMockBlocksWithBodies(num int) []*types.Block {
+	blocks := make([]*types.Block, num)
+	parentHash := types.ZeroHash
+
+	for i := 0; i < num; i++ {
+		txs := []*types.Transaction{
+			{Nonce: uint64(i), Value: big.NewInt(1), GasPrice: big.NewInt(1)},
+		}
+
+		receipts := createMockReceipts(uint64(i + 1))
+
+		header := &types.Header{
+			Number:       uint64(i + 1),
+			ParentHash:   parentHash,
+			TxRoot:       buildroot.CalculateTransactionsRoot(txs),
+			Sha3Uncles:   types.EmptyUncleHash,
+			ReceiptsRoot: buildroot.CalculateReceiptsRoot(receipts),
+			GasUsed:      receipts[len(receipts)-1].CumulativeGasUsed,
+		}
+		header.ComputeHash()
+
+		blocks[i] = &types.Block{
+			Header:       header,
+			Transactions: txs,
+		}
+
+		parentHash = header.Hash
+	}
+
+	return blocks
+}
+
 func TestSync(t *testing.T) {
 	t.Parallel()
 
@@ -506,7 +641,7 @@ func TestSync(t *testing.T) {
 		// peers
 		peerStatuses []*NoForkPeer
 
-		peerBlocksCh   map[peer.ID]<-chan *types.Block
+		peerBlocks     map[peer.ID][]*types.Block
 		newStatusDelay time.Duration
 
 		// handlers
@@ -535,8 +670,8 @@ func TestSync(t *testing.T) {
 				},
 			},
 			newStatusDelay: 0,
-			peerBlocksCh: map[peer.ID]<-chan *types.Block{
-				peer.ID("A"): blocksToCh(blocks[:10], 0),
+			peerBlocks: map[peer.ID][]*types.Block{
+				peer.ID("A"): blocks[:10],
 			},
 			createVerifyFinalizedBlockHandler: func() func(*types.Block) (*types.FullBlock, error) {
 				return func(b *types.Block) (*types.FullBlock, error) {
@@ -571,9 +706,9 @@ func TestSync(t *testing.T) {
 				},
 			},
 			newStatusDelay: 0,
-			peerBlocksCh: map[peer.ID]<-chan *types.Block{
-				peer.ID("A"): blocksToCh(blocks[:10], 0),
-				peer.ID("B"): blocksToCh(blocks[4:10], 0),
+			peerBlocks: map[peer.ID][]*types.Block{
+				peer.ID("A"): blocks[:10],
+				peer.ID("B"): blocks[:10],
 			},
 			createVerifyFinalizedBlockHandler: func() func(*types.Block) (*types.FullBlock, error) {
 				count := 0
@@ -611,9 +746,13 @@ func TestSync(t *testing.T) {
 				progression       = &mockProgression{}
 
 				syncer = NewTestSyncer(
-					nil,
+					newMockNetwork(),
 					&mockBlockchain{
-						headerHandler:               newSimpleHeaderHandler(latestBlockNumber),
+						headerHandler: func() *types.Header {
+							return &types.Header{
+								Number: latestBlockNumber,
+							}
+						},
 						verifyFinalizedBlockHandler: test.createVerifyFinalizedBlockHandler(),
 						writeFullBlockHandler: func(b *types.FullBlock) error {
 							syncedBlocks = append(syncedBlocks, b.Block)
@@ -624,17 +763,15 @@ func TestSync(t *testing.T) {
 					},
 					time.Second,
 					&mockSyncPeerClient{
-						getBlocksHandler: func(i peer.ID, u uint64, _ time.Duration) (<-chan *types.Block, error) {
-							// should not panic
-							peerCh := test.peerBlocksCh[i]
-
-							return peerCh, nil
-						},
+						getBlocksHandler: newRangeBlocksHandler(test.peerBlocks, 0),
 					},
 					progression,
 				)
 			)
 
+			// split the blocks into several ranges
+			syncer.blockRangeSize = 3
+
 			errCh := make(chan error, 1)
 
 			go func() {
@@ -661,27 +798,50 @@ func TestSync(t *testing.T) {
 	}
 }
 
-func Test_bulkSyncWithPeer(t *testing.T) {
-	t.Parallel()
-
-	blockNum := 30
-	blocks := make([]*types.Block, blockNum) // 1 to 30
-
-	for i := 0; i < blockNum; i++ {
-		blocks[i] = &types.Block{
-			Header: &types.Header{
-				Number: uint64(i + 1),
-			},
+// newRangeBlocksHandler returns a GetBlocks handler streaming the requested range of the peer blocks
+func newRangeBlocksHandler(
+	peerBlocks map[peer.ID][]*types.Block,
+	delay time.Duration,
+) func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Block, error) {
+	return func(id peer.ID, from, to uint64, _ time.Duration) (<-chan *types.Block, error) {
+		rangeBlocks := make([]*types.Block, 0)
+
+		for _, b := range peerBlocks[id] {
+			if b.Number() >= from && (to == 0 || b.Number() <= to) {
+				rangeBlocks = append(rangeBlocks, b)
+			}
 		}
+
+		return blocksToCh(rangeBlocks, delay), nil
 	}
+}
+
+func Test_bulkSync(t *testing.T) {
+	t.Parallel()
 
 	var (
+		blocks    = createMockBlocks(20)
+		badBlocks = createMockBlocks(20)
+
+		peerA = &NoForkPeer{ID: peer.ID("A"), Number: 20, Distance: big.NewInt(1)}
+		peerB = &NoForkPeer{ID: peer.ID("B"), Number: 20, Distance: big.NewInt(2)}
+
 		// mock errors
 		errPeerNoResponse       = errors.New("peer is not responding")
-		errInvalidBlock         = errors.New("invalid block")
 		errBlockInsertionFailed = errors.New("failed to insert block")
 	)
 
+	// the blocks from badBlocks never pass the verification
+	badBlockSet := make(map[*types.Block]bool, len(badBlocks))
+	for _, b := range badBlocks {
+		badBlockSet[b] = true
+	}
+
+	honestHandler := newRangeBlocksHandler(map[peer.ID][]*types.Block{
+		peerA.ID: blocks,
+		peerB.ID: blocks,
+	}, 0)
+
 	tests := []struct {
 		name string
 
@@ -691,98 +851,114 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 		blockCallback   func(*types.FullBlock) bool
 
 		// peers
-		getBlocksHandler func(id peer.ID, start uint64, timeoutPerBlock time.Duration) (<-chan *types.Block, error)
+		peers            []*NoForkPeer
+		getBlocksHandler func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Block, error)
 
 		// handlers
-		verifyFinalizedBlockHandler func(*types.Block) (*types.FullBlock, error)
-		writeFullBlockHandler       func(*types.FullBlock) error
+		writeFullBlockHandler func(*types.FullBlock) error
 
 		// results
-		blocks                []*types.Block
-		lastSyncedBlockNumber uint64
-		shouldTerminate       bool
-		err                   error
+		blocks          []*types.Block
+		servingPeers    []peer.ID
+		shouldTerminate bool
+		err             error
+		penalties       map[peer.ID]network.PeerPenalty
 	}{
 		{
 			name:            "should sync blocks to the latest successfully",
 			beginningHeight: 0,
 			blockTimeout:    time.Second,
-			blockCallback: func(b *types.FullBlock) bool {
-				return false
-			},
-			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
-				return blocksToCh(blocks[:10], 0), nil
-			},
-			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
-				return &types.FullBlock{Block: b}, nil
-			},
-			writeFullBlockHandler: func(b *types.FullBlock) error {
-				return nil
-			},
-			blocks:                blocks[:10],
-			lastSyncedBlockNumber: 10,
-			shouldTerminate:       false,
-			err:                   nil,
+			peers:           []*NoForkPeer{{ID: peerA.ID, Number: 10, Distance: big.NewInt(1)}},
+			getBlocksHandler: newRangeBlocksHandler(map[peer.ID][]*types.Block{
+				peerA.ID: blocks[:10],
+			}, 0),
+			blocks:       blocks[:10],
+			servingPeers: []peer.ID{peerA.ID},
+		},
+		{
+			name:             "should sync blocks from multiple peers in parallel",
+			beginningHeight:  0,
+			blockTimeout:     time.Second,
+			peers:            []*NoForkPeer{peerA, peerB},
+			getBlocksHandler: honestHandler,
+			blocks:           blocks,
+			servingPeers:     []peer.ID{peerA.ID, peerB.ID},
 		},
 		{
-			name:            "should return error if GetBlocks returns error",
+			name:             "should sync blocks following the local latest block",
+			beginningHeight:  12,
+			blockTimeout:     time.Second,
+			peers:            []*NoForkPeer{peerA, peerB},
+			getBlocksHandler: honestHandler,
+			blocks:           blocks[12:],
+			servingPeers:     []peer.ID{peerA.ID, peerB.ID},
+		},
+		{
+			name:            "should retry the ranges with another peer if GetBlocks returns error",
 			beginningHeight: 0,
 			blockTimeout:    time.Second,
-			blockCallback: func(b *types.FullBlock) bool {
-				return false
-			},
-			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
-				return nil, errPeerNoResponse
-			},
-			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
-				return &types.FullBlock{Block: b}, nil
-			},
-			writeFullBlockHandler: func(b *types.FullBlock) error {
-				return nil
+			peers:           []*NoForkPeer{peerA, peerB},
+			getBlocksHandler: func(id peer.ID, from, to uint64, timeout time.Duration) (<-chan *types.Block, error) {
+				if id == peerA.ID {
+					return nil, errPeerNoResponse
+				}
+
+				return honestHandler(id, from, to, timeout)
 			},
-			blocks:                []*types.Block{},
-			lastSyncedBlockNumber: 0,
-			shouldTerminate:       false,
-			err:                   errPeerNoResponse,
+			blocks:       blocks,
+			servingPeers: []peer.ID{peerB.ID},
 		},
 		{
-			name:            "should return error if verification is failed",
+			name:            "should retry the rest of the range with another peer in case of timeout",
 			beginningHeight: 0,
-			blockTimeout:    time.Second,
-			blockCallback: func(b *types.FullBlock) bool {
-				return false
-			},
-			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
-				return blocksToCh(blocks[:10], 0), nil
-			},
-			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
-				if b.Number() > 5 {
-					return nil, errInvalidBlock
+			blockTimeout:    200 * time.Millisecond,
+			peers:           []*NoForkPeer{peerA, peerB},
+			getBlocksHandler: func(id peer.ID, from, to uint64, timeout time.Duration) (<-chan *types.Block, error) {
+				if id == peerA.ID {
+					// send the first block of the range and stall
+					ch := make(chan *types.Block, 1)
+					ch <- blocks[from-1]
+
+					return ch, nil
 				}
 
-				return &types.FullBlock{Block: b}, nil
-			},
-			writeFullBlockHandler: func(b *types.FullBlock) error {
-				return nil
+				return honestHandler(id, from, to, timeout)
 			},
-			blocks:                blocks[:5],
-			lastSyncedBlockNumber: 5,
-			shouldTerminate:       false,
-			err:                   errInvalidBlock,
+			blocks:       blocks,
+			servingPeers: []peer.ID{peerA.ID, peerB.ID},
 		},
 		{
-			name:            "should return error if block insertion is failed",
+			name:            "should penalize the peer sending invalid blocks and retry with another peer",
 			beginningHeight: 0,
 			blockTimeout:    time.Second,
-			blockCallback: func(b *types.FullBlock) bool {
-				return false
-			},
-			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
-				return blocksToCh(blocks[:10], 0), nil
+			peers:           []*NoForkPeer{peerA, peerB},
+			getBlocksHandler: newRangeBlocksHandler(map[peer.ID][]*types.Block{
+				peerA.ID: badBlocks,
+				peerB.ID: blocks,
+			}, 0),
+			blocks:       blocks,
+			servingPeers: []peer.ID{peerA.ID, peerB.ID},
+			penalties: map[peer.ID]network.PeerPenalty{
+				peerA.ID: network.PenaltyInvalidBlock,
 			},
-			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
-				return &types.FullBlock{Block: b}, nil
+		},
+		{
+			name:            "should return error if no peer can serve the blocks",
+			beginningHeight: 0,
+			blockTimeout:    time.Second,
+			peers:           []*NoForkPeer{peerA},
+			getBlocksHandler: func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Block, error) {
+				return nil, errPeerNoResponse
 			},
+			blocks: []*types.Block{},
+			err:    errNoSyncPeers,
+		},
+		{
+			name:             "should return error if block insertion is failed",
+			beginningHeight:  0,
+			blockTimeout:     time.Second,
+			peers:            []*NoForkPeer{peerA, peerB},
+			getBlocksHandler: honestHandler,
 			writeFullBlockHandler: func(b *types.FullBlock) error {
 				if b.Block.Number() > 5 {
 					return errBlockInsertionFailed
@@ -790,31 +966,20 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 
 				return nil
 			},
-			blocks:                blocks[:5],
-			lastSyncedBlockNumber: 5,
-			shouldTerminate:       false,
-			err:                   errBlockInsertionFailed,
+			blocks: blocks[:5],
+			err:    errBlockInsertionFailed,
 		},
 		{
-			name:            "should return error in case of timeout",
+			name:            "should terminate if callback returns true",
 			beginningHeight: 0,
-			blockTimeout:    500 * time.Millisecond,
+			blockTimeout:    time.Second,
 			blockCallback: func(b *types.FullBlock) bool {
-				return false
-			},
-			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
-				return blocksToCh(blocks[:10], time.Second*1), nil
-			},
-			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
-				return &types.FullBlock{Block: b}, nil
-			},
-			writeFullBlockHandler: func(b *types.FullBlock) error {
-				return nil
+				return b.Block.Number() == 7
 			},
-			blocks:                []*types.Block{},
-			lastSyncedBlockNumber: 0,
-			shouldTerminate:       false,
-			err:                   errTimeout,
+			peers:            []*NoForkPeer{peerA, peerB},
+			getBlocksHandler: honestHandler,
+			blocks:           blocks[:7],
+			shouldTerminate:  true,
 		},
 	}
 
@@ -826,15 +991,27 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 
 			var (
 				syncedBlocks = make([]*types.Block, 0, len(test.blocks))
+				mockNetwork  = newMockNetwork()
+
+				requests     = make(map[peer.ID]int)
+				requestsLock sync.Mutex
 
 				syncer = NewTestSyncer(
-					nil,
+					mockNetwork,
 					&mockBlockchain{
-						headerHandler:               newSimpleHeaderHandler(test.beginningHeight),
-						verifyFinalizedBlockHandler: test.verifyFinalizedBlockHandler,
+						headerHandler: newSimpleHeaderHandler(test.beginningHeight),
+						verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+							if badBlockSet[b] {
+								return nil, errors.New("invalid block")
+							}
+
+							return &types.FullBlock{Block: b}, nil
+						},
 						writeFullBlockHandler: func(b *types.FullBlock) error {
-							if err := test.writeFullBlockHandler(b); err != nil {
-								return err
+							if test.writeFullBlockHandler != nil {
+								if err := test.writeFullBlockHandler(b); err != nil {
+									return err
+								}
 							}
 
 							syncedBlocks = append(syncedBlocks, b.Block)
@@ -844,18 +1021,345 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 					},
 					test.blockTimeout,
 					&mockSyncPeerClient{
-						getBlocksHandler: test.getBlocksHandler,
+						getBlocksHandler: func(
+							id peer.ID,
+							from, to uint64,
+							timeout time.Duration,
+						) (<-chan *types.Block, error) {
+							requestsLock.Lock()
+							requests[id]++
+							requestsLock.Unlock()
+
+							return test.getBlocksHandler(id, from, to, timeout)
+						},
 					},
 					&mockProgression{},
 				)
 			)
 
-			lastSynced, shouldTerminate, err := syncer.bulkSyncWithPeer(peer.ID("X"), test.blockCallback)
+			syncer.blockRangeSize = 5
+
+			blockCallback := test.blockCallback
+			if blockCallback == nil {
+				blockCallback = func(*types.FullBlock) bool {
+					return false
+				}
+			}
+
+			shouldTerminate, err := syncer.bulkSync(test.peers, blockCallback)
 
-			assert.Equal(t, test.lastSyncedBlockNumber, lastSynced)
 			assert.Equal(t, test.shouldTerminate, shouldTerminate)
 			assert.ErrorIs(t, err, test.err)
 			assert.Equal(t, test.blocks, syncedBlocks)
+
+			for _, p := range test.peers {
+				assert.Equal(t, test.penalties[p.ID], mockNetwork.penalty(p.ID))
+			}
+
+			requestsLock.Lock()
+			defer requestsLock.Unlock()
+
+			for _, id := range test.servingPeers {
+				assert.Positive(t, requests[id], id)
+			}
 		})
 	}
 }
+
+// newRangeHeadersHandler returns a GetHeaders handler returning the requested range of the peer block headers
+func newRangeHeadersHandler(
+	peerBlocks map[peer.ID][]*types.Block,
+) func(peer.ID, uint64, uint64, time.Duration) ([]*types.Header, error) {
+	return func(id peer.ID, from, to uint64, _ time.Duration) ([]*types.Header, error) {
+		headers := make([]*types.Header, 0)
+
+		for _, b := range peerBlocks[id] {
+			if b.Number() >= from && b.Number() <= to {
+				headers = append(headers, b.Header)
+			}
+		}
+
+		return headers, nil
+	}
+}
+
+// newRangeBodiesHandler returns a GetBodies handler returning the requested range of the peer block bodies
+func newRangeBodiesHandler(
+	peerBlocks map[peer.ID][]*types.Block,
+) func(peer.ID, uint64, uint64, time.Duration) ([]*types.Body, error) {
+	return func(id peer.ID, from, to uint64, _ time.Duration) ([]*types.Body, error) {
+		bodies := make([]*types.Body, 0)
+
+		for _, b := range peerBlocks[id] {
+			if b.Number() >= from && b.Number() <= to {
+				bodies = append(bodies, b.Body())
+			}
+		}
+
+		return bodies, nil
+	}
+}
+
+// newRangeReceiptsHandler returns a GetReceipts handler returning the receipts of the requested range
+// of the peer blocks, created by createMockReceipts
+func newRangeReceiptsHandler(
+	peerBlocks map[peer.ID][]*types.Block,
+) func(peer.ID, uint64, uint64, time.Duration) ([][]*types.Receipt, error) {
+	return func(id peer.ID, from, to uint64, _ time.Duration) ([][]*types.Receipt, error) {
+		receipts := make([][]*types.Receipt, 0)
+
+		for _, b := range peerBlocks[id] {
+			if b.Number() >= from && b.Number() <= to {
+				receipts = append(receipts, createMockReceipts(b.Number()))
+			}
+		}
+
+		return receipts, nil
+	}
+}
+
+func Test_bulkSync_HeaderFirst(t *testing.T) {
+	t.Parallel()
+
+	var (
+		blocks    = createMockBlocksWithBodies(20)
+		badBlocks = createMockBlocksWithBodies(20)
+
+		peerA = &NoForkPeer{ID: peer.ID("A"), Number: 20, Distance: big.NewInt(1)}
+		peerB = &NoForkPeer{ID: peer.ID("B"), Number: 20, Distance: big.NewInt(2)}
+
+		honestBlocks = map[peer.ID][]*types.Block{
+			peerA.ID: blocks,
+			peerB.ID: blocks,
+		}
+	)
+
+	// the headers from badBlocks never pass the verification
+	badHeaderSet := make(map[*types.Header]bool, len(badBlocks))
+	for _, b := range badBlocks {
+		badHeaderSet[b.Header] = true
+	}
+
+	// the bodies of the blocks don't match the headers of the other blocks
+	mismatchingBlocks := make([]*types.Block, len(blocks))
+	for i, b := range blocks {
+		mismatchingBlocks[i] = &types.Block{
+			Header:       b.Header,
+			Transactions: blocks[(i+1)%len(blocks)].Transactions,
+		}
+	}
+
+	tests := []struct {
+		name string
+
+		// peers
+		getHeadersHandler  func(peer.ID, uint64, uint64, time.Duration) ([]*types.Header, error)
+		getBodiesHandler   func(peer.ID, uint64, uint64, time.Duration) ([]*types.Body, error)
+		getReceiptsHandler func(peer.ID, uint64, uint64, time.Duration) ([][]*types.Receipt, error)
+
+		// results
+		blockRequests       bool
+		penalizedPeers      map[peer.ID]bool
+		headersVerifiedOnce bool
+	}{
+		{
+			name:                "should sync the headers first and the bodies afterwards",
+			getHeadersHandler:   newRangeHeadersHandler(honestBlocks),
+			getBodiesHandler:    newRangeBodiesHandler(honestBlocks),
+			getReceiptsHandler:  newRangeReceiptsHandler(honestBlocks),
+			headersVerifiedOnce: true,
+		},
+		{
+			name: "should penalize the peer sending invalid headers and retry with another peer",
+			getHeadersHandler: newRangeHeadersHandler(map[peer.ID][]*types.Block{
+				peerA.ID: badBlocks,
+				peerB.ID: blocks,
+			}),
+			getBodiesHandler:   newRangeBodiesHandler(honestBlocks),
+			getReceiptsHandler: newRangeReceiptsHandler(honestBlocks),
+			penalizedPeers:     map[peer.ID]bool{peerA.ID: true},
+		},
+		{
+			name:              "should penalize the peer sending bodies not matching the headers",
+			getHeadersHandler: newRangeHeadersHandler(honestBlocks),
+			getBodiesHandler: newRangeBodiesHandler(map[peer.ID][]*types.Block{
+				peerA.ID: mismatchingBlocks,
+				peerB.ID: blocks,
+			}),
+			getReceiptsHandler:  newRangeReceiptsHandler(honestBlocks),
+			penalizedPeers:      map[peer.ID]bool{peerA.ID: true},
+			headersVerifiedOnce: true,
+		},
+		{
+			name:              "should penalize the peer sending receipts not matching the headers",
+			getHeadersHandler: newRangeHeadersHandler(honestBlocks),
+			getBodiesHandler:  newRangeBodiesHandler(honestBlocks),
+			getReceiptsHandler: func(id peer.ID, from, to uint64, timeout time.Duration) ([][]*types.Receipt, error) {
+				receipts, err := newRangeReceiptsHandler(honestBlocks)(id, from, to, timeout)
+				if id == peerA.ID {
+					for _, r := range receipts {
+						r[0].CumulativeGasUsed++
+					}
+				}
+
+				return receipts, err
+			},
+			penalizedPeers:      map[peer.ID]bool{peerA.ID: true},
+			headersVerifiedOnce: true,
+		},
+		{
+			name: "should download whole blocks if the peers don't serve headers",
+			getHeadersHandler: func(peer.ID, uint64, uint64, time.Duration) ([]*types.Header, error) {
+				return nil, errHeadersNotServed
+			},
+			blockRequests: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				syncedBlocks    = make([]*types.Block, 0, len(blocks))
+				latestNumber    = uint64(0)
+				verifiedHeaders = 0
+				blockRequests   atomic.Int32
+				mockNetwork     = newMockNetwork()
+
+				syncer = NewTestSyncer(
+					mockNetwork,
+					&mockBlockchain{
+						headerHandler: func() *types.Header {
+							return &types.Header{Number: latestNumber}
+						},
+						verifyHeadersHandler: func(_, headers []*types.Header) error {
+							verifiedHeaders += len(headers)
+
+							for _, h := range headers {
+								if badHeaderSet[h] {
+									return errors.New("invalid header")
+								}
+							}
+
+							return nil
+						},
+						verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+							return &types.FullBlock{Block: b}, nil
+						},
+						writeFullBlockHandler: func(b *types.FullBlock) error {
+							syncedBlocks = append(syncedBlocks, b.Block)
+							latestNumber = b.Block.Number()
+
+							return nil
+						},
+					},
+					time.Second,
+					&mockSyncPeerClient{
+						getHeadersHandler:  test.getHeadersHandler,
+						getBodiesHandler:   test.getBodiesHandler,
+						getReceiptsHandler: test.getReceiptsHandler,
+						getBlocksHandler: func(
+							id peer.ID,
+							from, to uint64,
+							timeout time.Duration,
+						) (<-chan *types.Block, error) {
+							blockRequests.Add(1)
+
+							return newRangeBlocksHandler(honestBlocks, 0)(id, from, to, timeout)
+						},
+					},
+					&mockProgression{},
+				)
+			)
+
+			// split the blocks into several batches of several ranges
+			syncer.blockRangeSize = 3
+			syncer.headerRangeSize = 4
+			syncer.headerBatchSize = 8
+
+			shouldTerminate, err := syncer.bulkSync([]*NoForkPeer{peerA, peerB}, func(*types.FullBlock) bool {
+				return false
+			})
+
+			assert.False(t, shouldTerminate)
+			assert.NoError(t, err)
+			assert.Equal(t, len(blocks), len(syncedBlocks))
+
+			for i, b := range syncedBlocks {
+				assert.Equal(t, blocks[i].Header, b.Header)
+				assert.Equal(t, blocks[i].Transactions, b.Transactions)
+			}
+
+			assert.Equal(t, test.blockRequests, blockRequests.Load() > 0)
+
+			if test.headersVerifiedOnce {
+				assert.Equal(t, len(blocks), verifiedHeaders)
+			}
+
+			// the peer is penalized in every batch it serves
+			for _, id := range []peer.ID{peerA.ID, peerB.ID} {
+				assert.Equal(t, test.penalizedPeers[id], mockNetwork.penalty(id) > 0, id)
+			}
+		})
+	}
+}
+
+func Test_splitBlockRange(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(
+		t,
+		[]blockRange{{from: 1, to: 5}, {from: 6, to: 10}, {from: 11, to: 12}},
+		splitBlockRange(1, 12, 5),
+	)
+
+	assert.Equal(
+		t,
+		[]blockRange{{from: 7, to: 7}},
+		splitBlockRange(7, 7, 5),
+	)
+}
+
+func Test_rangeScheduler(t *testing.T) {
+	t.Parallel()
+
+	var (
+		peerA = &NoForkPeer{ID: peer.ID("A"), Number: 20, Distance: big.NewInt(1)}
+		peerB = &NoForkPeer{ID: peer.ID("B"), Number: 8, Distance: big.NewInt(1)}
+	)
+
+	scheduler := newRangeScheduler(1, 20, 5, []*NoForkPeer{peerA, peerB})
+
+	// the best peer gets the lowest range
+	rng, p, ok := scheduler.next(1)
+	assert.True(t, ok)
+	assert.Equal(t, blockRange{from: 1, to: 5}, rng)
+	assert.Equal(t, peerA, p)
+
+	// the remaining peer can't serve any range beyond its latest block
+	_, _, ok = scheduler.next(1)
+	assert.False(t, ok)
+
+	// the failed range is retried first
+	scheduler.retry(blockRange{from: 3, to: 5})
+	rng, p, ok = scheduler.next(1)
+	assert.True(t, ok)
+	assert.Equal(t, blockRange{from: 3, to: 5}, rng)
+	assert.Equal(t, peerB, p)
+
+	// the released peer gets the next range
+	scheduler.release(peerA)
+	rng, p, ok = scheduler.next(6)
+	assert.True(t, ok)
+	assert.Equal(t, blockRange{from: 6, to: 10}, rng)
+	assert.Equal(t, peerA, p)
+
+	// the dropped peer doesn't get ranges anymore
+	scheduler.release(peerA)
+	scheduler.drop(peerA.ID)
+	_, _, ok = scheduler.next(6)
+	assert.False(t, ok)
+}
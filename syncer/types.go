@@ -21,8 +21,15 @@ type Blockchain interface {
 	SubscribeEvents() blockchain.Subscription
 	// Header returns get latest header
 	Header() *types.Header
+	// GetHeaderByNumber returns header by number
+	GetHeaderByNumber(uint64) (*types.Header, bool)
 	// GetBlockByNumber returns block by number
 	GetBlockByNumber(uint64, bool) (*types.Block, bool)
+	// GetReceiptsByHash returns the receipts of the block by its hash
+	GetReceiptsByHash(types.Hash) ([]*types.Receipt, error)
+	// VerifyHeaders verifies a chain of headers following the already verified parents,
+	// which follow the local chain, without writing them
+	VerifyHeaders(parents, headers []*types.Header) error
 	// VerifyFinalizedBlock verifies finalized block
 	VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error)
 	// WriteBlock writes a given block to chain
@@ -53,6 +60,8 @@ type Network interface {
 	SaveProtocolStream(protocol string, stream *rawGrpc.ClientConn, peerID peer.ID)
 	// CloseProtocolStream closes stream
 	CloseProtocolStream(protocol string, peerID peer.ID) error
+	// ReportPeer penalizes the misbehaving peer
+	ReportPeer(peerID peer.ID, penalty network.PeerPenalty, reason string)
 }
 
 type Syncer interface {
@@ -95,8 +104,14 @@ type SyncPeerClient interface {
 	GetPeerStatus(id peer.ID) (*NoForkPeer, error)
 	// GetConnectedPeerStatuses fetches the statuses of all connecting peers
 	GetConnectedPeerStatuses() []*NoForkPeer
-	// GetBlocks returns a stream of blocks from given height to peer's latest
-	GetBlocks(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	// GetBlocks returns a stream of blocks in the given range, the range ends at peer's latest if to is zero
+	GetBlocks(peerID peer.ID, from uint64, to uint64, timeoutPerBlock time.Duration) (<-chan *types.Block, error)
+	// GetHeaders returns the headers in the given range, the range ends at peer's latest if to is zero
+	GetHeaders(peerID peer.ID, from uint64, to uint64, timeout time.Duration) ([]*types.Header, error)
+	// GetBodies returns the block bodies in the given range, the range ends at peer's latest if to is zero
+	GetBodies(peerID peer.ID, from uint64, to uint64, timeout time.Duration) ([]*types.Body, error)
+	// GetReceipts returns the block receipts in the given range, the range ends at peer's latest if to is zero
+	GetReceipts(peerID peer.ID, from uint64, to uint64, timeout time.Duration) ([][]*types.Receipt, error)
 	// GetPeerStatusUpdateCh returns a channel of peer's status update
 	GetPeerStatusUpdateCh() <-chan *NoForkPeer
 	// GetPeerConnectionUpdateEventCh returns peer's connection change event
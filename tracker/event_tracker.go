@@ -5,22 +5,31 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
 	hcf "github.com/hashicorp/go-hclog"
 	"github.com/umbracle/ethgo"
 	"github.com/umbracle/ethgo/blocktracker"
-	"github.com/umbracle/ethgo/jsonrpc"
 	"github.com/umbracle/ethgo/tracker"
 )
 
 const minBlockMaxBacklog = 96
 
+// eventSubscription is notified about the finalized logs.
+// If AddLog returns an error, the log is delivered again with the next block
 type eventSubscription interface {
-	AddLog(log *ethgo.Log)
+	AddLog(log *ethgo.Log) error
+}
+
+// eventRemovalSubscription is implemented by the subscribers which need to be notified
+// when a log already delivered to them is removed from the chain by a reorg
+type eventRemovalSubscription interface {
+	RemoveLog(log *ethgo.Log)
 }
 
 type EventTracker struct {
 	dbPath                string
 	rpcEndpoint           string
+	credentials           *txrelayer.Credentials
 	contractAddr          ethgo.Address
 	startBlock            uint64
 	subscriber            eventSubscription
@@ -31,6 +40,7 @@ type EventTracker struct {
 func NewEventTracker(
 	dbPath string,
 	rpcEndpoint string,
+	credentials *txrelayer.Credentials,
 	contractAddr ethgo.Address,
 	subscriber eventSubscription,
 	numBlockConfirmations uint64,
@@ -40,6 +50,7 @@ func NewEventTracker(
 	return &EventTracker{
 		dbPath:                dbPath,
 		rpcEndpoint:           rpcEndpoint,
+		credentials:           credentials,
 		contractAddr:          contractAddr,
 		subscriber:            subscriber,
 		numBlockConfirmations: numBlockConfirmations,
@@ -55,7 +66,7 @@ func (e *EventTracker) Start(ctx context.Context) error {
 		"num block confirmations", e.numBlockConfirmations,
 		"start block", e.startBlock)
 
-	provider, err := jsonrpc.NewClient(e.rpcEndpoint)
+	provider, err := txrelayer.NewClient(e.rpcEndpoint, e.credentials)
 	if err != nil {
 		return err
 	}
@@ -76,6 +87,7 @@ func (e *EventTracker) Start(ctx context.Context) error {
 		<-ctx.Done()
 		blockTracker.Close()
 		store.Close()
+		provider.Close()
 	}()
 
 	// Init and start block tracker concurrently, retrying indefinitely
@@ -101,31 +113,63 @@ func (e *EventTracker) Start(ctx context.Context) error {
 		return nil
 	})
 
-	tt, err := tracker.NewTracker(provider.Eth(),
-		tracker.WithBatchSize(10),
-		tracker.WithBlockTracker(blockTracker),
-		tracker.WithStore(store),
-		tracker.WithFilter(&tracker.FilterConfig{
-			Async: true,
-			Address: []ethgo.Address{
-				e.contractAddr,
-			},
-			Start: e.startBlock,
-		}),
-	)
+	newTracker := func() (*tracker.Tracker, error) {
+		return tracker.NewTracker(provider.Eth(),
+			tracker.WithBatchSize(10),
+			tracker.WithBlockTracker(blockTracker),
+			tracker.WithStore(store),
+			tracker.WithFilter(&tracker.FilterConfig{
+				Async: true,
+				Address: []ethgo.Address{
+					e.contractAddr,
+				},
+				Start: e.startBlock,
+			}),
+		)
+	}
+
+	tt, err := newTracker()
 	if err != nil {
 		return err
 	}
+
 	// Sync concurrently, retrying indefinitely
-	go common.RetryForever(ctx, time.Second, func(context.Context) error {
-		if err := tt.Sync(ctx); err != nil {
-			e.logger.Error("failed to sync", "error", err)
+	go func() {
+		for {
+			syncCtx, cancelFn := context.WithCancel(ctx)
 
-			return err
-		}
+			common.RetryForever(syncCtx, time.Second, func(context.Context) error {
+				if err := tt.Sync(syncCtx); err != nil {
+					e.logger.Error("failed to sync", "error", err)
 
-		return nil
-	})
+					return err
+				}
+
+				return nil
+			})
+
+			// a rolled back reorg requires syncing again from the rewound last block
+			select {
+			case <-ctx.Done():
+				cancelFn()
+
+				return
+			case <-store.resyncCh:
+				cancelFn()
+			}
+
+			e.logger.Info("Syncing the events again after a reorg")
+
+			next, err := newTracker()
+			if err != nil {
+				e.logger.Error("failed to create the tracker", "error", err)
+
+				return
+			}
+
+			tt = next
+		}
+	}()
 
 	return nil
 }
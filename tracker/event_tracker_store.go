@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync/atomic"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	hcf "github.com/hashicorp/go-hclog"
@@ -14,7 +15,12 @@ import (
 	bolt "go.etcd.io/bbolt"
 )
 
-const dbLastBlockPrefix = "lastBlock_"
+const (
+	dbLastBlockPrefix = "lastBlock_"
+
+	// blockHistoryLength is the number of the latest tracked blocks kept to roll back a reorg
+	blockHistoryLength = minBlockMaxBacklog
+)
 
 var (
 	_ store.Store = (*EventTrackerStore)(nil)
@@ -22,6 +28,7 @@ var (
 	dbLogs           = []byte("logs")
 	dbConf           = []byte("conf")
 	dbNextToProcess  = []byte("nextToProcess")
+	dbBlocks         = []byte("blocks")
 	nextToProcessKey = []byte("0")
 )
 
@@ -31,6 +38,12 @@ type EventTrackerStore struct {
 	numBlockConfirmations uint64
 	subscriber            eventSubscription
 	logger                hcf.Logger
+
+	// resyncCh is notified when a reorg is rolled back, so that the tracker syncs again from the rewound last block
+	resyncCh chan struct{}
+	// resyncing is set from the reorg rollback until the tracker reads the last block again,
+	// the writes of the outdated tracker are ignored meanwhile
+	resyncing *atomic.Bool
 }
 
 // NewEventTrackerStore creates a new EventTrackerStore
@@ -49,6 +62,8 @@ func NewEventTrackerStore(
 		numBlockConfirmations: numBlockConfirmations,
 		subscriber:            subscriber,
 		logger:                logger,
+		resyncCh:              make(chan struct{}, 1),
+		resyncing:             new(atomic.Bool),
 	}
 
 	if err := store.setupDB(); err != nil {
@@ -79,6 +94,11 @@ func (b *EventTrackerStore) Close() error {
 func (b *EventTrackerStore) Get(k string) (string, error) {
 	var result []byte
 
+	// the tracker reads the last block when it starts syncing
+	if strings.HasPrefix(k, dbLastBlockPrefix) {
+		b.resyncing.Store(false)
+	}
+
 	if err := b.conn.View(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(dbConf)
 		result = bucket.Get([]byte(k))
@@ -113,16 +133,50 @@ func (b *EventTrackerStore) ListPrefix(prefix string) ([]string, error) {
 
 // Set implements the store interface
 func (b *EventTrackerStore) Set(k, v string) error {
+	isLastBlock := strings.HasPrefix(k, dbLastBlockPrefix)
+
+	// the outdated tracker can not move the last block rewound by a reorg rollback
+	if isLastBlock && b.resyncing.Load() {
+		return nil
+	}
+
+	var prevValue []byte
+
 	if err := b.conn.Update(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(dbConf)
 
+		if prev := bucket.Get([]byte(k)); prev != nil {
+			prevValue = make([]byte, len(prev))
+			copy(prevValue, prev)
+		}
+
+		if isLastBlock {
+			if err := putBlockHistory(tx, k[len(dbLastBlockPrefix):], v); err != nil {
+				return err
+			}
+		}
+
 		return bucket.Put([]byte(k), []byte(v))
 	}); err != nil {
 		return err
 	}
 
-	if strings.HasPrefix(k, dbLastBlockPrefix) {
-		if err := b.onNewBlock(k[len(dbLastBlockPrefix):], v); err != nil {
+	if isLastBlock {
+		filterHash := k[len(dbLastBlockPrefix):]
+
+		if prevValue != nil {
+			rolledBack, err := b.checkReorg(filterHash, string(prevValue), v)
+			if err != nil {
+				b.logger.Error("failed to roll back the reorg", "err", err)
+			}
+
+			if rolledBack {
+				// the logs are processed once the tracker syncs again
+				return nil
+			}
+		}
+
+		if err := b.onNewBlock(filterHash, v); err != nil {
 			b.logger.Warn("new block error", "err", err)
 		}
 	}
@@ -130,15 +184,197 @@ func (b *EventTrackerStore) Set(k, v string) error {
 	return nil
 }
 
-func (b *EventTrackerStore) onNewBlock(filterHash, blockData string) error {
-	var block ethgo.Block
+// checkReorg compares the new last block with the previously stored one
+// and rolls back the reorg if the new block does not extend it.
+// Returns true if the reorg has been rolled back
+func (b *EventTrackerStore) checkReorg(filterHash, prevBlockData, blockData string) (bool, error) {
+	prevBlock, err := decodeBlock(prevBlockData)
+	if err != nil {
+		return false, nil
+	}
 
-	bytes, err := hex.DecodeString(blockData)
+	block, err := decodeBlock(blockData)
+	if err != nil {
+		return false, nil
+	}
+
+	var forkNumber uint64
+
+	switch {
+	case block.Number == prevBlock.Number+1 && block.ParentHash != prevBlock.Hash:
+		// the previous last block has been replaced
+		forkNumber = prevBlock.Number
+	case block.Number <= prevBlock.Number && block.Hash != prevBlock.Hash:
+		forkNumber = block.Number
+	default:
+		return false, nil
+	}
+
+	b.logger.Warn("Reorg detected",
+		"previous block", prevBlock.Number, "previous hash", prevBlock.Hash,
+		"new block", block.Number, "new hash", block.Hash, "parent hash", block.ParentHash)
+
+	if err := b.rollback(filterHash, forkNumber); err != nil {
+		return false, err
+	}
+
+	b.resyncing.Store(true)
+
+	select {
+	case b.resyncCh <- struct{}{}:
+	default:
+	}
+
+	return true, nil
+}
+
+// rollback rewinds the last block to the latest tracked block before the fork point
+// and removes the logs after it, notifying the subscriber about the removed delivered logs.
+// The tracker syncs the logs of the canonical chain from the rewound last block again
+func (b *EventTrackerStore) rollback(filterHash string, forkNumber uint64) error {
+	entry, err := b.getImplEntry(filterHash)
+	if err != nil {
+		return err
+	}
+
+	var removedLogs []*ethgo.Log
+
+	if err := b.conn.Update(func(tx *bolt.Tx) error {
+		ancestorData, err := rewindBlockHistory(tx, filterHash, forkNumber)
+		if err != nil {
+			return err
+		}
+
+		ancestor, err := decodeBlock(string(ancestorData))
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Bucket(dbConf).Put([]byte(dbLastBlockPrefix+filterHash), ancestorData); err != nil {
+			return err
+		}
+
+		b.logger.Info("Rewinding the last block", "block", ancestor.Number, "hash", ancestor.Hash)
+
+		removedLogs, err = entry.removeLogsFromBlock(tx, ancestor.Number+1)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	entry.notifyRemovedLogs(removedLogs)
+
+	return nil
+}
+
+// putBlockHistory records the tracked block, dropping the blocks older than blockHistoryLength
+func putBlockHistory(tx *bolt.Tx, filterHash, blockData string) error {
+	block, err := decodeBlock(blockData)
 	if err != nil {
+		return nil //nolint:nilerr // the history is kept for the valid blocks only
+	}
+
+	bucket, err := tx.CreateBucketIfNotExists(append(dbBlocks, []byte(filterHash)...))
+	if err != nil {
+		return err
+	}
+
+	if err := bucket.Put(common.EncodeUint64ToBytes(block.Number), []byte(blockData)); err != nil {
 		return err
 	}
 
+	if block.Number < blockHistoryLength {
+		return nil
+	}
+
+	var (
+		keys   [][]byte
+		oldest = block.Number - blockHistoryLength
+	)
+
+	cursor := bucket.Cursor()
+	for k, _ := cursor.First(); k != nil && common.EncodeBytesToUint64(k) <= oldest; k, _ = cursor.Next() {
+		keys = append(keys, append([]byte(nil), k...))
+	}
+
+	for _, k := range keys {
+		if err := bucket.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rewindBlockHistory drops the tracked blocks from the fork point,
+// and returns the latest tracked block before it
+func rewindBlockHistory(tx *bolt.Tx, filterHash string, forkNumber uint64) ([]byte, error) {
+	bucket := tx.Bucket(append(dbBlocks, []byte(filterHash)...))
+	if bucket == nil {
+		return nil, fmt.Errorf("no tracked block before the fork block %d", forkNumber)
+	}
+
+	var (
+		keys     [][]byte
+		ancestor []byte
+	)
+
+	cursor := bucket.Cursor()
+	for k, v := cursor.Last(); k != nil; k, v = cursor.Prev() {
+		if common.EncodeBytesToUint64(k) < forkNumber {
+			ancestor = append([]byte(nil), v...)
+
+			break
+		}
+
+		keys = append(keys, append([]byte(nil), k...))
+	}
+
+	if ancestor == nil {
+		return nil, fmt.Errorf("no tracked block before the fork block %d", forkNumber)
+	}
+
+	for _, k := range keys {
+		if err := bucket.Delete(k); err != nil {
+			return nil, err
+		}
+	}
+
+	return ancestor, nil
+}
+
+// notifyRemovedLogs notifies the subscriber about the delivered logs removed by a reorg
+func (b *EventTrackerStore) notifyRemovedLogs(logs []*ethgo.Log) {
+	b.logger.Warn("Delivered event logs have been removed by a reorg", "len", len(logs))
+
+	subscriber, ok := b.subscriber.(eventRemovalSubscription)
+	if !ok {
+		return
+	}
+
+	for _, log := range logs {
+		subscriber.RemoveLog(log)
+	}
+}
+
+func decodeBlock(blockData string) (*ethgo.Block, error) {
+	bytes, err := hex.DecodeString(blockData)
+	if err != nil {
+		return nil, err
+	}
+
+	block := &ethgo.Block{}
 	if err := block.UnmarshalJSON(bytes); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func (b *EventTrackerStore) onNewBlock(filterHash, blockData string) error {
+	block, err := decodeBlock(blockData)
+	if err != nil {
 		return err
 	}
 
@@ -161,14 +397,22 @@ func (b *EventTrackerStore) onNewBlock(filterHash, blockData string) error {
 	}
 
 	nextToProcessIdx := common.EncodeBytesToUint64(lastProcessedKey) + 1
+	firstIdx := nextToProcessIdx - uint64(len(logs))
 
-	if err := entry.saveNextToProcessIndx(nextToProcessIdx); err != nil {
-		return err
+	// notify subscriber with logs
+	for i, log := range logs {
+		if err := b.subscriber.AddLog(log); err != nil {
+			// the log is delivered again with the next block
+			if saveErr := entry.saveNextToProcessIndx(firstIdx + uint64(i)); saveErr != nil {
+				return saveErr
+			}
+
+			return fmt.Errorf("failed to notify the subscriber about log %d: %w", firstIdx+uint64(i), err)
+		}
 	}
 
-	// notify subscriber with logs
-	for _, log := range logs {
-		b.subscriber.AddLog(log)
+	if err := entry.saveNextToProcessIndx(nextToProcessIdx); err != nil {
+		return err
 	}
 
 	b.logger.Debug("Event logs have been notified to a subscriber", "len", len(logs), "next", nextToProcessIdx)
@@ -203,6 +447,8 @@ func (b *EventTrackerStore) getImplEntry(hash string) (*Entry, error) {
 		conn:                b.conn,
 		bucketLogs:          logsBucketName,
 		bucketNextToProcess: nextToProcessBucketName,
+		onRemovedLogs:       b.notifyRemovedLogs,
+		resyncing:           b.resyncing,
 	}, nil
 }
 
@@ -211,6 +457,10 @@ type Entry struct {
 	conn                *bolt.DB
 	bucketLogs          []byte
 	bucketNextToProcess []byte
+	// onRemovedLogs is called with the already delivered logs removed by a reorg, latest first
+	onRemovedLogs func(logs []*ethgo.Log)
+	// resyncing is set while the writes of the outdated tracker are ignored
+	resyncing *atomic.Bool
 }
 
 // LastIndex implements the store.Entry interface
@@ -236,7 +486,7 @@ func (e *Entry) StoreLog(log *ethgo.Log) error {
 // StoreLogs implements the store.Entry interface
 // logs are added in sequentional order
 func (e *Entry) StoreLogs(logs []*ethgo.Log) error {
-	if len(logs) == 0 { // dont start tx if there is nothing to add
+	if len(logs) == 0 || e.isResyncing() { // dont start tx if there is nothing to add
 		return nil
 	}
 
@@ -262,19 +512,110 @@ func (e *Entry) StoreLogs(logs []*ethgo.Log) error {
 }
 
 // RemoveLogs implements the store.Entry interface
+// If some of the removed logs were already delivered to the subscriber,
+// the subscriber is notified about their removal and the logs replacing them are delivered again
 func (e *Entry) RemoveLogs(indx uint64) error {
-	return e.conn.Update(func(tx *bolt.Tx) error {
-		cursorLogs := tx.Bucket(e.bucketLogs).Cursor()
+	if e.isResyncing() {
+		return nil
+	}
 
-		// remove logs
-		for k, _ := cursorLogs.Seek(common.EncodeUint64ToBytes(indx)); k != nil; k, _ = cursorLogs.Next() {
-			if err := cursorLogs.Delete(); err != nil {
-				return err
+	var removedLogs []*ethgo.Log
+
+	if err := e.conn.Update(func(tx *bolt.Tx) (err error) {
+		removedLogs, err = e.removeLogs(tx, indx)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	e.notifyRemovedLogs(removedLogs)
+
+	return nil
+}
+
+// removeLogsFromBlock removes the logs of the given block and the later ones,
+// and returns the removed logs already delivered to the subscriber
+func (e *Entry) removeLogsFromBlock(tx *bolt.Tx, blockNumber uint64) ([]*ethgo.Log, error) {
+	indx := getLastIndex(tx.Bucket(e.bucketLogs))
+
+	cursorLogs := tx.Bucket(e.bucketLogs).Cursor()
+	for k, v := cursorLogs.Last(); k != nil; k, v = cursorLogs.Prev() {
+		log := &ethgo.Log{}
+		if err := log.UnmarshalJSON(v); err != nil {
+			return nil, err
+		}
+
+		if log.BlockNumber < blockNumber {
+			break
+		}
+
+		indx = common.EncodeBytesToUint64(k)
+	}
+
+	return e.removeLogs(tx, indx)
+}
+
+// removeLogs removes the logs starting from the given index,
+// and returns the removed logs already delivered to the subscriber
+func (e *Entry) removeLogs(tx *bolt.Tx, indx uint64) ([]*ethgo.Log, error) {
+	bucketNextToProcess := tx.Bucket(e.bucketNextToProcess)
+	bucketLogs := tx.Bucket(e.bucketLogs)
+
+	nextToProcessIdx := uint64(0)
+	if val := bucketNextToProcess.Get(nextToProcessKey); val != nil {
+		nextToProcessIdx = common.EncodeBytesToUint64(val)
+	}
+
+	var (
+		keys        [][]byte
+		removedLogs []*ethgo.Log
+	)
+
+	cursorLogs := bucketLogs.Cursor()
+	for k, v := cursorLogs.Seek(common.EncodeUint64ToBytes(indx)); k != nil; k, v = cursorLogs.Next() {
+		keys = append(keys, append([]byte(nil), k...))
+
+		if common.EncodeBytesToUint64(k) < nextToProcessIdx {
+			log := &ethgo.Log{}
+			if err := log.UnmarshalJSON(v); err != nil {
+				return nil, err
 			}
+
+			removedLogs = append(removedLogs, log)
 		}
+	}
 
-		return nil
-	})
+	// remove logs
+	for _, k := range keys {
+		if err := bucketLogs.Delete(k); err != nil {
+			return nil, err
+		}
+	}
+
+	if indx >= nextToProcessIdx {
+		return removedLogs, nil
+	}
+
+	// the logs replacing the removed ones are yet to be delivered
+	return removedLogs, bucketNextToProcess.Put(nextToProcessKey, common.EncodeUint64ToBytes(indx))
+}
+
+// notifyRemovedLogs notifies about the removed delivered logs, in reverse order of their delivery
+func (e *Entry) notifyRemovedLogs(removedLogs []*ethgo.Log) {
+	if len(removedLogs) == 0 || e.onRemovedLogs == nil {
+		return
+	}
+
+	for i, j := 0, len(removedLogs)-1; i < j; i, j = i+1, j-1 {
+		removedLogs[i], removedLogs[j] = removedLogs[j], removedLogs[i]
+	}
+
+	e.onRemovedLogs(removedLogs)
+}
+
+func (e *Entry) isResyncing() bool {
+	return e.resyncing != nil && e.resyncing.Load()
 }
 
 // GetLog implements the store.Entry interface
@@ -2,6 +2,7 @@ package tracker
 
 import (
 	"encoding/hex"
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -180,3 +181,218 @@ func TestEventTrackerStore_SetLastBlockSubscriberNotified(t *testing.T) {
 		require.NoError(t, entry.(*Entry).saveNextToProcessIndx(0)) //nolint
 	}
 }
+
+func TestEventTrackerStore_RemoveLogs_DeliveredLogs(t *testing.T) {
+	t.Parallel()
+
+	const hash = "dummy_hash"
+
+	subs := &mockEventSubscriber{}
+
+	tstore, closeFn := createSetupDB(subs, 2)(t)
+	defer closeFn()
+
+	setLastBlock := func(number uint64) {
+		t.Helper()
+
+		block := ethgo.Block{Number: number}
+
+		bytes, err := block.MarshalJSON()
+		require.NoError(t, err)
+
+		require.NoError(t, tstore.Set(dbLastBlockPrefix+hash, hex.EncodeToString(bytes)))
+	}
+
+	entry, err := tstore.GetEntry(hash)
+	require.NoError(t, err)
+
+	require.NoError(t, entry.StoreLogs([]*ethgo.Log{
+		{BlockNumber: 1, BlockHash: ethgo.Hash{1}},
+		{BlockNumber: 2, BlockHash: ethgo.Hash{2}},
+		{BlockNumber: 3, BlockHash: ethgo.Hash{3}},
+		{BlockNumber: 4, BlockHash: ethgo.Hash{4}},
+	}))
+
+	// logs of the blocks 1, 2 and 3 are delivered
+	setLastBlock(5)
+	require.Len(t, subs.logs, 3)
+
+	// reorg replaces the blocks starting from the block 2
+	require.NoError(t, entry.RemoveLogs(1))
+
+	require.Len(t, subs.removedLogs, 2)
+	assert.Equal(t, ethgo.Hash{3}, subs.removedLogs[0].BlockHash)
+	assert.Equal(t, ethgo.Hash{2}, subs.removedLogs[1].BlockHash)
+
+	lastIndex, err := entry.LastIndex()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), lastIndex)
+
+	require.NoError(t, entry.StoreLogs([]*ethgo.Log{
+		{BlockNumber: 2, BlockHash: ethgo.Hash{22}},
+		{BlockNumber: 4, BlockHash: ethgo.Hash{44}},
+	}))
+
+	// the replacing logs are delivered once they are confirmed
+	subs.logs = nil
+
+	setLastBlock(6)
+	require.Len(t, subs.logs, 2)
+	assert.Equal(t, ethgo.Hash{22}, subs.logs[0].BlockHash)
+	assert.Equal(t, ethgo.Hash{44}, subs.logs[1].BlockHash)
+}
+
+func TestEventTrackerStore_RemoveLogs_UndeliveredLogs(t *testing.T) {
+	t.Parallel()
+
+	const hash = "dummy_hash"
+
+	subs := &mockEventSubscriber{}
+
+	tstore, closeFn := createSetupDB(subs, 2)(t)
+	defer closeFn()
+
+	entry, err := tstore.GetEntry(hash)
+	require.NoError(t, err)
+
+	require.NoError(t, entry.StoreLogs([]*ethgo.Log{
+		{BlockNumber: 1}, {BlockNumber: 2}, {BlockNumber: 3},
+	}))
+
+	require.NoError(t, entry.RemoveLogs(1))
+	require.Empty(t, subs.removedLogs)
+
+	lastIndex, err := entry.LastIndex()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), lastIndex)
+}
+
+func TestEventTrackerStore_SetLastBlockSubscriberFailed(t *testing.T) {
+	t.Parallel()
+
+	const hash = "dummy_hash"
+
+	subs := &mockEventSubscriber{}
+
+	tstore, closeFn := createSetupDB(subs, 0)(t)
+	defer closeFn()
+
+	setLastBlock := func(number uint64) {
+		t.Helper()
+
+		block := ethgo.Block{Number: number}
+
+		bytes, err := block.MarshalJSON()
+		require.NoError(t, err)
+
+		require.NoError(t, tstore.Set(dbLastBlockPrefix+hash, hex.EncodeToString(bytes)))
+	}
+
+	entry, err := tstore.GetEntry(hash)
+	require.NoError(t, err)
+
+	require.NoError(t, entry.StoreLogs([]*ethgo.Log{{BlockNumber: 1}, {BlockNumber: 2}}))
+
+	// the subscriber fails to process the logs
+	subs.addErr = errors.New("store failure")
+
+	setLastBlock(2)
+	require.Empty(t, subs.logs)
+
+	// the logs are delivered again with the next block
+	subs.addErr = nil
+
+	setLastBlock(3)
+	require.Len(t, subs.logs, 2)
+	assert.Equal(t, uint64(1), subs.logs[0].BlockNumber)
+	assert.Equal(t, uint64(2), subs.logs[1].BlockNumber)
+}
+
+func TestEventTrackerStore_SetLastBlockReorg(t *testing.T) {
+	t.Parallel()
+
+	const hash = "dummy_hash"
+
+	subs := &mockEventSubscriber{}
+
+	tstore, closeFn := createSetupDB(subs, 0)(t)
+	defer closeFn()
+
+	eventStore := tstore.(*EventTrackerStore) //nolint:forcetypeassert
+
+	encodeBlock := func(block *ethgo.Block) string {
+		t.Helper()
+
+		bytes, err := block.MarshalJSON()
+		require.NoError(t, err)
+
+		return hex.EncodeToString(bytes)
+	}
+
+	entry, err := tstore.GetEntry(hash)
+	require.NoError(t, err)
+
+	// the chain of the blocks 1 to 4 with a log in each of the blocks 2 to 4
+	for i := byte(1); i <= 4; i++ {
+		block := &ethgo.Block{Number: uint64(i), Hash: ethgo.Hash{i}, ParentHash: ethgo.Hash{i - 1}}
+
+		if i > 1 {
+			require.NoError(t, entry.StoreLogs([]*ethgo.Log{{BlockNumber: block.Number, BlockHash: block.Hash}}))
+		}
+
+		require.NoError(t, tstore.Set(dbLastBlockPrefix+hash, encodeBlock(block)))
+	}
+
+	require.Len(t, subs.logs, 3)
+
+	// the block 3 is replaced by a block with a different hash
+	reorgBlock := &ethgo.Block{Number: 3, Hash: ethgo.Hash{33}, ParentHash: ethgo.Hash{2}}
+	require.NoError(t, tstore.Set(dbLastBlockPrefix+hash, encodeBlock(reorgBlock)))
+
+	// the logs of the blocks 3 and 4 are removed, the latest first
+	require.Len(t, subs.removedLogs, 2)
+	assert.Equal(t, ethgo.Hash{4}, subs.removedLogs[0].BlockHash)
+	assert.Equal(t, ethgo.Hash{3}, subs.removedLogs[1].BlockHash)
+
+	lastIndex, err := entry.LastIndex()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), lastIndex)
+
+	// the tracker is notified to sync again
+	select {
+	case <-eventStore.resyncCh:
+	default:
+		t.Fatal("resync not requested")
+	}
+
+	// the writes of the outdated tracker are ignored until the last block is read again
+	require.NoError(t, entry.StoreLogs([]*ethgo.Log{{BlockNumber: 5, BlockHash: ethgo.Hash{5}}}))
+	require.NoError(t, tstore.Set(dbLastBlockPrefix+hash, encodeBlock(&ethgo.Block{Number: 5, Hash: ethgo.Hash{5}})))
+
+	lastIndex, err = entry.LastIndex()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), lastIndex)
+
+	// the last block is rewound to the block 2
+	lastBlockData, err := tstore.Get(dbLastBlockPrefix + hash)
+	require.NoError(t, err)
+	assert.Equal(t, encodeBlock(&ethgo.Block{Number: 2, Hash: ethgo.Hash{2}, ParentHash: ethgo.Hash{1}}), lastBlockData)
+
+	// the tracker syncs the canonical chain from the block 3 again
+	subs.logs = nil
+
+	for i := byte(3); i <= 4; i++ {
+		block := &ethgo.Block{Number: uint64(i), Hash: ethgo.Hash{i * 11}, ParentHash: ethgo.Hash{(i - 1) * 11}}
+		if i == 3 {
+			block.ParentHash = ethgo.Hash{2}
+		}
+
+		require.NoError(t, entry.StoreLogs([]*ethgo.Log{{BlockNumber: block.Number, BlockHash: block.Hash}}))
+		require.NoError(t, tstore.Set(dbLastBlockPrefix+hash, encodeBlock(block)))
+	}
+
+	require.Len(t, subs.logs, 2)
+	assert.Equal(t, ethgo.Hash{33}, subs.logs[0].BlockHash)
+	assert.Equal(t, ethgo.Hash{44}, subs.logs[1].BlockHash)
+	require.Len(t, subs.removedLogs, 2)
+}
@@ -16,19 +16,34 @@ import (
 )
 
 type mockEventSubscriber struct {
-	lock sync.RWMutex
-	logs []*ethgo.Log
+	lock        sync.RWMutex
+	logs        []*ethgo.Log
+	removedLogs []*ethgo.Log
+	addErr      error
 }
 
-func (m *mockEventSubscriber) AddLog(log *ethgo.Log) {
+func (m *mockEventSubscriber) AddLog(log *ethgo.Log) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	if m.addErr != nil {
+		return m.addErr
+	}
+
 	if len(m.logs) == 0 {
 		m.logs = []*ethgo.Log{}
 	}
 
 	m.logs = append(m.logs, log)
+
+	return nil
+}
+
+func (m *mockEventSubscriber) RemoveLog(log *ethgo.Log) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.removedLogs = append(m.removedLogs, log)
 }
 
 func (m *mockEventSubscriber) len() int {
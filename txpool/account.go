@@ -1,6 +1,7 @@
 package txpool
 
 import (
+	"math/big"
 	"sync"
 	"sync/atomic"
 
@@ -223,22 +224,68 @@ func (a *account) reset(nonce uint64, promoteCh chan<- promoteRequest) (
 }
 
 // enqueue attempts tp push the transaction onto the enqueued queue.
-func (a *account) enqueue(tx *types.Transaction) error {
+// If the account already holds a transaction with the same nonce (either
+// enqueued or promoted), it gets replaced by the given transaction instead,
+// provided that the given transaction pays at least priceBump percent more.
+// The replaced transaction is returned along with the flag indicating
+// whether it was promoted.
+func (a *account) enqueue(tx *types.Transaction, priceBump uint64) (
+	replaced *types.Transaction,
+	promoted bool,
+	err error,
+) {
+	a.promoted.lock(true)
 	a.enqueued.lock(true)
-	defer a.enqueued.unlock()
 
-	if a.enqueued.length() == a.maxEnqueued {
-		return ErrMaxEnqueuedLimitReached
-	}
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
 
-	// reject low nonce tx
 	if tx.Nonce < a.getNonce() {
-		return ErrNonceTooLow
+		// only a promoted tx can be replaced by a low nonce tx
+		if replaced, err = a.promoted.replace(tx, priceBump); replaced != nil || err != nil {
+			return replaced, true, err
+		}
+
+		// reject low nonce tx
+		return nil, false, ErrNonceTooLow
+	}
+
+	if replaced, err = a.enqueued.replace(tx, priceBump); replaced != nil || err != nil {
+		return replaced, false, err
+	}
+
+	if a.enqueued.length() == a.maxEnqueued {
+		return nil, false, ErrMaxEnqueuedLimitReached
 	}
 
 	// enqueue tx
 	a.enqueued.push(tx)
 
+	return nil, false, nil
+}
+
+// checkReplacement returns an error if the account holds a transaction
+// with the same nonce as the given one, which can not be replaced by it.
+func (a *account) checkReplacement(tx *types.Transaction, priceBump uint64) error {
+	a.promoted.lock(false)
+	a.enqueued.lock(false)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	old := a.promoted.getByNonce(tx.Nonce)
+	if old == nil {
+		old = a.enqueued.getByNonce(tx.Nonce)
+	}
+
+	if old != nil && old.Hash != tx.Hash && !isReplacementPriced(old, tx, priceBump) {
+		return ErrReplacementUnderpriced
+	}
+
 	return nil
 }
 
@@ -327,3 +374,35 @@ func (a *account) getLowestTx() *types.Transaction {
 
 	return nil
 }
+
+// isReplacementPriced reports whether the given transaction pays at least
+// priceBump percent more than the old one, both in fee cap and in tip cap.
+func isReplacementPriced(old, tx *types.Transaction, priceBump uint64) bool {
+	oldFeeCap, oldTipCap := feeCaps(old)
+	feeCap, tipCap := feeCaps(tx)
+
+	return exceedsByBump(feeCap, oldFeeCap, priceBump) && exceedsByBump(tipCap, oldTipCap, priceBump)
+}
+
+// feeCaps returns the fee cap and the tip cap of the given transaction,
+// which are both equal to the gas price for the legacy transactions.
+func feeCaps(tx *types.Transaction) (*big.Int, *big.Int) {
+	if tx.GasFeeCap != nil && tx.GasTipCap != nil {
+		return tx.GasFeeCap, tx.GasTipCap
+	}
+
+	if tx.GasPrice != nil {
+		return tx.GasPrice, tx.GasPrice
+	}
+
+	return big.NewInt(0), big.NewInt(0)
+}
+
+// exceedsByBump reports whether the fee is higher than the old one
+// and at least by the bump percent.
+func exceedsByBump(fee, old *big.Int, bump uint64) bool {
+	threshold := new(big.Int).Mul(old, new(big.Int).SetUint64(100+bump))
+	threshold.Div(threshold, big.NewInt(100))
+
+	return fee.Cmp(old) > 0 && fee.Cmp(threshold) >= 0
+}
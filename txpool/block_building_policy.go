@@ -0,0 +1,204 @@
+package txpool
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// PricePolicy proposes the transactions with the highest effective tip first
+	PricePolicy = "price"
+
+	// FIFOPolicy proposes the transactions strictly in the order they arrived to the pool
+	FIFOPolicy = "fifo"
+
+	// FairPolicy proposes the transactions in rounds, taking at most one transaction
+	// per sender in each round (ordered by price within the round)
+	FairPolicy = "fair"
+
+	// ReservedGasPolicy proposes the transactions of the whitelisted senders first
+	// and keeps a part of the block gas limit reserved for them
+	ReservedGasPolicy = "reserved-gas"
+)
+
+// BlockBuildingPolicies is the list of all supported block building policies
+var BlockBuildingPolicies = []string{PricePolicy, FIFOPolicy, FairPolicy, ReservedGasPolicy}
+
+// ExecutableTx is a transaction ready for execution together with
+// the metadata used by the block building policies to order it
+type ExecutableTx struct {
+	*types.Transaction
+
+	// Arrival is the sequence number of the transaction in the order
+	// in which the transactions arrived to the pool
+	Arrival uint64
+
+	// Round is the number of transactions of the same sender
+	// that were already proposed while building the current block
+	Round uint64
+}
+
+// BlockBuildingPolicy decides in which order the executable transactions are
+// proposed to the block builder and whether they can be included in the block
+type BlockBuildingPolicy interface {
+	// Less reports whether the transaction a should be proposed before b
+	Less(a, b *ExecutableTx, baseFee uint64) bool
+
+	// Fits reports whether tx can be included in a block with the given gas limit,
+	// out of which gasUsed is already spent
+	Fits(tx *types.Transaction, gasUsed, gasLimit uint64) bool
+}
+
+// NewBlockBuildingPolicy creates the block building policy with the given name.
+// Reserved gas and the whitelisted senders are only used by the reserved gas policy.
+func NewBlockBuildingPolicy(
+	name string,
+	reservedGas uint64,
+	whitelist []types.Address,
+) (BlockBuildingPolicy, error) {
+	switch name {
+	case "", PricePolicy:
+		return &pricePolicy{}, nil
+	case FIFOPolicy:
+		return &fifoPolicy{}, nil
+	case FairPolicy:
+		return &fairPolicy{}, nil
+	case ReservedGasPolicy:
+		policy := &reservedGasPolicy{
+			reservedGas: reservedGas,
+			whitelist:   make(map[types.Address]struct{}, len(whitelist)),
+		}
+
+		for _, addr := range whitelist {
+			policy.whitelist[addr] = struct{}{}
+		}
+
+		return policy, nil
+	default:
+		return nil, fmt.Errorf("unknown block building policy '%s', supported policies: %v",
+			name, BlockBuildingPolicies)
+	}
+}
+
+// pricePolicy orders the transactions by their fees (descending)
+type pricePolicy struct{}
+
+func (p *pricePolicy) Less(a, b *ExecutableTx, baseFee uint64) bool {
+	if c := compareFees(a.Transaction, b.Transaction, baseFee); c != 0 {
+		return c > 0
+	}
+
+	if a.Nonce != b.Nonce {
+		return a.Nonce < b.Nonce
+	}
+
+	return a.Arrival < b.Arrival
+}
+
+func (p *pricePolicy) Fits(*types.Transaction, uint64, uint64) bool {
+	return true
+}
+
+// fifoPolicy orders the transactions by their arrival to the pool
+type fifoPolicy struct{}
+
+func (p *fifoPolicy) Less(a, b *ExecutableTx, _ uint64) bool {
+	return a.Arrival < b.Arrival
+}
+
+func (p *fifoPolicy) Fits(*types.Transaction, uint64, uint64) bool {
+	return true
+}
+
+// fairPolicy orders the transactions in a round robin manner per sender,
+// so that a single sender can not take over the entire block
+type fairPolicy struct {
+	pricePolicy
+}
+
+func (p *fairPolicy) Less(a, b *ExecutableTx, baseFee uint64) bool {
+	if a.Round != b.Round {
+		return a.Round < b.Round
+	}
+
+	return p.pricePolicy.Less(a, b, baseFee)
+}
+
+// reservedGasPolicy orders the transactions of the whitelisted senders first
+// and keeps the reserved gas of each block available only to them
+type reservedGasPolicy struct {
+	pricePolicy
+
+	reservedGas uint64
+	whitelist   map[types.Address]struct{}
+}
+
+func (p *reservedGasPolicy) Less(a, b *ExecutableTx, baseFee uint64) bool {
+	if aListed, bListed := p.isWhitelisted(a.From), p.isWhitelisted(b.From); aListed != bListed {
+		return aListed
+	}
+
+	return p.pricePolicy.Less(a, b, baseFee)
+}
+
+func (p *reservedGasPolicy) Fits(tx *types.Transaction, gasUsed, gasLimit uint64) bool {
+	if p.isWhitelisted(tx.From) {
+		return true
+	}
+
+	if p.reservedGas >= gasLimit {
+		return false
+	}
+
+	return gasUsed+tx.Gas <= gasLimit-p.reservedGas
+}
+
+func (p *reservedGasPolicy) isWhitelisted(addr types.Address) bool {
+	_, ok := p.whitelist[addr]
+
+	return ok
+}
+
+// compareFees compares the given transactions by their fees and returns:
+//   - 0 if they have same fees
+//   - 1 if a has higher fees than b
+//   - -1 if b has higher fees than a
+func compareFees(a, b *types.Transaction, baseFee uint64) int {
+	effectiveTipA := a.EffectiveTip(baseFee)
+	effectiveTipB := b.EffectiveTip(baseFee)
+
+	// Compare effective tips if baseFee is specified
+	if c := effectiveTipA.Cmp(effectiveTipB); c != 0 {
+		return c
+	}
+
+	aGasFeeCap, bGasFeeCap := new(big.Int), new(big.Int)
+
+	if a.GasFeeCap != nil {
+		aGasFeeCap = aGasFeeCap.Set(a.GasFeeCap)
+	}
+
+	if b.GasFeeCap != nil {
+		bGasFeeCap = bGasFeeCap.Set(b.GasFeeCap)
+	}
+
+	// Compare fee caps if baseFee is not specified or effective tips are equal
+	if c := aGasFeeCap.Cmp(bGasFeeCap); c != 0 {
+		return c
+	}
+
+	aGasTipCap, bGasTipCap := new(big.Int), new(big.Int)
+
+	if a.GasTipCap != nil {
+		aGasTipCap = aGasTipCap.Set(a.GasTipCap)
+	}
+
+	if b.GasTipCap != nil {
+		bGasTipCap = bGasTipCap.Set(b.GasTipCap)
+	}
+
+	// Compare tips if effective tips and fee caps are equal
+	return aGasTipCap.Cmp(bGasTipCap)
+}
@@ -82,6 +82,9 @@ func (em *eventManager) Close() {
 		subscription.close()
 	}
 
+	// the closed subscriptions can't be cancelled afterwards
+	em.subscriptions = make(map[subscriptionID]*eventSubscription)
+
 	atomic.StoreInt64(&em.numSubscriptions, 0)
 }
 
@@ -47,6 +47,11 @@ func TestEventManager_SubscribeCancel(t *testing.T) {
 			t.Fatalf("Subscription channel not closed for index %d", indx)
 		}
 	}
+
+	// Cancelling the closed subscriptions is a no-op
+	for _, subscription := range subscriptions {
+		em.cancelSubscription(subscription.subscriptionID)
+	}
 }
 
 func TestEventManager_SubscribeClose(t *testing.T) {
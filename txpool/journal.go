@@ -0,0 +1,232 @@
+package txpool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// journal is an append-only on-disk log of the locally submitted transactions,
+// which allows them to survive node restarts. Every record is the RLP encoding
+// of a transaction, prefixed with its length (4 bytes, big endian).
+type journal struct {
+	lock sync.Mutex
+
+	// path of the journal file
+	path string
+
+	// writer appending to the journal file (nil while the journal is not active)
+	writer io.WriteCloser
+
+	// hashes of the journaled transactions in the order of their submission
+	journaled []types.Hash
+	exists    map[types.Hash]struct{}
+}
+
+func newJournal(path string) *journal {
+	return &journal{
+		path:   path,
+		exists: make(map[types.Hash]struct{}),
+	}
+}
+
+// load reads the journal and passes every journaled transaction to the add callback,
+// which is expected to insert the accepted transactions back to the journal.
+// The transactions rejected by the callback (e.g. the ones whose nonces are already mined)
+// are dropped from the journal on the next rotation. Returns the number of the loaded
+// and the dropped transactions.
+func (j *journal) load(add func(*types.Transaction) error) (loaded, dropped int, err error) {
+	file, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, 0, nil
+	} else if err != nil {
+		return 0, 0, err
+	}
+
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	for {
+		tx, err := readJournalRecord(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			// the rest of the journal is unreadable (e.g. the node crashed while
+			// writing the last record), keep the transactions loaded so far
+			return loaded, dropped, err
+		}
+
+		if err := add(tx); err != nil {
+			dropped++
+
+			continue
+		}
+
+		loaded++
+	}
+
+	return loaded, dropped, nil
+}
+
+// insert appends the given transaction to the journal.
+// Transactions added while the journal is not active (i.e. while it is being loaded)
+// are written on the next rotation.
+func (j *journal) insert(tx *types.Transaction) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if _, ok := j.exists[tx.Hash]; ok {
+		return nil
+	}
+
+	j.journaled = append(j.journaled, tx.Hash)
+	j.exists[tx.Hash] = struct{}{}
+
+	if j.writer == nil {
+		return nil
+	}
+
+	return writeJournalRecord(j.writer, tx)
+}
+
+// rotate regenerates the journal with the journaled transactions that are still in the pool,
+// in the order of their submission. The get callback returns the transaction
+// with the given hash if it is still in the pool.
+func (j *journal) rotate(get func(types.Hash) (*types.Transaction, bool)) (int, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return 0, err
+		}
+
+		j.writer = nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return 0, err
+	}
+
+	// write the transactions to a new file, which replaces the journal afterwards
+	tmpPath := j.path + ".new"
+
+	replacement, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, err
+	}
+
+	writer := bufio.NewWriter(replacement)
+	journaled := make([]types.Hash, 0, len(j.journaled))
+	exists := make(map[types.Hash]struct{}, len(j.journaled))
+
+	for _, hash := range j.journaled {
+		tx, ok := get(hash)
+		if !ok {
+			continue
+		}
+
+		if err := writeJournalRecord(writer, tx); err != nil {
+			replacement.Close()
+
+			return 0, err
+		}
+
+		journaled = append(journaled, hash)
+		exists[hash] = struct{}{}
+	}
+
+	if err := writer.Flush(); err != nil {
+		replacement.Close()
+
+		return 0, err
+	}
+
+	if err := replacement.Close(); err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return 0, err
+	}
+
+	file, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return 0, err
+	}
+
+	j.writer = file
+	j.journaled = journaled
+	j.exists = exists
+
+	return len(journaled), nil
+}
+
+// close closes the journal file
+func (j *journal) close() error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.writer == nil {
+		return nil
+	}
+
+	err := j.writer.Close()
+	j.writer = nil
+
+	return err
+}
+
+// writeJournalRecord writes the length prefixed RLP encoding of the transaction
+func writeJournalRecord(w io.Writer, tx *types.Transaction) error {
+	raw := tx.MarshalRLP()
+
+	var length [4]byte
+
+	binary.BigEndian.PutUint32(length[:], uint32(len(raw)))
+
+	if _, err := w.Write(append(length[:], raw...)); err != nil {
+		return fmt.Errorf("failed to write journal record: %w", err)
+	}
+
+	return nil
+}
+
+// readJournalRecord reads the next transaction from the journal,
+// returns io.EOF if there are no more records
+func readJournalRecord(r io.Reader) (*types.Transaction, error) {
+	var length [4]byte
+
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("truncated journal record: %w", err)
+		}
+
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(length[:])
+	if size > txMaxSize {
+		return nil, fmt.Errorf("journal record too large: %d bytes", size)
+	}
+
+	raw := make([]byte, size)
+	if _, err := io.ReadFull(r, raw); err != nil {
+		return nil, fmt.Errorf("truncated journal record: %w", err)
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalRLP(raw); err != nil {
+		return nil, fmt.Errorf("invalid journal record: %w", err)
+	}
+
+	return tx, nil
+}
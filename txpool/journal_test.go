@@ -0,0 +1,209 @@
+package txpool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// nonceMockStore is a mock store returning the configured account nonces
+type nonceMockStore struct {
+	defaultMockStore
+
+	nonces map[types.Address]uint64
+}
+
+func (m nonceMockStore) GetNonce(_ types.Hash, addr types.Address) uint64 {
+	return m.nonces[addr]
+}
+
+func newJournalTxs(t *testing.T, count int) []*types.Transaction {
+	t.Helper()
+
+	txs := make([]*types.Transaction, count)
+
+	for i := range txs {
+		txs[i] = newTx(addr1, uint64(i), 1).ComputeHash()
+	}
+
+	return txs
+}
+
+func loadJournal(t *testing.T, j *journal) []*types.Transaction {
+	t.Helper()
+
+	var loaded []*types.Transaction
+
+	_, _, err := j.load(func(tx *types.Transaction) error {
+		loaded = append(loaded, tx)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return loaded
+}
+
+func TestJournal_InsertAndLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+	txs := newJournalTxs(t, 3)
+
+	j := newJournal(path)
+
+	// nothing to load yet
+	assert.Empty(t, loadJournal(t, j))
+
+	count, err := j.rotate(func(types.Hash) (*types.Transaction, bool) { return nil, false })
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	for _, tx := range txs {
+		require.NoError(t, j.insert(tx))
+	}
+
+	// inserting the same transaction twice is a no-op
+	require.NoError(t, j.insert(txs[0]))
+	require.NoError(t, j.close())
+
+	loaded := loadJournal(t, newJournal(path))
+	require.Len(t, loaded, len(txs))
+
+	for i, tx := range txs {
+		assert.Equal(t, tx.Hash, loaded[i].Hash)
+	}
+}
+
+func TestJournal_Rotate(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+	txs := newJournalTxs(t, 3)
+
+	j := newJournal(path)
+
+	for _, tx := range txs {
+		require.NoError(t, j.insert(tx))
+	}
+
+	// only the second transaction is still in the pool
+	count, err := j.rotate(func(hash types.Hash) (*types.Transaction, bool) {
+		if hash == txs[1].Hash {
+			return txs[1], true
+		}
+
+		return nil, false
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, j.close())
+
+	loaded := loadJournal(t, newJournal(path))
+	require.Len(t, loaded, 1)
+	assert.Equal(t, txs[1].Hash, loaded[0].Hash)
+}
+
+func TestJournal_LoadTruncated(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+	txs := newJournalTxs(t, 2)
+
+	j := newJournal(path)
+
+	_, err := j.rotate(func(types.Hash) (*types.Transaction, bool) { return nil, false })
+	require.NoError(t, err)
+
+	for _, tx := range txs {
+		require.NoError(t, j.insert(tx))
+	}
+
+	require.NoError(t, j.close())
+
+	// simulate a crash in the middle of writing a record
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+
+	_, err = file.Write([]byte{0, 0, 1, 0, 0xf8})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	loaded, dropped, err := newJournal(path).load(func(*types.Transaction) error { return nil })
+	assert.ErrorContains(t, err, "truncated journal record")
+	assert.Equal(t, len(txs), loaded)
+	assert.Equal(t, 0, dropped)
+}
+
+func TestTxPool_Journal(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path = filepath.Join(t.TempDir(), "transactions.rlp")
+
+		eoa1 = new(eoa).create(t)
+		eoa2 = new(eoa).create(t)
+
+		tx1  = eoa1.signTx(t, newTx(eoa1.Address, 0, 1), signerEIP155).ComputeHash()
+		tx2  = eoa2.signTx(t, newTx(eoa2.Address, 0, 1), signerEIP155).ComputeHash()
+		tx3  = eoa2.signTx(t, newTx(eoa2.Address, 1, 1), signerEIP155).ComputeHash()
+		txs  = []*types.Transaction{tx1, tx2, tx3}
+		head = &types.Header{GasLimit: mockHeader.GasLimit}
+	)
+
+	newPool := func(t *testing.T, nonces map[types.Address]uint64) *TxPool {
+		t.Helper()
+
+		pool, err := NewTxPool(
+			hclog.NewNullLogger(),
+			forks.At(0),
+			nonceMockStore{defaultMockStore: defaultMockStore{DefaultHeader: head}, nonces: nonces},
+			nil,
+			nil,
+			&Config{
+				PriceLimit:         defaultPriceLimit,
+				MaxSlots:           defaultMaxSlots,
+				MaxAccountEnqueued: defaultMaxAccountEnqueued,
+				Journal:            path,
+			},
+		)
+		require.NoError(t, err)
+
+		pool.SetSigner(signerEIP155)
+
+		return pool
+	}
+
+	pool := newPool(t, nil)
+	pool.Start()
+
+	for _, tx := range txs {
+		require.NoError(t, pool.AddTx(tx.Copy()))
+	}
+
+	pool.Close()
+
+	// the first tx of the second account is mined while the node is down
+	pool = newPool(t, map[types.Address]uint64{eoa2.Address: 1})
+	pool.Start()
+
+	defer pool.Close()
+
+	for _, tx := range txs {
+		_, exists := pool.index.get(tx.Hash)
+		assert.Equal(t, tx != tx2, exists)
+	}
+
+	// the mined tx is dropped from the journal
+	loaded := loadJournal(t, newJournal(path))
+	require.Len(t, loaded, 2)
+	assert.Equal(t, tx1.Hash, loaded[0].Hash)
+	assert.Equal(t, tx3.Hash, loaded[1].Hash)
+}
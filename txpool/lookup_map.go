@@ -10,6 +10,10 @@ import (
 type lookupMap struct {
 	sync.RWMutex
 	all map[types.Hash]*types.Transaction
+
+	// arrivals keeps the arrival sequence number of each transaction
+	arrivals    map[types.Hash]uint64
+	nextArrival uint64
 }
 
 // add inserts the given transaction into the map. Returns false
@@ -23,6 +27,8 @@ func (m *lookupMap) add(tx *types.Transaction) bool {
 	}
 
 	m.all[tx.Hash] = tx
+	m.arrivals[tx.Hash] = m.nextArrival
+	m.nextArrival++
 
 	return true
 }
@@ -34,6 +40,7 @@ func (m *lookupMap) remove(txs ...*types.Transaction) {
 
 	for _, tx := range txs {
 		delete(m.all, tx.Hash)
+		delete(m.arrivals, tx.Hash)
 	}
 }
 
@@ -49,3 +56,12 @@ func (m *lookupMap) get(hash types.Hash) (*types.Transaction, bool) {
 
 	return tx, true
 }
+
+// arrival returns the arrival sequence number of the transaction
+// associated with the given hash. [thread-safe]
+func (m *lookupMap) arrival(hash types.Hash) uint64 {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.arrivals[hash]
+}
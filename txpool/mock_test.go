@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 var mockHeader = &types.Header{
@@ -66,3 +68,11 @@ type mockSigner struct {
 func (s *mockSigner) Sender(tx *types.Transaction) (types.Address, error) {
 	return tx.From, nil
 }
+
+type mockPeerReporter struct {
+	penalties map[peer.ID]network.PeerPenalty
+}
+
+func (r *mockPeerReporter) ReportPeer(peerID peer.ID, penalty network.PeerPenalty, _ string) {
+	r.penalties[peerID] += penalty
+}
@@ -71,6 +71,40 @@ func (q *accountQueue) clear() (removed []*types.Transaction) {
 	return
 }
 
+// getByNonce returns the transaction with the given nonce
+// or nil if the queue holds no such transaction.
+func (q *accountQueue) getByNonce(nonce uint64) *types.Transaction {
+	for _, tx := range q.queue {
+		if tx.Nonce == nonce {
+			return tx
+		}
+	}
+
+	return nil
+}
+
+// replace replaces the transaction having the same nonce as the given one,
+// if the given transaction pays at least priceBump percent more. It returns
+// the replaced transaction or nil if there is no transaction with the same nonce.
+func (q *accountQueue) replace(tx *types.Transaction, priceBump uint64) (*types.Transaction, error) {
+	for i, old := range q.queue {
+		if old.Nonce != tx.Nonce {
+			continue
+		}
+
+		if !isReplacementPriced(old, tx, priceBump) {
+			return nil, ErrReplacementUnderpriced
+		}
+
+		q.queue[i] = tx
+		heap.Fix(&q.queue, i)
+
+		return old, nil
+	}
+
+	return nil, nil
+}
+
 // push pushes the given transactions onto the queue.
 func (q *accountQueue) push(tx *types.Transaction) {
 	heap.Push(&q.queue, tx)
@@ -0,0 +1,109 @@
+package txpool
+
+import (
+	"container/heap"
+	"sync/atomic"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// executablesQueue is the queue of the primaries,
+// ordered by the configured block building policy
+type executablesQueue struct {
+	queue *policyQueue
+
+	// rounds is the number of transactions popped per sender
+	rounds map[types.Address]uint64
+}
+
+func newExecutablesQueue(policy BlockBuildingPolicy) *executablesQueue {
+	q := executablesQueue{
+		queue:  &policyQueue{policy: policy},
+		rounds: make(map[types.Address]uint64),
+	}
+
+	heap.Init(q.queue)
+
+	return &q
+}
+
+// clear empties the underlying queue.
+func (q *executablesQueue) clear() {
+	q.queue.txs = q.queue.txs[:0]
+	q.rounds = make(map[types.Address]uint64)
+}
+
+// Pushes the given transaction onto the queue.
+func (q *executablesQueue) push(tx *types.Transaction, arrival uint64) {
+	heap.Push(q.queue, &ExecutableTx{
+		Transaction: tx,
+		Arrival:     arrival,
+		Round:       q.rounds[tx.From],
+	})
+}
+
+// Pop removes the first transaction from the queue
+// or nil if the queue is empty.
+func (q *executablesQueue) pop() *types.Transaction {
+	if q.length() == 0 {
+		return nil
+	}
+
+	executable, ok := heap.Pop(q.queue).(*ExecutableTx)
+	if !ok {
+		return nil
+	}
+
+	q.rounds[executable.From]++
+
+	return executable.Transaction
+}
+
+// length returns the number of transactions in the queue.
+func (q *executablesQueue) length() uint64 {
+	return uint64(q.queue.Len())
+}
+
+// setBaseFee sets the base fee used for sorting the transactions by price
+func (q *executablesQueue) setBaseFee(baseFee uint64) {
+	atomic.StoreUint64(&q.queue.baseFee, baseFee)
+}
+
+// transactions sorted by the block building policy
+type policyQueue struct {
+	policy  BlockBuildingPolicy
+	baseFee uint64
+	txs     []*ExecutableTx
+}
+
+/* Queue methods required by the heap interface */
+
+func (q *policyQueue) Len() int {
+	return len(q.txs)
+}
+
+func (q *policyQueue) Swap(i, j int) {
+	q.txs[i], q.txs[j] = q.txs[j], q.txs[i]
+}
+
+func (q *policyQueue) Less(i, j int) bool {
+	return q.policy.Less(q.txs[i], q.txs[j], atomic.LoadUint64(&q.baseFee))
+}
+
+func (q *policyQueue) Push(x interface{}) {
+	transaction, ok := x.(*ExecutableTx)
+	if !ok {
+		return
+	}
+
+	q.txs = append(q.txs, transaction)
+}
+
+func (q *policyQueue) Pop() interface{} {
+	old := q.txs
+	n := len(old)
+	x := old[n-1]
+	q.txs = old[0 : n-1]
+
+	return x
+}
@@ -3,15 +3,15 @@ package txpool
 import (
 	"math/big"
 	"math/rand"
-	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
-func Test_maxPriceQueue(t *testing.T) {
+func Test_executablesQueue_PricePolicy(t *testing.T) {
 	t.Parallel()
 
 	testTable := []struct {
@@ -232,22 +232,125 @@ func Test_maxPriceQueue(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			queue := &maxPriceQueue{
-				baseFee: tt.baseFee,
-				txs:     tt.unsorted,
-			}
+			queue := newExecutablesQueue(&pricePolicy{})
+			queue.setBaseFee(tt.baseFee)
 
-			sort.Sort(queue)
+			for i, tx := range tt.unsorted {
+				queue.push(tx, uint64(i))
+			}
 
 			for _, tx := range tt.sorted {
-				actual := queue.Pop()
+				actual := queue.pop()
 				assert.Equal(t, tx, actual)
 			}
+
+			assert.Nil(t, queue.pop())
 		})
 	}
 }
 
-func Benchmark_pricedQueue(t *testing.B) {
+func Test_executablesQueue_FIFOPolicy(t *testing.T) {
+	t.Parallel()
+
+	queue := newExecutablesQueue(&fifoPolicy{})
+
+	txs := []*types.Transaction{
+		{From: addr1, GasPrice: big.NewInt(100)},
+		{From: addr2, GasPrice: big.NewInt(300)},
+		{From: addr3, GasPrice: big.NewInt(200)},
+	}
+
+	// push in reverse order, arrival defines the order
+	for i := len(txs) - 1; i >= 0; i-- {
+		queue.push(txs[i], uint64(i))
+	}
+
+	for _, tx := range txs {
+		assert.Equal(t, tx, queue.pop())
+	}
+}
+
+func Test_executablesQueue_FairPolicy(t *testing.T) {
+	t.Parallel()
+
+	queue := newExecutablesQueue(&fairPolicy{})
+
+	// addr1 pays the most, but only gets one tx per round
+	addr1Txs := []*types.Transaction{
+		{From: addr1, Nonce: 0, GasPrice: big.NewInt(1000)},
+		{From: addr1, Nonce: 1, GasPrice: big.NewInt(1000)},
+	}
+	addr2Tx := &types.Transaction{From: addr2, GasPrice: big.NewInt(200)}
+	addr3Tx := &types.Transaction{From: addr3, GasPrice: big.NewInt(100)}
+
+	queue.push(addr1Txs[0], 0)
+	queue.push(addr2Tx, 1)
+	queue.push(addr3Tx, 2)
+
+	assert.Equal(t, addr1Txs[0], queue.pop())
+
+	// the next primary of addr1 is pushed after the previous one got popped
+	queue.push(addr1Txs[1], 3)
+
+	assert.Equal(t, addr2Tx, queue.pop())
+	assert.Equal(t, addr3Tx, queue.pop())
+	assert.Equal(t, addr1Txs[1], queue.pop())
+
+	// rounds are reset once the queue is cleared
+	queue.clear()
+	queue.push(addr1Txs[1], 3)
+	queue.push(addr2Tx, 1)
+
+	assert.Equal(t, addr1Txs[1], queue.pop())
+}
+
+func Test_executablesQueue_ReservedGasPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := NewBlockBuildingPolicy(ReservedGasPolicy, 1000, []types.Address{addr2})
+	require.NoError(t, err)
+
+	queue := newExecutablesQueue(policy)
+
+	whitelistedTx := &types.Transaction{From: addr2, GasPrice: big.NewInt(1), Gas: 500}
+	regularTx := &types.Transaction{From: addr1, GasPrice: big.NewInt(1000), Gas: 500}
+
+	queue.push(regularTx, 0)
+	queue.push(whitelistedTx, 1)
+
+	assert.Equal(t, whitelistedTx, queue.pop())
+	assert.Equal(t, regularTx, queue.pop())
+
+	// regular transactions can not spend the reserved gas
+	assert.True(t, policy.Fits(regularTx, 0, 2000))
+	assert.True(t, policy.Fits(regularTx, 500, 2000))
+	assert.False(t, policy.Fits(regularTx, 501, 2000))
+	assert.False(t, policy.Fits(regularTx, 0, 1000))
+
+	// whitelisted transactions can
+	assert.True(t, policy.Fits(whitelistedTx, 1500, 2000))
+}
+
+func TestNewBlockBuildingPolicy(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]BlockBuildingPolicy{
+		"":                &pricePolicy{},
+		PricePolicy:       &pricePolicy{},
+		FIFOPolicy:        &fifoPolicy{},
+		FairPolicy:        &fairPolicy{},
+		ReservedGasPolicy: &reservedGasPolicy{whitelist: map[types.Address]struct{}{}},
+	} {
+		policy, err := NewBlockBuildingPolicy(name, 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, expected, policy)
+	}
+
+	_, err := NewBlockBuildingPolicy("unknown", 0, nil)
+	assert.ErrorContains(t, err, "unknown block building policy")
+}
+
+func Benchmark_executablesQueue(t *testing.B) {
 	testTable := []struct {
 		name        string
 		unsortedTxs []*types.Transaction
@@ -269,11 +372,11 @@ func Benchmark_pricedQueue(t *testing.B) {
 	for _, tt := range testTable {
 		t.Run(tt.name, func(b *testing.B) {
 			for i := 0; i < t.N; i++ {
-				q := newPricedQueue()
-				q.queue.baseFee = uint64(i)
+				q := newExecutablesQueue(&pricePolicy{})
+				q.setBaseFee(uint64(i))
 
-				for _, tx := range tt.unsortedTxs {
-					q.push(tx)
+				for j, tx := range tt.unsortedTxs {
+					q.push(tx, uint64(j))
 				}
 
 				for q.length() > 0 {
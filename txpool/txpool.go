@@ -35,6 +35,9 @@ const (
 
 	pruningCooldown = 5000 * time.Millisecond
 
+	// defaultJournalRotation is the default interval of the journal regeneration
+	defaultJournalRotation = time.Hour
+
 	// txPoolMetrics is a prefix used for txpool-related metrics
 	txPoolMetrics = "txpool"
 )
@@ -52,6 +55,7 @@ var (
 	ErrInsufficientFunds       = errors.New("insufficient funds for gas * price + value")
 	ErrInvalidAccountState     = errors.New("invalid account state")
 	ErrAlreadyKnown            = errors.New("already known")
+	ErrReplacementUnderpriced  = errors.New("replacement transaction underpriced")
 	ErrOversizedData           = errors.New("oversized data")
 	ErrMaxEnqueuedLimitReached = errors.New("maximum number of enqueued transactions reached")
 	ErrRejectFutureTx          = errors.New("rejected future tx due to low slots")
@@ -92,10 +96,30 @@ type signer interface {
 	Sender(tx *types.Transaction) (types.Address, error)
 }
 
+// peerReporter penalizes the peers gossiping invalid transactions
+type peerReporter interface {
+	ReportPeer(peerID peer.ID, penalty network.PeerPenalty, reason string)
+}
+
 type Config struct {
 	PriceLimit         uint64
 	MaxSlots           uint64
 	MaxAccountEnqueued uint64
+
+	// PriceBump is the minimum fee increase (in percent) required
+	// to replace a transaction with the same nonce
+	PriceBump uint64
+
+	// Journal is the path of the journal persisting the local transactions
+	// across restarts, the journal is disabled if empty
+	Journal string
+
+	// JournalRotation is the interval of the journal regeneration
+	JournalRotation time.Duration
+
+	// BlockBuildingPolicy orders the executable transactions,
+	// the price policy is used if not set
+	BlockBuildingPolicy BlockBuildingPolicy
 }
 
 /* All requests are passed to the main loop
@@ -144,15 +168,19 @@ type TxPool struct {
 	// map of all accounts registered by the pool
 	accounts accountsMap
 
-	// all the primaries sorted by max gas price
-	executables *pricedQueue
+	// block building policy ordering the primaries
+	policy BlockBuildingPolicy
+
+	// all the primaries sorted by the block building policy
+	executables *executablesQueue
 
 	// lookup map keeping track of all
 	// transactions present in the pool
 	index lookupMap
 
 	// networking stack
-	topic *network.Topic
+	topic    *network.Topic
+	reporter peerReporter
 
 	// gauge for measuring pool capacity
 	gauge slotGauge
@@ -160,6 +188,10 @@ type TxPool struct {
 	// priceLimit is a lower threshold for gas price
 	priceLimit uint64
 
+	// priceBump is the minimum fee increase (in percent)
+	// required to replace a transaction
+	priceBump uint64
+
 	// channels on which the pool's event loop
 	// does dispatching/handling requests.
 	enqueueReqCh chan enqueueRequest
@@ -169,6 +201,12 @@ type TxPool struct {
 	// shutdown channel
 	shutdownCh chan struct{}
 
+	// journal of the local transactions (nil if disabled)
+	journal         *journal
+	journalRotation time.Duration
+	journalCloseCh  chan struct{}
+	journalDoneCh   chan struct{}
+
 	// flag indicating if the current node is a sealer,
 	// and should therefore gossip transactions
 	sealing atomic.Bool
@@ -197,15 +235,27 @@ func NewTxPool(
 	network *network.Server,
 	config *Config,
 ) (*TxPool, error) {
+	policy := config.BlockBuildingPolicy
+	if policy == nil {
+		policy = &pricePolicy{}
+	}
+
 	pool := &TxPool{
 		logger:      logger.Named("txpool"),
 		forks:       forks,
 		store:       store,
-		executables: newPricedQueue(),
+		policy:      policy,
+		executables: newExecutablesQueue(policy),
 		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
-		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
-		gauge:       slotGauge{height: 0, max: config.MaxSlots},
-		priceLimit:  config.PriceLimit,
+		index: lookupMap{
+			all:      make(map[types.Hash]*types.Transaction),
+			arrivals: make(map[types.Hash]uint64),
+		},
+		gauge:      slotGauge{height: 0, max: config.MaxSlots},
+		priceLimit: config.PriceLimit,
+		priceBump:  config.PriceBump,
+
+		journalRotation: config.JournalRotation,
 
 		//	main loop channels
 		enqueueReqCh: make(chan enqueueRequest),
@@ -229,12 +279,23 @@ func NewTxPool(
 		}
 
 		pool.topic = topic
+		pool.reporter = network
 	}
 
 	if grpcServer != nil {
 		proto.RegisterTxnPoolOperatorServer(grpcServer, pool)
 	}
 
+	if config.Journal != "" {
+		pool.journal = newJournal(config.Journal)
+		pool.journalCloseCh = make(chan struct{})
+		pool.journalDoneCh = make(chan struct{})
+
+		if pool.journalRotation == 0 {
+			pool.journalRotation = defaultJournalRotation
+		}
+	}
+
 	return pool, nil
 }
 
@@ -279,14 +340,94 @@ func (p *TxPool) Start() {
 			}
 		}
 	}()
+
+	if p.journal != nil {
+		// replay the local transactions once the pipeline is running
+		p.loadJournal()
+
+		go p.runJournalRotation()
+	}
 }
 
 // Close shuts down the pool's main loop.
 func (p *TxPool) Close() {
+	if p.journal != nil {
+		close(p.journalCloseCh)
+		<-p.journalDoneCh
+	}
+
 	p.eventManager.Close()
 	p.shutdownCh <- struct{}{}
 }
 
+// SubscribeTxEvents subscribes for the tx pool events of the given types.
+// The subscription is stopped with the returned cancel function
+func (p *TxPool) SubscribeTxEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	subscription := p.eventManager.subscribe(eventTypes)
+
+	return subscription.subscriptionChannel, func() {
+		p.eventManager.cancelSubscription(subscription.subscriptionID)
+	}
+}
+
+// loadJournal adds the journaled local transactions to the pool
+// and regenerates the journal afterwards. Transactions whose nonces
+// are already mined are rejected by the pool and dropped from the journal.
+func (p *TxPool) loadJournal() {
+	loaded, dropped, err := p.journal.load(func(tx *types.Transaction) error {
+		if err := p.addTx(local, tx); err != nil {
+			p.logger.Debug("dropping journaled tx", "hash", tx.Hash.String(), "err", err)
+
+			return err
+		}
+
+		p.publish(tx)
+
+		return nil
+	})
+	if err != nil {
+		p.logger.Warn("failed to load the transactions journal", "path", p.journal.path, "err", err)
+	}
+
+	p.logger.Info("loaded local transactions from the journal", "loaded", loaded, "dropped", dropped)
+
+	p.rotateJournal()
+}
+
+// runJournalRotation periodically regenerates the journal
+// until the pool is closed.
+func (p *TxPool) runJournalRotation() {
+	defer close(p.journalDoneCh)
+
+	ticker := time.NewTicker(p.journalRotation)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.journalCloseCh:
+			if err := p.journal.close(); err != nil {
+				p.logger.Error("failed to close the transactions journal", "err", err)
+			}
+
+			return
+		case <-ticker.C:
+			p.rotateJournal()
+		}
+	}
+}
+
+// rotateJournal regenerates the journal with the local transactions still in the pool
+func (p *TxPool) rotateJournal() {
+	count, err := p.journal.rotate(p.index.get)
+	if err != nil {
+		p.logger.Error("failed to rotate the transactions journal", "err", err)
+
+		return
+	}
+
+	p.logger.Debug("rotated the transactions journal", "transactions", count)
+}
+
 // SetSigner sets the signer the pool will use
 // to validate a transaction's signature.
 func (p *TxPool) SetSigner(s signer) {
@@ -307,21 +448,27 @@ func (p *TxPool) AddTx(tx *types.Transaction) error {
 		return err
 	}
 
-	// broadcast the transaction only if a topic
-	// subscription is present
-	if p.topic != nil {
-		tx := &proto.Txn{
-			Raw: &any.Any{
-				Value: tx.MarshalRLP(),
-			},
-		}
+	p.publish(tx)
 
-		if err := p.topic.Publish(tx); err != nil {
-			p.logger.Error("failed to topic tx", "err", err)
-		}
+	return nil
+}
+
+// publish broadcasts the transaction only if a topic
+// subscription is present
+func (p *TxPool) publish(tx *types.Transaction) {
+	if p.topic == nil {
+		return
 	}
 
-	return nil
+	raw := &proto.Txn{
+		Raw: &any.Any{
+			Value: tx.MarshalRLP(),
+		},
+	}
+
+	if err := p.topic.Publish(raw); err != nil {
+		p.logger.Error("failed to topic tx", "err", err)
+	}
 }
 
 // Prepare generates all the transactions
@@ -340,19 +487,19 @@ func (p *TxPool) Prepare(baseFee uint64) {
 
 	// push primaries to the executables queue
 	for _, tx := range primaries {
-		p.executables.push(tx)
+		p.executables.push(tx, p.index.arrival(tx.Hash))
 	}
 }
 
-// Peek returns the best-price selected
-// transaction ready for execution.
+// Peek returns the transaction ready for execution
+// selected by the block building policy.
 func (p *TxPool) Peek() *types.Transaction {
 	// Popping the executables queue
 	// does not remove the actual tx
 	// from the pool.
 	// The executables queue just provides
-	// insight into which account has the
-	// highest priced tx (head of promoted queue)
+	// insight into which account's tx
+	// (head of promoted queue) is next in line
 	return p.executables.pop()
 }
 
@@ -367,8 +514,13 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 	account.promoted.lock(true)
 	defer account.promoted.unlock()
 
-	// pop the top most promoted tx
-	account.promoted.pop()
+	// pop the top most promoted tx, which is a replacement of the given tx
+	// if the replacement arrived while the block was being built
+	if popped := account.promoted.pop(); popped != nil && popped.Hash != tx.Hash {
+		p.index.remove(popped)
+
+		tx = popped
+	}
 
 	// successfully popping an account resets its demotions count to 0
 	account.resetDemotions()
@@ -381,10 +533,17 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 
 	// update executables
 	if tx := account.promoted.peek(); tx != nil {
-		p.executables.push(tx)
+		p.executables.push(tx, p.index.arrival(tx.Hash))
 	}
 }
 
+// Fits reports whether the block building policy allows the given
+// transaction to be included in a block with the given gas limit,
+// out of which gasUsed is already spent.
+func (p *TxPool) Fits(tx *types.Transaction, gasUsed, gasLimit uint64) bool {
+	return p.policy.Fits(tx, gasUsed, gasLimit)
+}
+
 // Drop clears the entire account associated with the given transaction
 // and reverts its next (expected) nonce.
 func (p *TxPool) Drop(tx *types.Transaction) {
@@ -564,10 +723,15 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	}
 
 	// Check if transaction can deploy smart contract
-	if tx.IsContractCreation() && p.forks.EIP158 && len(tx.Input) > state.TxPoolMaxInitCodeSize {
+	if tx.IsContractCreation() && p.forks.EIP158 && len(tx.Input) > runtime.MaxInitCodeSize {
 		return runtime.ErrMaxCodeSizeExceeded
 	}
 
+	// Reject access list tx if berlin hardfork is not enabled
+	if tx.Type == types.AccessListTx && !p.forks.Berlin {
+		return ErrInvalidTxType
+	}
+
 	if tx.Type == types.DynamicFeeTx {
 		// Reject dynamic fee tx if london hardfork is not enabled
 		if !p.forks.London {
@@ -621,7 +785,7 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	}
 
 	// Make sure the transaction has more gas than the basic transaction fee
-	intrinsicGas, err := state.TransactionGasCost(tx, p.forks.Homestead, p.forks.Istanbul)
+	intrinsicGas, err := state.TransactionGasCost(tx, p.forks.Homestead, p.forks.Istanbul, p.forks.Shanghai)
 	if err != nil {
 		return err
 	}
@@ -707,6 +871,13 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 
 	tx.ComputeHash()
 
+	// reject underpriced replacements
+	if account := p.accounts.get(tx.From); account != nil {
+		if err := account.checkReplacement(tx, p.priceBump); err != nil {
+			return err
+		}
+	}
+
 	// add to index
 	if ok := p.index.add(tx); !ok {
 		return ErrAlreadyKnown
@@ -719,6 +890,13 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	p.enqueueReqCh <- enqueueRequest{tx: tx}
 	p.eventManager.signalEvent(proto.EventType_ADDED, tx.Hash)
 
+	// persist the local transactions, so they survive node restarts
+	if origin == local && p.journal != nil {
+		if err := p.journal.insert(tx); err != nil {
+			p.logger.Error("failed to journal tx", "hash", tx.Hash.String(), "err", err)
+		}
+	}
+
 	return nil
 }
 
@@ -734,7 +912,8 @@ func (p *TxPool) handleEnqueueRequest(req enqueueRequest) {
 	account := p.accounts.get(addr)
 
 	// enqueue tx
-	if err := account.enqueue(tx); err != nil {
+	replaced, promoted, err := account.enqueue(tx, p.priceBump)
+	if err != nil {
 		p.logger.Error("enqueue request", "err", err)
 
 		p.index.remove(tx)
@@ -742,10 +921,26 @@ func (p *TxPool) handleEnqueueRequest(req enqueueRequest) {
 		return
 	}
 
+	if replaced != nil {
+		p.logger.Debug("replaced tx", "hash", replaced.Hash.String(), "replacement", tx.Hash.String())
+
+		p.index.remove(replaced)
+		p.gauge.decrease(slotsRequired(replaced))
+
+		p.eventManager.signalEvent(proto.EventType_REPLACED, replaced.Hash)
+	}
+
 	p.logger.Debug("enqueue request", "hash", tx.Hash.String())
 
 	p.gauge.increase(slotsRequired(tx))
 
+	if promoted {
+		// the replacement took the place of the promoted tx
+		p.eventManager.signalEvent(proto.EventType_PROMOTED, tx.Hash)
+
+		return
+	}
+
 	p.eventManager.signalEvent(proto.EventType_ENQUEUED, tx.Hash)
 
 	if tx.Nonce > account.getNonce() {
@@ -779,7 +974,7 @@ func (p *TxPool) handlePromoteRequest(req promoteRequest) {
 
 // addGossipTx handles receiving transactions
 // gossiped by the network.
-func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
+func (p *TxPool) addGossipTx(obj interface{}, from peer.ID) {
 	if !p.sealing.Load() {
 		return
 	}
@@ -794,6 +989,7 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 	// Verify that the gossiped transaction message is not empty
 	if raw == nil || raw.Raw == nil {
 		p.logger.Error("malformed gossip transaction message received")
+		p.reportPeer(from, network.PenaltyMalformedMessage, "malformed gossip transaction message")
 
 		return
 	}
@@ -803,6 +999,7 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 	// decode tx
 	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
 		p.logger.Error("failed to decode broadcast tx", "err", err)
+		p.reportPeer(from, network.PenaltyMalformedMessage, "undecodable gossip transaction")
 
 		return
 	}
@@ -816,7 +1013,42 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 		}
 
 		p.logger.Error("failed to add broadcast tx", "err", err, "hash", tx.Hash.String())
+
+		if isInvalidTxErr(err) {
+			p.reportPeer(from, network.PenaltyInvalidTx, err.Error())
+		}
+	}
+}
+
+// reportPeer penalizes the peer for gossiping an invalid transaction
+func (p *TxPool) reportPeer(from peer.ID, penalty network.PeerPenalty, reason string) {
+	if p.reporter == nil {
+		return
+	}
+
+	p.reporter.ReportPeer(from, penalty, reason)
+}
+
+// isInvalidTxErr checks if the transaction was rejected for being invalid regardless of the pool
+// and chain state, as opposed to e.g. a low nonce or a full pool, which honest peers can't foresee
+func isInvalidTxErr(err error) bool {
+	for _, invalidErr := range []error{
+		ErrNegativeValue,
+		ErrExtractSignature,
+		ErrInvalidSender,
+		ErrIntrinsicGas,
+		ErrOversizedData,
+		ErrInvalidTxType,
+		ErrTipAboveFeeCap,
+		ErrTipVeryHigh,
+		ErrFeeCapVeryHigh,
+	} {
+		if errors.Is(err, invalidErr) {
+			return true
+		}
 	}
+
+	return false
 }
 
 // resetAccounts updates existing accounts with the new nonce and prunes stale transactions.
@@ -937,7 +1169,7 @@ func (p *TxPool) Length() uint64 {
 // updateBaseFee updates base fee in the tx pool and priced queue
 func (p *TxPool) updateBaseFee(baseFee uint64) {
 	atomic.StoreUint64(&p.baseFee, baseFee)
-	atomic.StoreUint64(&p.executables.queue.baseFee, baseFee)
+	p.executables.setBaseFee(baseFee)
 }
 
 // toHash returns the hash(es) of given transaction(s)
@@ -13,12 +13,14 @@ import (
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -35,6 +37,7 @@ var (
 		chain.Homestead: chain.NewFork(0),
 		chain.Istanbul:  chain.NewFork(0),
 		chain.London:    chain.NewFork(0),
+		chain.Berlin:    chain.NewFork(0),
 	})
 )
 
@@ -605,6 +608,65 @@ func TestAddGossipTx(t *testing.T) {
 	})
 }
 
+func TestAddGossipTx_ReportPeer(t *testing.T) {
+	t.Parallel()
+
+	const from = peer.ID("peer")
+
+	encodeTx := func(mutate func(tx *types.Transaction)) *proto.Txn {
+		tx := newTx(types.ZeroAddress, 0, 1)
+		mutate(tx)
+
+		return &proto.Txn{Raw: &any.Any{Value: tx.MarshalRLP()}}
+	}
+
+	testTable := []struct {
+		name    string
+		msg     *proto.Txn
+		penalty network.PeerPenalty
+	}{
+		{
+			name:    "malformed message",
+			msg:     &proto.Txn{},
+			penalty: network.PenaltyMalformedMessage,
+		},
+		{
+			name:    "undecodable transaction",
+			msg:     &proto.Txn{Raw: &any.Any{Value: []byte{0x1, 0x2}}},
+			penalty: network.PenaltyMalformedMessage,
+		},
+		{
+			name:    "invalid transaction",
+			msg:     encodeTx(func(tx *types.Transaction) { tx.Gas = 1 }),
+			penalty: network.PenaltyInvalidTx,
+		},
+		{
+			name: "underpriced transaction",
+			msg:  encodeTx(func(tx *types.Transaction) { tx.GasPrice = big.NewInt(0) }),
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool, err := newTestPool()
+			require.NoError(t, err)
+			pool.SetSigner(&mockSigner{})
+			pool.SetSealing(true)
+
+			reporter := &mockPeerReporter{penalties: make(map[peer.ID]network.PeerPenalty)}
+			pool.reporter = reporter
+
+			pool.addGossipTx(testCase.msg, from)
+
+			assert.Equal(t, testCase.penalty, reporter.penalties[from])
+		})
+	}
+}
+
 func TestDropKnownGossipTx(t *testing.T) {
 	t.Parallel()
 
@@ -898,7 +960,7 @@ func TestPromoteHandler(t *testing.T) {
 	})
 
 	t.Run(
-		"promote handler discards cheaper tx",
+		"enqueue handler replaces cheaper tx",
 		func(t *testing.T) {
 			t.Parallel()
 
@@ -964,16 +1026,19 @@ func TestPromoteHandler(t *testing.T) {
 			promReq1 := handleEnqueueRequest(enqTx1)
 			promReq2 := handleEnqueueRequest(enqTx2)
 
+			// the second Tx replaces the first one
 			assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
-			assert.Equal(t, uint64(2), pool.accounts.get(addr1).enqueued.length())
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
 			assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+			assertTxExists(t, tx1, false)
+			assertTxExists(t, tx2, true)
 			assert.Equal(
 				t,
-				slotsRequired(tx1)+slotsRequired(tx2),
+				slotsRequired(tx2),
 				pool.gauge.read(),
 			)
 
-			// promote the second Tx and remove the first Tx
+			// promote the second Tx
 			pool.handlePromoteRequest(promReq1)
 
 			assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
@@ -1546,6 +1611,195 @@ func TestDemote(t *testing.T) {
 	})
 }
 
+func TestReplaceTx(t *testing.T) {
+	t.Parallel()
+
+	const priceBump = 10
+
+	newPricedTx := func(nonce, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr1, nonce, 1)
+		tx.GasPrice.SetUint64(gasPrice)
+
+		return tx
+	}
+
+	newPool := func(t *testing.T) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+
+		pool.SetSigner(&mockSigner{})
+		pool.priceBump = priceBump
+
+		return pool
+	}
+
+	// enqueueTx adds the tx and handles its enqueue request
+	enqueueTx := func(t *testing.T, pool *TxPool, tx *types.Transaction) {
+		t.Helper()
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, tx))
+		}()
+
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	}
+
+	// promoteTx adds the tx with the expected nonce and promotes it
+	promoteTx := func(t *testing.T, pool *TxPool, tx *types.Transaction) {
+		t.Helper()
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, tx))
+		}()
+
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+	}
+
+	assertTxExists := func(t *testing.T, pool *TxPool, tx *types.Transaction, shouldExist bool) {
+		t.Helper()
+
+		_, exists := pool.index.get(tx.Hash)
+		assert.Equal(t, shouldExist, exists)
+	}
+
+	t.Run("replace enqueued tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newPool(t)
+		subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_REPLACED})
+
+		defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+		tx1, tx2 := newPricedTx(5, 100), newPricedTx(5, 110)
+
+		enqueueTx(t, pool, tx1)
+		enqueueTx(t, pool, tx2)
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancelFn()
+
+		events := waitForEvents(ctx, subscription, 1)
+		require.Len(t, events, 1)
+		assert.Equal(t, tx1.Hash.String(), events[0].TxHash)
+
+		account := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(1), account.enqueued.length())
+		assert.Equal(t, tx2, account.enqueued.peek())
+		assert.Equal(t, slotsRequired(tx2), pool.gauge.read())
+
+		assertTxExists(t, pool, tx1, false)
+		assertTxExists(t, pool, tx2, true)
+	})
+
+	t.Run("reject underpriced replacement", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newPool(t)
+
+		tx1 := newPricedTx(5, 100)
+		enqueueTx(t, pool, tx1)
+
+		assert.ErrorIs(t, pool.addTx(local, newPricedTx(5, 109)), ErrReplacementUnderpriced)
+		assert.ErrorIs(t, pool.addTx(local, newPricedTx(5, 100)), ErrReplacementUnderpriced)
+
+		assert.Equal(t, tx1, pool.accounts.get(addr1).enqueued.peek())
+	})
+
+	t.Run("replace promoted tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newPool(t)
+
+		tx1, tx2 := newPricedTx(0, 100), newPricedTx(0, 200)
+
+		promoteTx(t, pool, tx1)
+
+		enqueueTx(t, pool, tx2)
+
+		account := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(1), account.getNonce())
+		assert.Equal(t, uint64(0), account.enqueued.length())
+		assert.Equal(t, uint64(1), account.promoted.length())
+		assert.Equal(t, tx2, account.promoted.peek())
+		assert.Equal(t, slotsRequired(tx2), pool.gauge.read())
+
+		assertTxExists(t, pool, tx1, false)
+		assertTxExists(t, pool, tx2, true)
+
+		pool.Prepare(0)
+		assert.Equal(t, tx2, pool.Peek())
+	})
+
+	t.Run("pop promoted tx replaced during block building", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newPool(t)
+
+		tx1, tx2 := newPricedTx(0, 100), newPricedTx(0, 200)
+
+		promoteTx(t, pool, tx1)
+
+		pool.Prepare(0)
+		peeked := pool.Peek()
+		require.Equal(t, tx1, peeked)
+
+		enqueueTx(t, pool, tx2)
+
+		pool.Pop(peeked)
+
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+		assert.Equal(t, uint64(0), pool.gauge.read())
+
+		assertTxExists(t, pool, tx1, false)
+		assertTxExists(t, pool, tx2, false)
+	})
+}
+
+func Test_isReplacementPriced(t *testing.T) {
+	t.Parallel()
+
+	dynamicTx := func(feeCap, tipCap int64) *types.Transaction {
+		return &types.Transaction{
+			Type:      types.DynamicFeeTx,
+			GasFeeCap: big.NewInt(feeCap),
+			GasTipCap: big.NewInt(tipCap),
+		}
+	}
+
+	legacyTx := func(gasPrice int64) *types.Transaction {
+		return &types.Transaction{GasPrice: big.NewInt(gasPrice)}
+	}
+
+	cases := []struct {
+		name      string
+		old, tx   *types.Transaction
+		priceBump uint64
+		expected  bool
+	}{
+		{"legacy bumped", legacyTx(100), legacyTx(110), 10, true},
+		{"legacy not bumped enough", legacyTx(100), legacyTx(109), 10, false},
+		{"legacy same price without bump", legacyTx(100), legacyTx(100), 0, false},
+		{"legacy higher price without bump", legacyTx(100), legacyTx(101), 0, true},
+		{"dynamic bumped", dynamicTx(1000, 100), dynamicTx(1100, 110), 10, true},
+		{"dynamic tip cap not bumped", dynamicTx(1000, 100), dynamicTx(2000, 105), 10, false},
+		{"dynamic fee cap not bumped", dynamicTx(1000, 100), dynamicTx(1050, 200), 10, false},
+		{"legacy replaced by dynamic", legacyTx(100), dynamicTx(110, 110), 10, true},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.expected, isReplacementPriced(c.old, c.tx, c.priceBump))
+		})
+	}
+}
+
 func Test_updateAccountSkipsCounts(t *testing.T) {
 	t.Parallel()
 
@@ -1711,12 +1965,12 @@ func Test_TxPool_validateTx(t *testing.T) {
 		return signedTx
 	}
 
-	t.Run("tx input larger than the TxPoolMaxInitCodeSize", func(t *testing.T) {
+	t.Run("tx input larger than the MaxInitCodeSize", func(t *testing.T) {
 		t.Parallel()
 		pool := setupPool()
 		pool.forks.EIP158 = true
 
-		input := make([]byte, state.TxPoolMaxInitCodeSize+1)
+		input := make([]byte, runtime.MaxInitCodeSize+1)
 		_, err := rand.Read(input)
 		require.NoError(t, err)
 
@@ -1730,12 +1984,12 @@ func Test_TxPool_validateTx(t *testing.T) {
 		)
 	})
 
-	t.Run("tx input the same as TxPoolMaxInitCodeSize", func(t *testing.T) {
+	t.Run("tx input the same as MaxInitCodeSize", func(t *testing.T) {
 		t.Parallel()
 		pool := setupPool()
 		pool.forks.EIP158 = true
 
-		input := make([]byte, state.TxPoolMaxInitCodeSize)
+		input := make([]byte, runtime.MaxInitCodeSize)
 		_, err := rand.Read(input)
 		require.NoError(t, err)
 
@@ -1873,6 +2127,63 @@ func Test_TxPool_validateTx(t *testing.T) {
 			ErrInvalidTxType,
 		)
 	})
+
+	t.Run("access list tx can pass", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool()
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.Type = types.AccessListTx
+		tx.To = &addr1
+		tx.Input = nil
+		tx.Gas = state.TxGas + state.TxAccessListAddressGas + state.TxAccessListStorageKeyGas
+		tx.AccessList = types.TxAccessList{
+			{
+				Address:     types.StringToAddress("0x1"),
+				StorageKeys: []types.Hash{types.StringToHash("0x1")},
+			},
+		}
+
+		assert.NoError(t, pool.validateTx(signTx(tx)))
+	})
+
+	t.Run("access list tx (not enough gas for access list)", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool()
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.Type = types.AccessListTx
+		tx.To = &addr1
+		tx.Input = nil
+		tx.Gas = state.TxGas
+		tx.AccessList = types.TxAccessList{
+			{
+				Address: types.StringToAddress("0x1"),
+			},
+		}
+
+		assert.ErrorIs(t,
+			pool.validateTx(signTx(tx)),
+			ErrIntrinsicGas,
+		)
+	})
+
+	t.Run("access list tx placed without berlin fork enabled", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool()
+		pool.forks.Berlin = false
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.Type = types.AccessListTx
+
+		assert.ErrorIs(t,
+			pool.validateTx(signTx(tx)),
+			ErrInvalidTxType,
+		)
+	})
 }
 
 /* "Integrated" tests */
@@ -2961,3 +3272,30 @@ func TestBatchTx_SingleAccount(t *testing.T) {
 		}
 	}
 }
+
+func TestSubscribeTxEvents(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+
+	eventCh, cancel := pool.SubscribeTxEvents(proto.EventType_PROMOTED)
+
+	hash := types.StringToHash("0x1")
+
+	pool.eventManager.signalEvent(proto.EventType_ENQUEUED, hash)
+	pool.eventManager.signalEvent(proto.EventType_PROMOTED, hash)
+
+	select {
+	case event := <-eventCh:
+		assert.Equal(t, proto.EventType_PROMOTED, event.Type)
+		assert.Equal(t, hash.String(), event.TxHash)
+	case <-time.After(5 * time.Second):
+		t.Fatal("promoted event not received")
+	}
+
+	cancel()
+
+	_, more := <-eventCh
+	assert.False(t, more)
+}
@@ -0,0 +1,198 @@
+package txrelayer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/jwt"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+// forwarderPathLength is the length (in bytes) of the random path of the local forwarder
+const forwarderPathLength = 32
+
+var errCredentialsNotSupported = errors.New("the credentials are supported only by the HTTP endpoints")
+
+// Credentials authenticate the JSON-RPC client to the server
+type Credentials struct {
+	// JWTSecret signs a new JWT token for each request (if set)
+	JWTSecret []byte
+
+	// TLSConfig is the TLS config of the connections to the server (the default one if not set)
+	TLSConfig *tls.Config
+}
+
+// empty reports whether none of the credentials is set
+func (c *Credentials) empty() bool {
+	return c == nil || (len(c.JWTSecret) == 0 && c.TLSConfig == nil)
+}
+
+// Client is the JSON-RPC client of an endpoint
+type Client struct {
+	*jsonrpc.Client
+
+	// forwarder forwards the requests of the authenticated client (nil if the client is not authenticated)
+	forwarder *http.Server
+}
+
+// Close closes the client along with its forwarder
+func (c *Client) Close() error {
+	if c.forwarder != nil {
+		if err := c.forwarder.Close(); err != nil {
+			return err
+		}
+	}
+
+	return c.Client.Close()
+}
+
+// NewClient creates the JSON-RPC client of the given endpoint, authenticated with the credentials (if set).
+// Since the JSON-RPC client supports neither the custom TLS configs nor the per request headers,
+// the requests of the authenticated client are sent through a forwarder on the loopback interface.
+// The forwarder is reachable only on a random path known to the client, and runs until the client is closed
+func NewClient(endpoint string, creds *Credentials) (*Client, error) {
+	if creds.empty() {
+		client, err := jsonrpc.NewClient(endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Client{Client: client}, nil
+	}
+
+	srv, forwarderAddr, err := startForwarder(endpoint, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := jsonrpc.NewClient(forwarderAddr)
+	if err != nil {
+		_ = srv.Close()
+
+		return nil, err
+	}
+
+	return &Client{Client: client, forwarder: srv}, nil
+}
+
+// forwarder forwards the JSON-RPC requests to the server, authenticating them with the credentials
+type forwarder struct {
+	endpoint string
+	secret   []byte
+	client   *http.Client
+}
+
+// startForwarder starts the forwarder of the requests to the given endpoint on the loopback interface,
+// and returns the forwarder server along with the address the requests are sent to
+func startForwarder(endpoint string, creds *Credentials) (*http.Server, string, error) {
+	switch {
+	case strings.HasPrefix(endpoint, "http://"), strings.HasPrefix(endpoint, "https://"):
+	case strings.Contains(endpoint, "://"):
+		return nil, "", errCredentialsNotSupported
+	case creds.TLSConfig != nil:
+		endpoint = "https://" + endpoint
+	default:
+		endpoint = "http://" + endpoint
+	}
+
+	rawPath := make([]byte, forwarderPathLength)
+	if _, err := rand.Read(rawPath); err != nil {
+		return nil, "", err
+	}
+
+	path := "/" + hex.EncodeToString(rawPath)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to start the JSON-RPC forwarder: %w", err)
+	}
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		transport = &http.Transport{}
+	}
+
+	transport = transport.Clone()
+	transport.TLSClientConfig = creds.TLSConfig
+
+	mux := http.NewServeMux()
+	mux.Handle(path, &forwarder{
+		endpoint: endpoint,
+		secret:   creds.JWTSecret,
+		client:   &http.Client{Transport: transport},
+	})
+
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 60 * time.Second,
+	}
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+
+	return srv, "http://" + lis.Addr().String() + path, nil
+}
+
+func (f *forwarder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	resp, err := f.forward(r)
+	if err != nil {
+		// the client expects a JSON-RPC response, so that the error is not lost
+		raw, _ := json.Marshal(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      0,
+			"error": map[string]interface{}{
+				"code":    -32603,
+				"message": err.Error(),
+			},
+		})
+
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write(raw)
+
+		return
+	}
+
+	defer resp.Body.Close()
+
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
+}
+
+// forward sends the request to the server, authenticated with a new JWT token (if the secret is set)
+func (f *forwarder) forward(r *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodPost, f.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if len(f.secret) > 0 {
+		token, err := jwt.NewToken(f.secret, time.Now(), 0)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return f.client.Do(req)
+}
@@ -0,0 +1,101 @@
+package txrelayer
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient_Credentials(t *testing.T) {
+	t.Parallel()
+
+	secret := make([]byte, jwt.SecretLength)
+	tokens := make(chan string, 2)
+
+	// JSON-RPC server over TLS, authenticating the requests with the JWT secret
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if err := jwt.Verify(token, secret, time.Now()); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32600,"message":"unauthorized"}}`)
+
+			return
+		}
+
+		tokens <- token
+
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"0x64"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	tlsConfig := &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+
+	t.Run("authenticated", func(t *testing.T) {
+		t.Parallel()
+
+		client, err := NewClient(server.URL, &Credentials{JWTSecret: secret, TLSConfig: tlsConfig})
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			chainID, err := client.Eth().ChainID()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(100), chainID.Uint64())
+		}
+
+		// the token is signed for each request, so that it never expires
+		first, second := <-tokens, <-tokens
+		assert.NotEmpty(t, first)
+		assert.NotEmpty(t, second)
+	})
+
+	t.Run("missing JWT secret", func(t *testing.T) {
+		t.Parallel()
+
+		client, err := NewClient(server.URL, &Credentials{TLSConfig: tlsConfig})
+		require.NoError(t, err)
+
+		_, err = client.Eth().ChainID()
+		assert.ErrorContains(t, err, "unauthorized")
+	})
+
+	t.Run("untrusted server certificate", func(t *testing.T) {
+		t.Parallel()
+
+		client, err := NewClient(server.URL, &Credentials{JWTSecret: secret})
+		require.NoError(t, err)
+
+		_, err = client.Eth().ChainID()
+		assert.ErrorContains(t, err, "certificate")
+	})
+
+	t.Run("closed client", func(t *testing.T) {
+		t.Parallel()
+
+		client, err := NewClient(server.URL, &Credentials{JWTSecret: secret, TLSConfig: tlsConfig})
+		require.NoError(t, err)
+		require.NoError(t, client.Close())
+
+		// the forwarder is stopped along with the client
+		_, err = client.Eth().ChainID()
+		assert.Error(t, err)
+	})
+
+	t.Run("websocket endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewClient("wss://127.0.0.1:8546", &Credentials{JWTSecret: secret})
+		assert.ErrorIs(t, err, errCredentialsNotSupported)
+	})
+}
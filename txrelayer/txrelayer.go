@@ -3,6 +3,8 @@ package txrelayer
 import (
 	"errors"
 	"fmt"
+	"net"
+	"strings"
 	"sync"
 	"time"
 
@@ -38,6 +40,7 @@ var _ TxRelayer = (*TxRelayerImpl)(nil)
 
 type TxRelayerImpl struct {
 	ipAddress      string
+	credentials    *Credentials
 	client         *jsonrpc.Client
 	receiptTimeout time.Duration
 
@@ -54,12 +57,14 @@ func NewTxRelayer(opts ...TxRelayerOption) (TxRelayer, error) {
 	}
 
 	if t.client == nil {
-		client, err := jsonrpc.NewClient(t.ipAddress)
+		// the client is not closed, since only the commands (running until the process exits) set the credentials;
+		// the long running components authenticated with the credentials pass their own client with WithClient
+		client, err := NewClient(t.ipAddress, t.credentials)
 		if err != nil {
 			return nil, err
 		}
 
-		t.client = client
+		t.client = client.Client
 	}
 
 	return t, nil
@@ -190,8 +195,30 @@ func WithIPAddress(ipAddress string) TxRelayerOption {
 	}
 }
 
+// WithCredentials authenticates the relayer to the JSON-RPC server with the given credentials (if set)
+func WithCredentials(credentials *Credentials) TxRelayerOption {
+	return func(t *TxRelayerImpl) {
+		t.credentials = credentials
+	}
+}
+
 func WithReceiptTimeout(receiptTimeout time.Duration) TxRelayerOption {
 	return func(t *TxRelayerImpl) {
 		t.receiptTimeout = receiptTimeout
 	}
 }
+
+// SanitizeRPCEndpoint returns the endpoint of the local JSON RPC server
+// if the given one is empty or listens on all interfaces
+func SanitizeRPCEndpoint(rpcEndpoint string) string {
+	if rpcEndpoint == "" || strings.Contains(rpcEndpoint, "0.0.0.0") {
+		_, port, err := net.SplitHostPort(rpcEndpoint)
+		if err == nil {
+			rpcEndpoint = fmt.Sprintf("http://%s:%s", "127.0.0.1", port)
+		} else {
+			rpcEndpoint = DefaultRPCAddress
+		}
+	}
+
+	return rpcEndpoint
+}
@@ -153,6 +153,7 @@ func TestRLPMarshall_And_Unmarshall_TypedTransaction(t *testing.T) {
 	txTypes := []TxType{
 		StateTx,
 		LegacyTx,
+		AccessListTx,
 		DynamicFeeTx,
 	}
 
@@ -173,6 +174,53 @@ func TestRLPMarshall_And_Unmarshall_TypedTransaction(t *testing.T) {
 	}
 }
 
+func TestRLPMarshall_And_Unmarshall_AccessList(t *testing.T) {
+	t.Parallel()
+
+	addrTo := StringToAddress("11")
+	accessList := TxAccessList{
+		{
+			Address:     StringToAddress("33"),
+			StorageKeys: []Hash{StringToHash("1"), StringToHash("2")},
+		},
+		{
+			Address:     StringToAddress("44"),
+			StorageKeys: []Hash{},
+		},
+	}
+
+	for _, txType := range []TxType{AccessListTx, DynamicFeeTx} {
+		txType := txType
+
+		t.Run(txType.String(), func(t *testing.T) {
+			t.Parallel()
+
+			originalTx := &Transaction{
+				Type:       txType,
+				GasPrice:   big.NewInt(11),
+				GasFeeCap:  big.NewInt(12),
+				GasTipCap:  big.NewInt(13),
+				Gas:        11,
+				To:         &addrTo,
+				Value:      big.NewInt(1),
+				Input:      []byte{1, 2},
+				AccessList: accessList,
+				V:          big.NewInt(1),
+				S:          big.NewInt(26),
+				R:          big.NewInt(27),
+			}
+			originalTx.ComputeHash()
+
+			unmarshalledTx := new(Transaction)
+			require.NoError(t, unmarshalledTx.UnmarshalRLP(originalTx.MarshalRLP()))
+
+			unmarshalledTx.ComputeHash()
+			assert.Equal(t, originalTx.Hash, unmarshalledTx.Hash)
+			assert.Equal(t, accessList, unmarshalledTx.AccessList)
+		})
+	}
+}
+
 func TestRLPMarshall_Unmarshall_Missing_Data(t *testing.T) {
 	t.Parallel()
 
@@ -400,6 +400,8 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		num = 10
 	case DynamicFeeTx:
 		num = 12
+	case AccessListTx:
+		num = 11
 	default:
 		return fmt.Errorf("transaction type %d not found", t.Type)
 	}
@@ -413,7 +415,7 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 	// Skipping Chain ID field since we don't support it (yet)
 	// This is needed to be compatible with other EVM chains and have the same format.
 	// Since we don't have a chain ID, just skip it here.
-	if t.Type == DynamicFeeTx {
+	if t.Type.HasAccessList() {
 		_ = getElem()
 	}
 
@@ -468,11 +470,13 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return err
 	}
 
-	// Skipping Access List field since we don't support it.
-	// This is needed to be compatible with other EVM chains and have the same format.
-	// Since we don't have access list, just skip it here.
-	if t.Type == DynamicFeeTx {
-		_ = getElem()
+	// access list
+	if t.Type.HasAccessList() {
+		if err = t.AccessList.unmarshalRLPFrom(p, getElem()); err != nil {
+			return err
+		}
+	} else {
+		t.AccessList = nil
 	}
 
 	// V
@@ -506,3 +510,59 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 
 	return nil
 }
+
+// unmarshalRLPFrom unmarshals an access list in RLP format
+func (al *TxAccessList) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	accessList := make(TxAccessList, len(elems))
+
+	for i, elem := range elems {
+		tuple, err := elem.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if numElems := len(tuple); numElems != 2 {
+			return fmt.Errorf("incorrect number of access tuple elements, expected 2 but found %d", numElems)
+		}
+
+		addr, err := tuple[0].Bytes()
+		if err != nil {
+			return err
+		}
+
+		if len(addr) != AddressLength {
+			return fmt.Errorf("incorrect access tuple address length, expected %d but found %d", AddressLength, len(addr))
+		}
+
+		accessList[i].Address = BytesToAddress(addr)
+
+		keys, err := tuple[1].GetElems()
+		if err != nil {
+			return err
+		}
+
+		accessList[i].StorageKeys = make([]Hash, len(keys))
+
+		for j, key := range keys {
+			keyBytes, err := key.Bytes()
+			if err != nil {
+				return err
+			}
+
+			if len(keyBytes) != HashLength {
+				return fmt.Errorf("incorrect access tuple storage key length, expected %d but found %d", HashLength, len(keyBytes))
+			}
+
+			accessList[i].StorageKeys[j] = BytesToHash(keyBytes)
+		}
+	}
+
+	*al = accessList
+
+	return nil
+}
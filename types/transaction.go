@@ -22,13 +22,14 @@ const (
 	LegacyTx     TxType = 0x0
 	StateTx      TxType = 0x7f
 	DynamicFeeTx TxType = 0x02
+	AccessListTx TxType = 0x01
 )
 
 func txTypeFromByte(b byte) (TxType, error) {
 	tt := TxType(b)
 
 	switch tt {
-	case LegacyTx, StateTx, DynamicFeeTx:
+	case LegacyTx, StateTx, DynamicFeeTx, AccessListTx:
 		return tt, nil
 	default:
 		return tt, fmt.Errorf("unknown transaction type: %d", b)
@@ -44,11 +45,55 @@ func (t TxType) String() (s string) {
 		return "StateTx"
 	case DynamicFeeTx:
 		return "DynamicFeeTx"
+	case AccessListTx:
+		return "AccessListTx"
 	}
 
 	return
 }
 
+// HasAccessList returns true if the transaction type carries an EIP-2930 access list
+func (t TxType) HasAccessList() bool {
+	return t == AccessListTx || t == DynamicFeeTx
+}
+
+// AccessTuple is the element type of an access list
+type AccessTuple struct {
+	Address     Address `json:"address"`
+	StorageKeys []Hash  `json:"storageKeys"`
+}
+
+// TxAccessList is an EIP-2930 access list
+type TxAccessList []AccessTuple
+
+// StorageKeys returns the total number of storage keys in the access list
+func (al TxAccessList) StorageKeys() int {
+	sum := 0
+	for _, tuple := range al {
+		sum += len(tuple.StorageKeys)
+	}
+
+	return sum
+}
+
+// Copy returns a deep copy of the access list
+func (al TxAccessList) Copy() TxAccessList {
+	if al == nil {
+		return nil
+	}
+
+	cpy := make(TxAccessList, len(al))
+
+	for i, tuple := range al {
+		cpy[i] = AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append([]Hash{}, tuple.StorageKeys...),
+		}
+	}
+
+	return cpy
+}
+
 type Transaction struct {
 	Nonce     uint64
 	GasPrice  *big.Int
@@ -64,6 +109,8 @@ type Transaction struct {
 
 	Type TxType
 
+	AccessList TxAccessList
+
 	// Cache
 	size atomic.Pointer[uint64]
 }
@@ -124,6 +171,8 @@ func (t *Transaction) Copy() *Transaction {
 	tt.Input = make([]byte, len(t.Input))
 	copy(tt.Input[:], t.Input[:])
 
+	tt.AccessList = t.AccessList.Copy()
+
 	return tt
 }
 
@@ -193,6 +193,41 @@ type Proof struct {
 	Metadata map[string]interface{}
 }
 
+// BridgeEventType is the kind of the message bridged between the rootchain and the child chain
+type BridgeEventType string
+
+const (
+	// StateSyncBridgeEvent is a state sync sent from the rootchain to the child chain
+	StateSyncBridgeEvent BridgeEventType = "stateSync"
+	// ExitBridgeEvent is an exit sent from the child chain to the rootchain
+	ExitBridgeEvent BridgeEventType = "exit"
+)
+
+// BridgeEventStatus is the progress of a bridged message
+type BridgeEventStatus string
+
+const (
+	// BridgeEventPending is the status of an event not yet committed nor checkpointed
+	BridgeEventPending BridgeEventStatus = "pending"
+	// BridgeEventCommitted is the status of a state sync included in a commitment submitted to the child chain
+	BridgeEventCommitted BridgeEventStatus = "committed"
+	// BridgeEventExecuted is the status of a state sync executed on the child chain
+	BridgeEventExecuted BridgeEventStatus = "executed"
+	// BridgeEventCheckpointed is the status of an exit included in a checkpoint submitted to the rootchain
+	BridgeEventCheckpointed BridgeEventStatus = "checkpointed"
+	// BridgeEventExited is the status of an exit processed on the rootchain
+	BridgeEventExited BridgeEventStatus = "exited"
+)
+
+// BridgeEvent is a state sync or an exit indexed by the node
+type BridgeEvent struct {
+	Type     BridgeEventType
+	ID       uint64
+	Sender   Address
+	Receiver Address
+	Status   BridgeEventStatus
+}
+
 type OverrideAccount struct {
 	Nonce     *uint64
 	Code      []byte